@@ -0,0 +1,195 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package batchverify implements batch verification of Ed25519
+// signatures, using random linear combinations of the verification
+// equations.
+//
+// Batch verification uses the cofactored verification equation
+// (`[8][S]B = [8]R + [8][k]A`), as the cofactorless equation used by
+// crypto/ed25519 can not be batched consistently.  When a batch fails to
+// verify, each signature is verified individually with the same
+// cofactored equation, so the per-signature results do not depend on
+// the randomness, or on the other signatures in the batch.  For
+// signatures produced by honest signers, the results are identical to
+// crypto/ed25519.
+package batchverify
+
+import (
+	"crypto/ed25519"
+	cryptorand "crypto/rand"
+	"crypto/sha512"
+	"io"
+
+	"filippo.io/edwards25519"
+)
+
+// zSize is the size of the random coefficients in bytes.
+const zSize = 16
+
+// Verifier is a batch signature verifier.
+type Verifier struct {
+	entries []entry
+}
+
+type entry struct {
+	A edwards25519.Point
+	R edwards25519.Point
+	s edwards25519.Scalar
+	k edwards25519.Scalar
+
+	malformed bool
+}
+
+// NewVerifier creates a new, empty batch verifier.
+func NewVerifier() *Verifier {
+	return &Verifier{}
+}
+
+// Add adds a (public key, message, signature) triple to the batch.
+func (v *Verifier) Add(pk ed25519.PublicKey, message, sig []byte) {
+	var e entry
+	e.malformed = !e.set(pk, message, sig)
+	v.entries = append(v.entries, e)
+}
+
+// Len returns the number of signatures in the batch.
+func (v *Verifier) Len() int {
+	return len(v.entries)
+}
+
+// Verify verifies the batch, using entropy from rand (or
+// crypto/rand.Reader if nil) for the random coefficients, and returns
+// true iff all of the signatures are valid, along with the validity of
+// each signature, in the order they were added.  If reading from rand
+// fails, each signature is verified individually.
+func (v *Verifier) Verify(rand io.Reader) (bool, []bool) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	valid := make([]bool, len(v.entries))
+	if v.verifyBatch(rand) {
+		for i := range valid {
+			valid[i] = true
+		}
+		return true, valid
+	}
+
+	// Fall back to verifying each signature individually, to find
+	// the invalid ones.
+	allValid := true
+	for i := range v.entries {
+		valid[i] = v.entries[i].verify()
+		allValid = allValid && valid[i]
+	}
+	return allValid, valid
+}
+
+func (v *Verifier) verifyBatch(rand io.Reader) bool {
+	n := len(v.entries)
+	if n == 0 {
+		return true
+	}
+
+	// [8] (-(sum z_i s_i) B + sum z_i R_i + sum (z_i k_i) A_i) = 0
+	scalars := make([]*edwards25519.Scalar, 0, 1+2*n)
+	points := make([]*edwards25519.Point, 0, 1+2*n)
+
+	bCoeff := edwards25519.NewScalar()
+	scalars = append(scalars, bCoeff)
+	points = append(points, edwards25519.NewGeneratorPoint())
+
+	var zBytes [64]byte
+	zs := make([]edwards25519.Scalar, 2*n)
+	for i := range v.entries {
+		e := &v.entries[i]
+		if e.malformed {
+			return false
+		}
+
+		if _, err := io.ReadFull(rand, zBytes[:zSize]); err != nil {
+			return false
+		}
+		z, zk := &zs[2*i], &zs[2*i+1]
+		if _, err := z.SetUniformBytes(zBytes[:]); err != nil {
+			panic("batchverify: failed to deserialize z: " + err.Error())
+		}
+		zk.Multiply(z, &e.k)
+		bCoeff.MultiplyAdd(z, &e.s, bCoeff)
+
+		scalars = append(scalars, z, zk)
+		points = append(points, &e.R, &e.A)
+	}
+	bCoeff.Negate(bCoeff)
+
+	check := edwards25519.NewIdentityPoint().VarTimeMultiScalarMult(scalars, points)
+	return check.MultByCofactor(check).Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+func (e *entry) set(pk ed25519.PublicKey, message, sig []byte) bool {
+	if len(pk) != ed25519.PublicKeySize || len(sig) != ed25519.SignatureSize {
+		return false
+	}
+	if _, err := e.A.SetBytes(pk); err != nil {
+		return false
+	}
+	if _, err := e.R.SetBytes(sig[:32]); err != nil {
+		return false
+	}
+	if _, err := e.s.SetCanonicalBytes(sig[32:]); err != nil {
+		return false
+	}
+
+	// k = SHA-512(R || A || M) mod l
+	var digest [64]byte
+	h := sha512.New()
+	_, _ = h.Write(sig[:32])
+	_, _ = h.Write(pk)
+	_, _ = h.Write(message)
+	h.Sum(digest[:0])
+	if _, err := e.k.SetUniformBytes(digest[:]); err != nil {
+		panic("batchverify: failed to deserialize k: " + err.Error())
+	}
+
+	return true
+}
+
+func (e *entry) verify() bool {
+	if e.malformed {
+		return false
+	}
+
+	// [8] ([S]B - R - [k]A) = 0
+	var minusA edwards25519.Point
+	minusA.Negate(&e.A)
+	check := edwards25519.NewIdentityPoint().VarTimeDoubleScalarBaseMult(&e.k, &minusA, &e.s)
+	check.Subtract(check, &e.R)
+	return check.MultByCofactor(check).Equal(edwards25519.NewIdentityPoint()) == 1
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package batchverify
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+type errorReader struct{}
+
+func (errorReader) Read([]byte) (int, error) {
+	return 0, errors.New("batchverify: test entropy failure")
+}
+
+func newTestBatch(t *testing.T, n int) (*Verifier, []ed25519.PublicKey, [][]byte, [][]byte) {
+	v := NewVerifier()
+	pks := make([]ed25519.PublicKey, 0, n)
+	msgs := make([][]byte, 0, n)
+	sigs := make([][]byte, 0, n)
+	for i := 0; i < n; i++ {
+		pk, sk, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("ed25519.GenerateKey: %v", err)
+		}
+		msg := []byte(fmt.Sprintf("test message %d", i))
+		sig := ed25519.Sign(sk, msg)
+
+		v.Add(pk, msg, sig)
+		pks = append(pks, pk)
+		msgs = append(msgs, msg)
+		sigs = append(sigs, sig)
+	}
+	return v, pks, msgs, sigs
+}
+
+func checkResults(t *testing.T, allValid bool, valid []bool, expected []bool) {
+	expectedAllValid := true
+	for _, ok := range expected {
+		expectedAllValid = expectedAllValid && ok
+	}
+	if allValid != expectedAllValid {
+		t.Fatalf("Verify: allValid = %v, expected %v", allValid, expectedAllValid)
+	}
+	if len(valid) != len(expected) {
+		t.Fatalf("Verify: len(valid) = %d, expected %d", len(valid), len(expected))
+	}
+	for i := range expected {
+		if valid[i] != expected[i] {
+			t.Fatalf("Verify: valid[%d] = %v, expected %v", i, valid[i], expected[i])
+		}
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	const batchSize = 38
+
+	t.Run("Empty", func(t *testing.T) {
+		allValid, valid := NewVerifier().Verify(rand.Reader)
+		checkResults(t, allValid, valid, []bool{})
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		for _, n := range []int{1, 2, batchSize} {
+			v, _, _, _ := newTestBatch(t, n)
+			if v.Len() != n {
+				t.Fatalf("Len: %d, expected %d", v.Len(), n)
+			}
+
+			expected := make([]bool, n)
+			for i := range expected {
+				expected[i] = true
+			}
+			allValid, valid := v.Verify(rand.Reader)
+			checkResults(t, allValid, valid, expected)
+		}
+	})
+
+	t.Run("NilRand", func(t *testing.T) {
+		v, _, _, _ := newTestBatch(t, batchSize)
+		expected := make([]bool, batchSize)
+		for i := range expected {
+			expected[i] = true
+		}
+		allValid, valid := v.Verify(nil)
+		checkResults(t, allValid, valid, expected)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		v, pks, msgs, sigs := newTestBatch(t, batchSize)
+
+		// Append a handful of bad entries.
+		badSig := append([]byte{}, sigs[0]...)
+		badSig[0] ^= 0x69
+		v.Add(pks[0], msgs[0], badSig)                  // Bad R.
+		v.Add(pks[1], []byte("wrong message"), sigs[1]) // Bad message.
+		v.Add(pks[2], msgs[3], sigs[3])                 // Wrong key.
+		v.Add(pks[4][:31], msgs[4], sigs[4])            // Truncated key.
+		v.Add(pks[5], msgs[5], sigs[5][:63])            // Truncated signature.
+
+		// Non-canonical S (S + l).
+		nonCanonicalSig := append([]byte{}, sigs[6]...)
+		addL(nonCanonicalSig[32:])
+		v.Add(pks[6], msgs[6], nonCanonicalSig)
+
+		expected := make([]bool, v.Len())
+		for i := 0; i < batchSize; i++ {
+			expected[i] = true
+		}
+		allValid, valid := v.Verify(rand.Reader)
+		checkResults(t, allValid, valid, expected)
+
+		// The individual results must agree with the standard library.
+		for i := 0; i < batchSize; i++ {
+			if !ed25519.Verify(pks[i], msgs[i], sigs[i]) {
+				t.Fatalf("ed25519.Verify: failed for signature %d", i)
+			}
+		}
+	})
+
+	t.Run("EntropyFailure", func(t *testing.T) {
+		v, pks, _, sigs := newTestBatch(t, batchSize)
+		v.Add(pks[0], []byte("wrong message"), sigs[0])
+
+		expected := make([]bool, v.Len())
+		for i := 0; i < batchSize; i++ {
+			expected[i] = true
+		}
+		allValid, valid := v.Verify(errorReader{})
+		checkResults(t, allValid, valid, expected)
+	})
+
+	t.Run("SmallOrderR", func(t *testing.T) {
+		// A signature with a small order component added to R is
+		// rejected by the cofactorless equation used by the standard
+		// library, but accepted by the cofactored equation.  The
+		// batch and fallback paths must agree.
+		pk, sk, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("ed25519.GenerateKey: %v", err)
+		}
+		msg := []byte("small order R")
+		sig := signWithTorsion(t, sk, msg)
+
+		if ed25519.Verify(pk, msg, sig) {
+			t.Fatalf("ed25519.Verify: accepted signature with small order R component")
+		}
+
+		v, _, _, _ := newTestBatch(t, 3)
+		v.Add(pk, msg, sig)
+		expected := []bool{true, true, true, true}
+		allValid, valid := v.Verify(rand.Reader)
+		checkResults(t, allValid, valid, expected)
+
+		v.Add(pk, []byte("wrong message"), sig)
+		expected = append(expected, false)
+		allValid, valid = v.Verify(rand.Reader)
+		checkResults(t, allValid, valid, expected)
+	})
+}
+
+// addL adds l to the little-endian 256-bit integer in b.
+func addL(b []byte) {
+	l := [32]byte{
+		0xed, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58,
+		0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+	}
+	var carry uint16
+	for i := range b {
+		sum := uint16(b[i]) + uint16(l[i]) + carry
+		b[i] = byte(sum)
+		carry = sum >> 8
+	}
+}
+
+// signWithTorsion signs message with sk, adding a point of order 8 to R.
+func signWithTorsion(t *testing.T, sk ed25519.PrivateKey, message []byte) []byte {
+	torsionBytes, _ := hex.DecodeString("c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a")
+	torsion, err := new(edwards25519.Point).SetBytes(torsionBytes)
+	if err != nil {
+		t.Fatalf("SetBytes(torsion): %v", err)
+	}
+	if torsion.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		t.Fatalf("torsion point is the identity")
+	}
+	if check := new(edwards25519.Point).MultByCofactor(torsion); check.Equal(edwards25519.NewIdentityPoint()) != 1 {
+		t.Fatalf("torsion point is not of small order")
+	}
+
+	h := sha512.Sum512(sk.Seed())
+	a, err := new(edwards25519.Scalar).SetBytesWithClamping(h[:32])
+	if err != nil {
+		t.Fatalf("SetBytesWithClamping: %v", err)
+	}
+
+	var rBytes [64]byte
+	if _, err = rand.Read(rBytes[:]); err != nil {
+		t.Fatalf("rand.Read: %v", err)
+	}
+	r, err := new(edwards25519.Scalar).SetUniformBytes(rBytes[:])
+	if err != nil {
+		t.Fatalf("SetUniformBytes: %v", err)
+	}
+	R := new(edwards25519.Point).ScalarBaseMult(r)
+	R.Add(R, torsion)
+	encodedR := R.Bytes()
+
+	kh := sha512.New()
+	_, _ = kh.Write(encodedR)
+	_, _ = kh.Write(sk.Public().(ed25519.PublicKey))
+	_, _ = kh.Write(message)
+	k, err := new(edwards25519.Scalar).SetUniformBytes(kh.Sum(nil))
+	if err != nil {
+		t.Fatalf("SetUniformBytes: %v", err)
+	}
+	s := new(edwards25519.Scalar).MultiplyAdd(k, a, r)
+
+	return append(encodedR, s.Bytes()...)
+}
+
+func BenchmarkVerifier(b *testing.B) {
+	for _, n := range []int{1, 8, 64} {
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			v := NewVerifier()
+			for i := 0; i < n; i++ {
+				pk, sk, _ := ed25519.GenerateKey(rand.Reader)
+				msg := []byte("benchmark message")
+				v.Add(pk, msg, ed25519.Sign(sk, msg))
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if allValid, _ := v.Verify(rand.Reader); !allValid {
+					b.Fatalf("Verify: failed")
+				}
+			}
+		})
+	}
+}
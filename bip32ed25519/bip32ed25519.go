@@ -0,0 +1,401 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package bip32ed25519 implements the BIP32-Ed25519 hierarchical
+// deterministic key derivation scheme by Khovratovich and Law, as used
+// by Cardano (derivation scheme "V2", CIP-3), including non-hardened
+// derivation of child public keys from a parent public key.
+//
+// Extended private keys are `kL || kR` where kL is the (unreduced)
+// secret scalar and kR is the nonce prefix, along with a chain code.
+// Signatures are ordinary Ed25519 signatures under the public key
+// `kL * B`.
+//
+// Unlike SLIP-0010 (see the slip10 package), the derived private keys
+// are not RFC 8032 seeds, and can not be represented as
+// crypto/ed25519.PrivateKey values.
+package bip32ed25519
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"filippo.io/edwards25519"
+	"golang.org/x/crypto/pbkdf2"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/signing"
+)
+
+const (
+	// HardenedOffset is the offset added to an index to make it a
+	// hardened index.
+	HardenedOffset uint32 = 0x80000000
+
+	// ChainCodeSize is the size of a chain code in bytes.
+	ChainCodeSize = 32
+
+	// PrivateKeySize is the size of a serialized extended private key
+	// (`kL || kR || chain code`) in bytes.
+	PrivateKeySize = 32 + 32 + ChainCodeSize
+
+	// PublicKeySize is the size of a serialized extended public key
+	// (`A || chain code`) in bytes.
+	PublicKeySize = ed25519.PublicKeySize + ChainCodeSize
+
+	icarusIterations = 4096
+
+	tagZHardened     = 0x00
+	tagCCHardened    = 0x01
+	tagZNonHardened  = 0x02
+	tagCCNonHardened = 0x03
+)
+
+var (
+	// ErrInvalidKey is the error returned when a serialized key is
+	// malformed.
+	ErrInvalidKey = errors.New("bip32ed25519: invalid key")
+
+	// ErrHardenedIndex is the error returned when attempting to derive
+	// a hardened child public key from a public key.
+	ErrHardenedIndex = errors.New("bip32ed25519: hardened index")
+
+	// ErrInvalidPath is the error returned when a derivation path is
+	// malformed.
+	ErrInvalidPath = errors.New("bip32ed25519: invalid path")
+)
+
+// PrivateKey is an extended private key.
+type PrivateKey struct {
+	kL        [32]byte
+	kR        [32]byte
+	chainCode [ChainCodeSize]byte
+}
+
+// PublicKey is an extended public key.
+type PublicKey struct {
+	a         [ed25519.PublicKeySize]byte
+	chainCode [ChainCodeSize]byte
+}
+
+// NewMasterKeyIcarus derives the master extended private key from the
+// wallet entropy (eg: the BIP-39 mnemonic entropy) and the optional
+// passphrase, as per the CIP-3 "Icarus" master key generation.
+func NewMasterKeyIcarus(entropy, passphrase []byte) *PrivateKey {
+	// data = PBKDF2(HMAC-SHA512, passphrase, entropy, 4096, 96)
+	data := pbkdf2.Key(passphrase, entropy, icarusIterations, PrivateKeySize, sha512.New)
+	defer func() {
+		for i := range data {
+			data[i] = 0
+		}
+	}()
+
+	data[0] &= 0b1111_1000
+	data[31] &= 0b0001_1111
+	data[31] |= 0b0100_0000
+
+	k, err := NewPrivateKey(data)
+	if err != nil {
+		panic("bip32ed25519: failed to deserialize master key: " + err.Error())
+	}
+	return k
+}
+
+// NewPrivateKey deserializes an extended private key.
+func NewPrivateKey(b []byte) (*PrivateKey, error) {
+	if len(b) != PrivateKeySize {
+		return nil, fmt.Errorf("%w: invalid length: %d", ErrInvalidKey, len(b))
+	}
+
+	// The lowest 3 bits of kL must be cleared, the highest bit cleared,
+	// and the second highest bit set.  The third highest bit is only
+	// guaranteed to be cleared for master keys.
+	if b[0]&0b0000_0111 != 0 || b[31]&0b1100_0000 != 0b0100_0000 {
+		return nil, fmt.Errorf("%w: malformed kL", ErrInvalidKey)
+	}
+
+	var k PrivateKey
+	copy(k.kL[:], b[0:32])
+	copy(k.kR[:], b[32:64])
+	copy(k.chainCode[:], b[64:])
+
+	return &k, nil
+}
+
+// Bytes returns the PrivateKeySize-byte serialized extended private key.
+func (k *PrivateKey) Bytes() []byte {
+	b := make([]byte, 0, PrivateKeySize)
+	b = append(b, k.kL[:]...)
+	b = append(b, k.kR[:]...)
+	b = append(b, k.chainCode[:]...)
+	return b
+}
+
+// Public returns the extended public key.
+func (k *PrivateKey) Public() *PublicKey {
+	var pk PublicKey
+	copy(pk.a[:], edwards25519.NewIdentityPoint().ScalarBaseMult(k.scalar()).Bytes())
+	pk.chainCode = k.chainCode
+	return &pk
+}
+
+// Derive derives the child extended private key with the index, which
+// is hardened iff it is >= HardenedOffset.
+func (k *PrivateKey) Derive(index uint32) *PrivateKey {
+	var z, cc []byte
+	if index >= HardenedOffset {
+		// Z = HMAC-SHA512(c, 0x00 || kL || kR || LE32(i))
+		// c_i = HMAC-SHA512(c, 0x01 || kL || kR || LE32(i))[32:]
+		var data [1 + 64 + 4]byte
+		copy(data[1:], k.kL[:])
+		copy(data[33:], k.kR[:])
+		binary.LittleEndian.PutUint32(data[65:], index)
+
+		data[0] = tagZHardened
+		z = k.mac(data[:])
+		data[0] = tagCCHardened
+		cc = k.mac(data[:])
+
+		for i := range data {
+			data[i] = 0
+		}
+	} else {
+		// Z = HMAC-SHA512(c, 0x02 || A || LE32(i))
+		// c_i = HMAC-SHA512(c, 0x03 || A || LE32(i))[32:]
+		z, cc = k.Public().nonHardenedMACs(index)
+	}
+
+	var child PrivateKey
+
+	// kL_i = 8 * ZL + kL, where ZL is the first 28 bytes of Z
+	var zL8 [32]byte
+	mulEight(&zL8, z[:28])
+	add256(&child.kL, &k.kL, &zL8)
+
+	// kR_i = ZR + kR mod 2^256
+	var zR [32]byte
+	copy(zR[:], z[32:])
+	add256(&child.kR, &k.kR, &zR)
+
+	copy(child.chainCode[:], cc[32:])
+
+	for i := range z {
+		z[i] = 0
+	}
+
+	return &child
+}
+
+// DerivePath derives the descendant extended private key along the
+// path.
+func (k *PrivateKey) DerivePath(path []uint32) *PrivateKey {
+	for _, index := range path {
+		k = k.Derive(index)
+	}
+	return k
+}
+
+// Sign signs the message with the extended private key.  The signature
+// verifies under the public key with ed25519.Verify.
+func (k *PrivateKey) Sign(message []byte) []byte {
+	s := k.scalar()
+	A := edwards25519.NewIdentityPoint().ScalarBaseMult(s).Bytes()
+	return signing.Sign(s, k.kR[:], A, message)
+}
+
+func (k *PrivateKey) scalar() *edwards25519.Scalar {
+	var wide [64]byte
+	copy(wide[:], k.kL[:])
+	s, err := edwards25519.NewScalar().SetUniformBytes(wide[:])
+	if err != nil {
+		panic("bip32ed25519: failed to deserialize kL: " + err.Error())
+	}
+	for i := range wide {
+		wide[i] = 0
+	}
+	return s
+}
+
+func (k *PrivateKey) mac(data []byte) []byte {
+	mac := hmac.New(sha512.New, k.chainCode[:])
+	_, _ = mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// NewPublicKey deserializes an extended public key.
+func NewPublicKey(b []byte) (*PublicKey, error) {
+	if len(b) != PublicKeySize {
+		return nil, fmt.Errorf("%w: invalid length: %d", ErrInvalidKey, len(b))
+	}
+	if _, err := edwards25519.NewIdentityPoint().SetBytes(b[:ed25519.PublicKeySize]); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+
+	var pk PublicKey
+	copy(pk.a[:], b[:ed25519.PublicKeySize])
+	copy(pk.chainCode[:], b[ed25519.PublicKeySize:])
+
+	return &pk, nil
+}
+
+// Bytes returns the PublicKeySize-byte serialized extended public key.
+func (pk *PublicKey) Bytes() []byte {
+	b := make([]byte, 0, PublicKeySize)
+	b = append(b, pk.a[:]...)
+	b = append(b, pk.chainCode[:]...)
+	return b
+}
+
+// Key returns the Ed25519 public key.
+func (pk *PublicKey) Key() ed25519.PublicKey {
+	return append(ed25519.PublicKey{}, pk.a[:]...)
+}
+
+// ChainCode returns the chain code.
+func (pk *PublicKey) ChainCode() []byte {
+	return append([]byte{}, pk.chainCode[:]...)
+}
+
+// Derive derives the child extended public key with the non-hardened
+// index (< HardenedOffset).
+func (pk *PublicKey) Derive(index uint32) (*PublicKey, error) {
+	if index >= HardenedOffset {
+		return nil, fmt.Errorf("%w: %d", ErrHardenedIndex, index)
+	}
+
+	A, err := edwards25519.NewIdentityPoint().SetBytes(pk.a[:])
+	if err != nil {
+		panic("bip32ed25519: failed to deserialize public key: " + err.Error())
+	}
+
+	z, cc := pk.nonHardenedMACs(index)
+
+	// A_i = A + (8 * ZL) * B
+	var zL8 [32]byte
+	mulEight(&zL8, z[:28])
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(zL8[:])
+	if err != nil {
+		panic("bip32ed25519: failed to deserialize 8 * ZL: " + err.Error())
+	}
+	A.Add(A, edwards25519.NewIdentityPoint().ScalarBaseMult(s))
+
+	var child PublicKey
+	copy(child.a[:], A.Bytes())
+	copy(child.chainCode[:], cc[32:])
+
+	return &child, nil
+}
+
+// DerivePath derives the descendant extended public key along the path
+// of non-hardened indexes.
+func (pk *PublicKey) DerivePath(path []uint32) (*PublicKey, error) {
+	var err error
+	for _, index := range path {
+		if pk, err = pk.Derive(index); err != nil {
+			return nil, err
+		}
+	}
+	return pk, nil
+}
+
+func (pk *PublicKey) nonHardenedMACs(index uint32) ([]byte, []byte) {
+	var data [1 + ed25519.PublicKeySize + 4]byte
+	copy(data[1:], pk.a[:])
+	binary.LittleEndian.PutUint32(data[1+ed25519.PublicKeySize:], index)
+
+	mac := hmac.New(sha512.New, pk.chainCode[:])
+	data[0] = tagZNonHardened
+	_, _ = mac.Write(data[:])
+	z := mac.Sum(nil)
+
+	mac.Reset()
+	data[0] = tagCCNonHardened
+	_, _ = mac.Write(data[:])
+	cc := mac.Sum(nil)
+
+	return z, cc
+}
+
+// ParsePath parses a derivation path of the form "m/1852'/1815'/0'/0/0",
+// where hardened indexes are suffixed with "'", "H" or "h".
+func ParsePath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("%w: missing master key", ErrInvalidPath)
+	}
+
+	indexes := make([]uint32, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		trimmed := strings.TrimRight(segment, "'Hh")
+		hardened := len(segment) != len(trimmed)
+		if len(segment)-len(trimmed) > 1 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPath, segment)
+		}
+		index, err := strconv.ParseUint(trimmed, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidPath, segment, err)
+		}
+		if hardened {
+			index += uint64(HardenedOffset)
+		}
+		indexes = append(indexes, uint32(index))
+	}
+
+	return indexes, nil
+}
+
+// mulEight sets out to 8 * x, where x is a little-endian integer of at
+// most 28 bytes.
+func mulEight(out *[32]byte, x []byte) {
+	var carry byte
+	for i := range out {
+		var b byte
+		if i < len(x) {
+			b = x[i]
+		}
+		out[i] = b<<3 | carry
+		carry = b >> 5
+	}
+}
+
+// add256 sets out to x + y mod 2^256, where x and y are little-endian
+// integers.
+func add256(out, x, y *[32]byte) {
+	var carry uint16
+	for i := range out {
+		sum := uint16(x[i]) + uint16(y[i]) + carry
+		out[i] = byte(sum)
+		carry = sum >> 8
+	}
+}
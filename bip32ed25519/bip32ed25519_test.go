@@ -0,0 +1,185 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package bip32ed25519
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func mustUnhex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func mustParsePath(s string) []uint32 {
+	path, err := ParsePath(s)
+	if err != nil {
+		panic(err)
+	}
+	return path
+}
+
+func TestBIP32Ed25519(t *testing.T) {
+	master := NewMasterKeyIcarus(mustUnhex("000102030405060708090a0b0c0d0e0f"), nil)
+
+	t.Run("Icarus", func(t *testing.T) {
+		// From CIP-3, "Icarus" master key generation test vectors.
+		entropy := mustUnhex("46e62370a138a182a498b8e2885bc032379ddf38")
+		for _, v := range []struct {
+			passphrase string
+			expected   string
+		}{
+			{"", "c065afd2832cd8b087c4d9ab7011f481ee1e0721e78ea5dd609f3ab3f156d245d176bd8fd4ec60b4731c3918a2a72a0226c0cd119ec35b47e4d55884667f552a23f7fdcd4a10c6cd2c7393ac61d877873e248f417634aa3d812af327ffe9d620"},
+			{"foo", "70531039904019351e1afb361cd1b312a4d0565d4ff9f8062d38acf4b15cce41d7b5738d9c893feea55512a3004acb0d222c35d3e3d5cde943a15a9824cbac59443cf67e589614076ba01e354b1a432e0e6db3b59e37fc56b5fb0222970a010e"},
+		} {
+			k := NewMasterKeyIcarus(entropy, []byte(v.passphrase))
+			if got := hex.EncodeToString(k.Bytes()); got != v.expected {
+				t.Fatalf("NewMasterKeyIcarus(%q): got %s", v.passphrase, got)
+			}
+		}
+	})
+	t.Run("CIP19", func(t *testing.T) {
+		// From the CIP-19 test vectors, the payment verification key
+		// (addr_vk1w0l2sr2zgfm26ztc6nl9xy8ghsk5sh6ldwemlpmp9xylzy4dtf7st80zhd)
+		// of the mnemonic "test walk nut penalty hip pave soap entry
+		// language right filter choice".
+		const expected = "73fea80d424276ad0978d4fe5310e8bc2d485f5f6bb3bf87612989f112ad5a7d"
+
+		root := NewMasterKeyIcarus(mustUnhex("df9ed25ed146bf43336a5d7cf7395994"), nil)
+		k := root.DerivePath(mustParsePath("m/1852'/1815'/0'/0/0"))
+		if got := hex.EncodeToString(k.Public().Key()); got != expected {
+			t.Fatalf("DerivePath: got public key %s", got)
+		}
+
+		accountPk := root.DerivePath(mustParsePath("m/1852'/1815'/0'")).Public()
+		pk, err := accountPk.DerivePath(mustParsePath("m/0/0"))
+		if err != nil {
+			t.Fatalf("PublicKey.DerivePath: %v", err)
+		}
+		if got := hex.EncodeToString(pk.Key()); got != expected {
+			t.Fatalf("PublicKey.DerivePath: got public key %s", got)
+		}
+	})
+	t.Run("PublicDerivation", func(t *testing.T) {
+		account := master.DerivePath(mustParsePath("m/1852'/1815'/0'"))
+		accountPk := account.Public()
+
+		for _, s := range []string{"m/0/0", "m/0/1", "m/1/0", "m/2147483647/2147483647"} {
+			path := mustParsePath(s)
+			childPk, err := accountPk.DerivePath(path)
+			if err != nil {
+				t.Fatalf("PublicKey.DerivePath(%s): %v", s, err)
+			}
+			if !bytes.Equal(childPk.Bytes(), account.DerivePath(path).Public().Bytes()) {
+				t.Fatalf("PublicKey.DerivePath(%s) != PrivateKey.DerivePath(%s).Public()", s, s)
+			}
+		}
+
+		if _, err := accountPk.Derive(HardenedOffset); !errors.Is(err, ErrHardenedIndex) {
+			t.Fatalf("PublicKey.Derive(hardened): unexpected error: %v", err)
+		}
+	})
+	t.Run("Sign", func(t *testing.T) {
+		msg := []byte("bip32ed25519 test message")
+		for _, s := range []string{"m", "m/1852'/1815'/0'", "m/1852'/1815'/0'/0/0"} {
+			k := master.DerivePath(mustParsePath(s))
+			sig := k.Sign(msg)
+			if !ed25519.Verify(k.Public().Key(), msg, sig) {
+				t.Fatalf("%s: Sign: signature does not verify", s)
+			}
+			if ed25519.Verify(master.Derive(7).Public().Key(), msg, sig) {
+				t.Fatalf("%s: Sign: signature verifies under an unrelated key", s)
+			}
+		}
+	})
+	t.Run("Serialization", func(t *testing.T) {
+		k := master.DerivePath(mustParsePath("m/1852'/1815'/0'/0/0"))
+		k2, err := NewPrivateKey(k.Bytes())
+		if err != nil {
+			t.Fatalf("NewPrivateKey: %v", err)
+		}
+		if !bytes.Equal(k2.Bytes(), k.Bytes()) {
+			t.Fatalf("NewPrivateKey(k.Bytes()) != k")
+		}
+		pk, err := NewPublicKey(k.Public().Bytes())
+		if err != nil {
+			t.Fatalf("NewPublicKey: %v", err)
+		}
+		if !bytes.Equal(pk.Bytes(), k.Public().Bytes()) {
+			t.Fatalf("NewPublicKey(pk.Bytes()) != pk")
+		}
+		if !bytes.Equal(pk.ChainCode(), k.Bytes()[64:]) {
+			t.Fatalf("ChainCode: mismatch")
+		}
+
+		bad := k.Bytes()
+		bad[0] |= 0x01
+		if _, err = NewPrivateKey(bad); !errors.Is(err, ErrInvalidKey) {
+			t.Fatalf("NewPrivateKey(bad kL): unexpected error: %v", err)
+		}
+		if _, err = NewPrivateKey(bad[1:]); !errors.Is(err, ErrInvalidKey) {
+			t.Fatalf("NewPrivateKey(short): unexpected error: %v", err)
+		}
+		if _, err = NewPublicKey(pk.Bytes()[1:]); !errors.Is(err, ErrInvalidKey) {
+			t.Fatalf("NewPublicKey(short): unexpected error: %v", err)
+		}
+	})
+	t.Run("Passphrase", func(t *testing.T) {
+		other := NewMasterKeyIcarus(mustUnhex("000102030405060708090a0b0c0d0e0f"), []byte("passphrase"))
+		if bytes.Equal(other.Bytes(), master.Bytes()) {
+			t.Fatalf("NewMasterKeyIcarus: passphrase does not affect the master key")
+		}
+	})
+	t.Run("ParsePath", func(t *testing.T) {
+		path := mustParsePath("m/1852'/1815H/0h/1/2")
+		expected := []uint32{HardenedOffset + 1852, HardenedOffset + 1815, HardenedOffset, 1, 2}
+		if len(path) != len(expected) {
+			t.Fatalf("ParsePath: unexpected path: %v", path)
+		}
+		for i := range path {
+			if path[i] != expected[i] {
+				t.Fatalf("ParsePath: unexpected path: %v", path)
+			}
+		}
+
+		for _, s := range []string{"", "1852'", "m/", "m/0''", "m/-1", "m/2147483648"} {
+			if _, err := ParsePath(s); !errors.Is(err, ErrInvalidPath) {
+				t.Fatalf("ParsePath(%q): unexpected error: %v", s, err)
+			}
+		}
+	})
+}
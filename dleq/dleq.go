@@ -0,0 +1,209 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package dleq implements non-interactive Chaum-Pedersen proofs of
+// discrete logarithm equality over edwards25519, ie: proofs that
+// `log_B(Y) = log_H(Gamma)` for the base point B.  The proof format and
+// the challenge derivation follow RFC 9497 Section 2.2, with the
+// challenge hashed to a scalar with a caller provided `expand_message`
+// and domain separation tag.
+package dleq
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+
+	"gitlab.com/yawning/edwards25519-extra/h2c"
+)
+
+const (
+	// ProofSize is the size of a serialized Proof in bytes.
+	ProofSize = 2 * scalarSize
+
+	scalarSize = 32
+	pointSize  = 32
+)
+
+var (
+	// ErrInvalidProof is the error returned when a proof encoding is
+	// malformed.
+	ErrInvalidProof = errors.New("dleq: invalid proof")
+
+	challengeLabel = []byte("Challenge")
+)
+
+// Params are the parameters used to derive the challenge of a proof.
+type Params struct {
+	expander        h2c.Expander
+	domainSeparator []byte
+}
+
+// NewParams creates a new set of proof parameters, with challenges
+// derived with the provided Expander and domain separation tag (eg:
+// `"HashToScalar-" || contextString` for RFC 9497).
+func NewParams(expander h2c.Expander, domainSeparator []byte) (*Params, error) {
+	if expander == nil {
+		return nil, errors.New("dleq: nil expander")
+	}
+	if len(domainSeparator) == 0 {
+		return nil, errors.New("dleq: empty domain separation tag")
+	}
+
+	return &Params{
+		expander:        expander,
+		domainSeparator: append([]byte{}, domainSeparator...),
+	}, nil
+}
+
+// Proof is a proof of discrete logarithm equality.
+type Proof struct {
+	c edwards25519.Scalar
+	s edwards25519.Scalar
+}
+
+// Bytes returns the ProofSize-byte encoding of the proof (`c || s`).
+func (proof *Proof) Bytes() []byte {
+	var b [ProofSize]byte
+	copy(b[:scalarSize], proof.c.Bytes())
+	copy(b[scalarSize:], proof.s.Bytes())
+	return b[:]
+}
+
+// SetBytes sets proof to the proof encoded in b, and returns proof.
+// Both scalars MUST be canonically encoded, otherwise SetBytes returns
+// nil and ErrInvalidProof, and the receiver is unchanged.
+func (proof *Proof) SetBytes(b []byte) (*Proof, error) {
+	if len(b) != ProofSize {
+		return nil, fmt.Errorf("%w: invalid length: %d", ErrInvalidProof, len(b))
+	}
+
+	var c, s edwards25519.Scalar
+	if _, err := c.SetCanonicalBytes(b[:scalarSize]); err != nil {
+		return nil, fmt.Errorf("%w: c: %v", ErrInvalidProof, err)
+	}
+	if _, err := s.SetCanonicalBytes(b[scalarSize:]); err != nil {
+		return nil, fmt.Errorf("%w: s: %v", ErrInvalidProof, err)
+	}
+
+	proof.c.Set(&c)
+	proof.s.Set(&s)
+
+	return proof, nil
+}
+
+// Prove generates a proof that `Y = x * B` and `Gamma = x * H`, for the
+// base point B, using entropy from rand for the nonce.  If rand is nil,
+// crypto/rand.Reader will be used.
+func (params *Params) Prove(rand io.Reader, x *edwards25519.Scalar, Y, H, Gamma *edwards25519.Point) (*Proof, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	var rBytes [64]byte
+	if _, err := io.ReadFull(rand, rBytes[:]); err != nil {
+		return nil, fmt.Errorf("dleq: failed to read nonce entropy: %w", err)
+	}
+	r, err := edwards25519.NewScalar().SetUniformBytes(rBytes[:])
+	if err != nil {
+		panic("dleq: failed to deserialize nonce: " + err.Error())
+	}
+	for i := range rBytes {
+		rBytes[i] = 0
+	}
+
+	// t2 = r * A, t3 = r * M
+	t2 := edwards25519.NewIdentityPoint().ScalarBaseMult(r)
+	t3 := edwards25519.NewIdentityPoint().ScalarMult(r, H)
+
+	c, err := params.challenge(Y, H, Gamma, t2, t3)
+	if err != nil {
+		return nil, err
+	}
+
+	// s = r - c * k
+	var proof Proof
+	proof.c.Set(c)
+	proof.s.Multiply(c, x)
+	proof.s.Subtract(r, &proof.s)
+
+	r.Set(edwards25519.NewScalar())
+
+	return &proof, nil
+}
+
+// Verify returns true iff proof is a valid proof that
+// `log_B(Y) = log_H(Gamma)`, for the base point B.
+//
+// Note: It is the caller's responsibility to validate Y, H and Gamma
+// (eg: to reject the identity element and points not in the prime
+// order subgroup), as required by the protocol.
+func (params *Params) Verify(Y, H, Gamma *edwards25519.Point, proof *Proof) bool {
+	// t2 = s * A + c * B
+	t2 := edwards25519.NewIdentityPoint().VarTimeDoubleScalarBaseMult(&proof.c, Y, &proof.s)
+
+	// t3 = s * M + c * Z
+	t3 := edwards25519.NewIdentityPoint().VarTimeMultiScalarMult(
+		[]*edwards25519.Scalar{&proof.s, &proof.c},
+		[]*edwards25519.Point{H, Gamma},
+	)
+
+	c, err := params.challenge(Y, H, Gamma, t2, t3)
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(c.Bytes(), proof.c.Bytes()) == 1
+}
+
+func (params *Params) challenge(B, M, Z, t2, t3 *edwards25519.Point) (*edwards25519.Scalar, error) {
+	// challengeTranscript =
+	//   I2OSP(len(Bm), 2) || Bm ||
+	//   I2OSP(len(a0), 2) || a0 ||
+	//   I2OSP(len(a1), 2) || a1 ||
+	//   I2OSP(len(a2), 2) || a2 ||
+	//   I2OSP(len(a3), 2) || a3 ||
+	//   "Challenge"
+	transcript := make([]byte, 0, 5*(2+pointSize)+len(challengeLabel))
+	for _, p := range []*edwards25519.Point{B, M, Z, t2, t3} {
+		transcript = append(transcript, 0x00, pointSize)
+		transcript = append(transcript, p.Bytes()...)
+	}
+	transcript = append(transcript, challengeLabel...)
+
+	c, err := h2c.HashToScalar(params.expander, params.domainSeparator, transcript)
+	if err != nil {
+		return nil, fmt.Errorf("dleq: failed to derive challenge: %w", err)
+	}
+	return c, nil
+}
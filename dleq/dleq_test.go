@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package dleq
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"filippo.io/edwards25519"
+
+	"gitlab.com/yawning/edwards25519-extra/h2c"
+)
+
+func newTestParams(t *testing.T, dst string) *Params {
+	params, err := NewParams(h2c.NewExpanderXMD(crypto.SHA512), []byte(dst))
+	if err != nil {
+		t.Fatalf("NewParams: %v", err)
+	}
+	return params
+}
+
+func randomScalar(t *testing.T) *edwards25519.Scalar {
+	var b [64]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		t.Fatalf("rand.Read: %v", err)
+	}
+	s, _ := edwards25519.NewScalar().SetUniformBytes(b[:])
+	return s
+}
+
+func TestDLEQ(t *testing.T) {
+	params := newTestParams(t, "dleq-test")
+
+	x := randomScalar(t)
+	Y := edwards25519.NewIdentityPoint().ScalarBaseMult(x)
+	H, err := h2c.Edwards25519_XMD_SHA512_ELL2_RO([]byte("dleq-test-h2c"), []byte("message"))
+	if err != nil {
+		t.Fatalf("h2c: %v", err)
+	}
+	Gamma := edwards25519.NewIdentityPoint().ScalarMult(x, H)
+
+	proof, err := params.Prove(rand.Reader, x, Y, H, Gamma)
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+	if !params.Verify(Y, H, Gamma, proof) {
+		t.Fatalf("Verify: failed")
+	}
+
+	t.Run("NilRand", func(t *testing.T) {
+		proof, err := params.Prove(nil, x, Y, H, Gamma)
+		if err != nil {
+			t.Fatalf("Prove(nil): %v", err)
+		}
+		if !params.Verify(Y, H, Gamma, proof) {
+			t.Fatalf("Verify: failed")
+		}
+	})
+	t.Run("Serialization", func(t *testing.T) {
+		b := proof.Bytes()
+		if len(b) != ProofSize {
+			t.Fatalf("Bytes: unexpected length: %d", len(b))
+		}
+		proof2, err := new(Proof).SetBytes(b)
+		if err != nil {
+			t.Fatalf("SetBytes: %v", err)
+		}
+		if !bytes.Equal(proof2.Bytes(), b) {
+			t.Fatalf("SetBytes(Bytes()) != proof")
+		}
+		if !params.Verify(Y, H, Gamma, proof2) {
+			t.Fatalf("Verify(deserialized): failed")
+		}
+
+		bad := append([]byte{}, b...)
+		for i := scalarSize; i < ProofSize; i++ {
+			bad[i] = 0xff
+		}
+		if _, err = proof2.SetBytes(bad); !errors.Is(err, ErrInvalidProof) {
+			t.Fatalf("SetBytes(non-canonical): unexpected error: %v", err)
+		}
+		if _, err = proof2.SetBytes(b[1:]); !errors.Is(err, ErrInvalidProof) {
+			t.Fatalf("SetBytes(truncated): unexpected error: %v", err)
+		}
+		if !bytes.Equal(proof2.Bytes(), b) {
+			t.Fatalf("SetBytes: modified proof on failure")
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		otherGamma := edwards25519.NewIdentityPoint().ScalarMult(randomScalar(t), H)
+		if params.Verify(Y, H, otherGamma, proof) {
+			t.Fatalf("Verify(wrong Gamma): succeeded")
+		}
+		otherY := edwards25519.NewIdentityPoint().ScalarBaseMult(randomScalar(t))
+		if params.Verify(otherY, H, Gamma, proof) {
+			t.Fatalf("Verify(wrong Y): succeeded")
+		}
+		if newTestParams(t, "dleq-test-other").Verify(Y, H, Gamma, proof) {
+			t.Fatalf("Verify(wrong DST): succeeded")
+		}
+
+		// A proof for an inconsistent statement must not verify.
+		badProof, err := params.Prove(rand.Reader, x, Y, H, otherGamma)
+		if err != nil {
+			t.Fatalf("Prove(inconsistent): %v", err)
+		}
+		if params.Verify(Y, H, otherGamma, badProof) {
+			t.Fatalf("Verify(inconsistent): succeeded")
+		}
+
+		b := proof.Bytes()
+		b[0] ^= 1
+		tampered, err := new(Proof).SetBytes(b)
+		if err != nil {
+			t.Fatalf("SetBytes(tampered): %v", err)
+		}
+		if params.Verify(Y, H, Gamma, tampered) {
+			t.Fatalf("Verify(tampered): succeeded")
+		}
+	})
+	t.Run("Params", func(t *testing.T) {
+		if _, err := NewParams(nil, []byte("dst")); err == nil {
+			t.Fatalf("NewParams(nil expander): succeeded")
+		}
+		if _, err := NewParams(h2c.NewExpanderXMD(crypto.SHA512), nil); err == nil {
+			t.Fatalf("NewParams(empty dst): succeeded")
+		}
+		if _, err := params.Prove(bytes.NewReader(nil), x, Y, H, Gamma); err == nil {
+			t.Fatalf("Prove(empty rand): succeeded")
+		}
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package constants exposes the curve25519 constants used by the
+// Elligator2 map.
+//
+// Each function returns a new copy of the constant, so callers are free
+// to modify the returned value.
+package constants
+
+import (
+	"filippo.io/edwards25519/field"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+// A returns the Montgomery curve parameter A (486662).
+func A() *field.Element {
+	return new(field.Element).Set(montgomery.A)
+}
+
+// ASquared returns A^2.
+func ASquared() *field.Element {
+	return new(field.Element).Set(montgomery.A_SQUARED)
+}
+
+// NegA returns -A.
+func NegA() *field.Element {
+	return new(field.Element).Set(montgomery.NEG_A)
+}
+
+// SqrtNegAPlusTwo returns sqrt(-(A+2)) (sqrt(-486664)), the scaling
+// factor of the birational map between curve25519 and edwards25519.
+func SqrtNegAPlusTwo() *field.Element {
+	return new(field.Element).Set(montgomery.SQRT_NEG_A_PLUS_TWO)
+}
+
+// UFactor returns -2 * sqrt(-1), the factor applied to the
+// u-coordinate when the non-square branch of the map is taken.
+func UFactor() *field.Element {
+	return new(field.Element).Set(montgomery.U_FACTOR)
+}
+
+// VFactor returns sqrt(UFactor()), the factor applied to the
+// v-coordinate when the non-square branch of the map is taken.
+func VFactor() *field.Element {
+	return new(field.Element).Set(montgomery.V_FACTOR)
+}
+
+// SqrtM1 returns sqrt(-1).
+func SqrtM1() *field.Element {
+	return new(field.Element).Set(montgomery.SQRT_M1)
+}
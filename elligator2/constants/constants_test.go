@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package constants
+
+import (
+	"testing"
+
+	"filippo.io/edwards25519/field"
+)
+
+func TestConstants(t *testing.T) {
+	one := new(field.Element).One()
+	two := new(field.Element).Add(one, one)
+
+	t.Run("A", func(t *testing.T) {
+		expected := new(field.Element).Zero()
+		for i := 0; i < 486662; i++ {
+			expected.Add(expected, one)
+		}
+		if A().Equal(expected) != 1 {
+			t.Fatalf("invalid value for A: %x", A().Bytes())
+		}
+	})
+
+	t.Run("ASquared", func(t *testing.T) {
+		expected := new(field.Element).Square(A())
+		if ASquared().Equal(expected) != 1 {
+			t.Fatalf("invalid value for A^2: %x", ASquared().Bytes())
+		}
+	})
+
+	t.Run("NegA", func(t *testing.T) {
+		expected := new(field.Element).Negate(A())
+		if NegA().Equal(expected) != 1 {
+			t.Fatalf("invalid value for -A: %x", NegA().Bytes())
+		}
+	})
+
+	t.Run("SqrtM1", func(t *testing.T) {
+		expected := new(field.Element).Negate(one)
+		if new(field.Element).Square(SqrtM1()).Equal(expected) != 1 {
+			t.Fatalf("invalid value for sqrt(-1): %x", SqrtM1().Bytes())
+		}
+	})
+
+	t.Run("SqrtNegAPlusTwo", func(t *testing.T) {
+		expected := new(field.Element).Subtract(NegA(), two)
+		if new(field.Element).Square(SqrtNegAPlusTwo()).Equal(expected) != 1 {
+			t.Fatalf("invalid value for sqrt(-(A+2)): %x", SqrtNegAPlusTwo().Bytes())
+		}
+	})
+
+	t.Run("UFactor", func(t *testing.T) {
+		expected := new(field.Element).Negate(two)
+		expected.Multiply(expected, SqrtM1())
+		if UFactor().Equal(expected) != 1 {
+			t.Fatalf("invalid value for u_factor: %x", UFactor().Bytes())
+		}
+	})
+
+	t.Run("VFactor", func(t *testing.T) {
+		if new(field.Element).Square(VFactor()).Equal(UFactor()) != 1 {
+			t.Fatalf("invalid value for v_factor: %x", VFactor().Bytes())
+		}
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		a := A()
+		a.Zero()
+		if A().Equal(a) == 1 {
+			t.Fatalf("A() does not return a copy")
+		}
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+// EdwardsFlavorInverseCoset calculates a representative r of a point in
+// the coset `p + E[8]` (the 8 points that differ from p only in the
+// small order component), such that `EdwardsFlavor(r) == p + T` for
+// some point T of order dividing 8.  The point to invert is selected
+// uniformly at random (with randomness read from rand, or
+// crypto/rand.Reader if nil) from the members of the coset that are in
+// the image of the map.
+//
+// The representative only encodes the equivalence class of p modulo
+// the small order subgroup, so consumers MUST clear the cofactor (eg:
+// `MultByCofactor`, or use the point as an X25519 public key) before
+// using the decoded point.  As every member of the coset is tried, ok
+// will be false only in the rare case that none of them are in the
+// image of the map.
+//
+// Unlike EdwardsFlavorInverse, this does not require the caller to
+// clear the small order component of p first, and the timing does not
+// depend on which members of the coset are invertible.
+func EdwardsFlavorInverseCoset(p *edwards25519.Point, rand io.Reader) (r *field.Element, ok bool, err error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	var ranks [cosetRanksSize]byte
+	if _, err = io.ReadFull(rand, ranks[:]); err != nil {
+		return nil, false, fmt.Errorf("elligator2: failed to read coset ranks: %w", err)
+	}
+
+	var fe field.Element
+	if edwardsFlavorInverseCoset(&fe, p, &ranks) != 1 {
+		return nil, false, nil
+	}
+	return &fe, true, nil
+}
+
+// cosetRanksSize is the amount of randomness consumed by
+// EdwardsFlavorInverseCoset, a 32-bit rank for each member of the coset.
+const cosetRanksSize = 8 * 4
+
+func edwardsFlavorInverseCoset(r *field.Element, p *edwards25519.Point, ranks *[cosetRanksSize]byte) int {
+	var (
+		q         edwards25519.Point
+		candidate field.Element
+		found     int
+	)
+
+	// Give each member of the coset p + i * T8 an independent random
+	// rank, and keep the invertible member with the lowest rank.  The
+	// index is appended to the rank so that ties (which are vanishingly
+	// rare) are broken consistently.  All 8 members are always inverted,
+	// and the comparisons are done without branching.
+	best := uint64(1) << 35
+	q.Set(p)
+	for i := 0; i < 8; i++ {
+		ok := edwardsFlavorInverse(&candidate, &q)
+		key := uint64(binary.LittleEndian.Uint32(ranks[i*4:]))<<3 | uint64(i)
+		less := int((key - best) >> 63)
+		sel := ok & less
+		r.Select(&candidate, r, sel)
+		best ^= (best ^ key) & -uint64(sel)
+		found |= ok
+		q.Add(&q, lowOrderPoint)
+	}
+
+	candidate.Zero()
+	q.Set(edwards25519.NewIdentityPoint())
+
+	return found
+}
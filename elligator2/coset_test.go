@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+func TestEdwardsFlavorInverseCoset(t *testing.T) {
+	var (
+		scalarBytes [64]byte
+		s           edwards25519.Scalar
+		tor         edwards25519.Point
+	)
+	for i := 0; i < 64; i++ {
+		// p = random prime order point + random small order component.
+		if _, err := rand.Read(scalarBytes[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		if _, err := s.SetUniformBytes(scalarBytes[:]); err != nil {
+			t.Fatalf("s.SetUniformBytes: %v", err)
+		}
+		p := new(edwards25519.Point).ScalarBaseMult(&s)
+		expected := new(edwards25519.Point).MultByCofactor(p)
+		for j := byte(0); j < scalarBytes[0]&7; j++ {
+			p.Add(p, tor.Set(lowOrderPoint))
+		}
+
+		r, ok, err := EdwardsFlavorInverseCoset(p, nil)
+		if err != nil {
+			t.Fatalf("EdwardsFlavorInverseCoset: %v", err)
+		}
+		if !ok {
+			// Extremely unlikely, but possible.
+			continue
+		}
+		if r.Bytes()[31]&highBitsMask != 0 {
+			t.Fatalf("EdwardsFlavorInverseCoset: non-canonical representative")
+		}
+
+		q := EdwardsFlavor(r)
+		if q.MultByCofactor(q).Equal(expected) != 1 {
+			t.Fatalf("EdwardsFlavorInverseCoset: decoded point not in the coset")
+		}
+	}
+
+	// The choice of coset member is driven by the randomness: giving
+	// member i the lowest rank selects it if it is invertible.  Use the
+	// first multiple of the base point with more than one invertible
+	// coset member, so that the outcome is deterministic.
+	var (
+		p           *edwards25519.Point
+		members     [8]*edwards25519.Point
+		invertible  [8]bool
+		nInvertible int
+	)
+	for k := byte(1); nInvertible < 2; k++ {
+		scalarBytes = [64]byte{k}
+		if _, err := s.SetUniformBytes(scalarBytes[:]); err != nil {
+			t.Fatalf("s.SetUniformBytes: %v", err)
+		}
+		p = new(edwards25519.Point).ScalarBaseMult(&s)
+		nInvertible = 0
+		q := new(edwards25519.Point).Set(p)
+		for i := 0; i < 8; i++ {
+			members[i] = new(edwards25519.Point).Set(q)
+			if _, invertible[i] = EdwardsFlavorInverse(q); invertible[i] {
+				nInvertible++
+			}
+			q.Add(q, lowOrderPoint)
+		}
+	}
+	for i := 0; i < 8; i++ {
+		ranks := bytes.Repeat([]byte{0xff}, cosetRanksSize)
+		copy(ranks[i*4:], []byte{0, 0, 0, 0})
+		r, ok, err := EdwardsFlavorInverseCoset(p, bytes.NewReader(ranks))
+		if err != nil {
+			t.Fatalf("EdwardsFlavorInverseCoset: %v", err)
+		}
+		if !ok {
+			continue
+		}
+		if invertible[i] && EdwardsFlavor(r).Equal(members[i]) != 1 {
+			t.Fatalf("EdwardsFlavorInverseCoset: member %d not selected", i)
+		}
+	}
+
+	// The selected member is uniformly distributed over the invertible
+	// members of the coset.
+	const nTrials = 4096
+	counts := make(map[string]int)
+	for i := 0; i < nTrials; i++ {
+		r, ok, err := EdwardsFlavorInverseCoset(p, nil)
+		if err != nil {
+			t.Fatalf("EdwardsFlavorInverseCoset: %v", err)
+		}
+		if ok {
+			counts[string(r.Bytes())]++
+		}
+	}
+	if len(counts) != nInvertible {
+		t.Fatalf("EdwardsFlavorInverseCoset: %d distinct representatives, expected %d", len(counts), nInvertible)
+	}
+	expected := nTrials / nInvertible
+	for _, n := range counts {
+		if n < expected*3/4 || n > expected*5/4 {
+			t.Fatalf("EdwardsFlavorInverseCoset: member selected %d times, expected ~%d", n, expected)
+		}
+	}
+
+	if _, _, err := EdwardsFlavorInverseCoset(p, bytes.NewReader(make([]byte, cosetRanksSize-1))); err == nil {
+		t.Fatalf("EdwardsFlavorInverseCoset(short reader): expected failure")
+	}
+}
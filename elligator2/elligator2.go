@@ -32,16 +32,156 @@
 package elligator2
 
 import (
+	"errors"
+
 	"filippo.io/edwards25519"
 	"filippo.io/edwards25519/field"
 
 	"gitlab.com/yawning/edwards25519-extra/internal/montgomery"
 )
 
+// RepresentativeSize is the size of an encoded representative in bytes.
+const RepresentativeSize = 32
+
+// ErrInvalidRepresentative is the error returned when an encoded
+// representative is malformed.
+var ErrInvalidRepresentative = errors.New("elligator2: invalid representative")
+
+// HighBits is the convention used for the 2 most significant bits of
+// encoded representatives, which are unused as representatives are
+// <= (p - 1) / 2.
+type HighBits int
+
+const (
+	// HighBitsRandomize ignores the high bits when mapping a
+	// representative to a point, and fills them from the tweak in the
+	// inverse map, so that the encoded representatives are uniform
+	// over all RepresentativeSize-byte strings.  This is the default,
+	// and matches Monocypher and obfs4.
+	HighBitsRandomize HighBits = iota
+
+	// HighBitsIgnore ignores the high bits when mapping a
+	// representative to a point, and leaves them cleared in the inverse
+	// map.
+	HighBitsIgnore
+
+	// HighBitsReject rejects representatives with any of the high bits
+	// set when mapping a representative to a point, and leaves them
+	// cleared in the inverse map.
+	HighBitsReject
+)
+
+const highBitsMask = 0xc0
+
+// MontgomeryFlavorFromRepresentative decodes the RepresentativeSize-byte
+// encoded representative repr with the HighBitsRandomize convention,
+// and calculates the corresponding Montgomery point like
+// MontgomeryFlavor.
+//
+// The 2 most significant bits of the representative are padding, and
+// are ignored (matching Monocypher's crypto_elligator_map).  Callers
+// that wish to reject non-canonical representatives should use
+// IsValidRepresentative.
+func MontgomeryFlavorFromRepresentative(repr []byte) (*field.Element, *field.Element, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var uv [2]field.Element
+	return HighBitsRandomize.montgomeryFlavorFromRepresentative(&uv, repr)
+}
+
+// MontgomeryFlavorFromRepresentative decodes the RepresentativeSize-byte
+// encoded representative repr with the convention h, and calculates the
+// corresponding Montgomery point like MontgomeryFlavor.
+func (h HighBits) MontgomeryFlavorFromRepresentative(repr []byte) (*field.Element, *field.Element, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var uv [2]field.Element
+	return h.montgomeryFlavorFromRepresentative(&uv, repr)
+}
+
+func (h HighBits) montgomeryFlavorFromRepresentative(uv *[2]field.Element, repr []byte) (*field.Element, *field.Element, error) {
+	var r field.Element
+	if err := h.decodeRepresentative(&r, repr); err != nil {
+		return nil, nil, err
+	}
+	montgomeryFlavor(&uv[0], &uv[1], &r)
+	r.Zero()
+
+	return &uv[0], &uv[1], nil
+}
+
+// IsValidRepresentative returns true iff b is a canonical
+// RepresentativeSize-byte encoded representative with the
+// HighBitsRandomize convention, as produced by MontgomeryFlavorInverse.
+// The 2 most significant bits are padding and are ignored, and the
+// remaining bits must encode a value that is <= (p - 1) / 2.
+func IsValidRepresentative(b []byte) bool {
+	return HighBitsRandomize.IsValidRepresentative(b)
+}
+
+// IsValidRepresentative returns true iff b is a canonical
+// RepresentativeSize-byte encoded representative with the convention h.
+func (h HighBits) IsValidRepresentative(b []byte) bool {
+	var r, r2 field.Element
+	if err := h.decodeRepresentative(&r, b); err != nil {
+		return false
+	}
+
+	// With the padding bits masked off, the value is always < p, so
+	// decoding will not reduce.  r <= (p - 1) / 2 iff 2r does not wrap
+	// around p, which is the case iff 2r is even.
+	r2.Add(&r, &r)
+	ok := r2.IsNegative() == 0
+
+	r.Zero()
+	r2.Zero()
+
+	return ok
+}
+
+func (h HighBits) decodeRepresentative(r *field.Element, repr []byte) error {
+	if len(repr) != RepresentativeSize {
+		return ErrInvalidRepresentative
+	}
+
+	switch h {
+	case HighBitsRandomize, HighBitsIgnore:
+	case HighBitsReject:
+		if repr[31]&highBitsMask != 0 {
+			return ErrInvalidRepresentative
+		}
+	default:
+		panic("elligator2: invalid HighBits convention")
+	}
+
+	var b [RepresentativeSize]byte
+	copy(b[:], repr)
+	b[31] &^= highBitsMask
+
+	_, err := r.SetBytes(b[:])
+
+	for i := range b {
+		b[i] = 0
+	}
+
+	return err
+}
+
 // MontgomeryFlavor calculates the Montgomery point corresponding to the
 // representative r, returning the u and v coordinates (Elligator2
 // direct map).
 func MontgomeryFlavor(r *field.Element) (*field.Element, *field.Element) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var u, v field.Element
+	montgomeryFlavor(&u, &v, r)
+	return &u, &v
+}
+
+// montgomeryFlavor sets (u, v) to the Montgomery point corresponding to
+// the representative r, and returns 1 iff the square branch of the map
+// was taken (gx1 is square), 0 otherwise.
+func montgomeryFlavor(u, v, r *field.Element) int {
 	// This is based off the public domain python implementation by
 	// Loup Vaillant, taken from the Monocypher package
 	// (tests/gen/elligator.py).
@@ -57,7 +197,7 @@ func MontgomeryFlavor(r *field.Element) (*field.Element, *field.Element) {
 	t1.Multiply(t1, montgomery.TWO)
 
 	// r2
-	u := new(field.Element).Add(t1, montgomery.ONE)
+	u.Add(t1, montgomery.ONE)
 
 	t2 := new(field.Element).Square(u)
 
@@ -75,7 +215,7 @@ func MontgomeryFlavor(r *field.Element) (*field.Element, *field.Element) {
 	u.Square(r)
 	u.Multiply(u, montgomery.U_FACTOR)
 
-	v := new(field.Element).Multiply(r, montgomery.V_FACTOR)
+	v.Multiply(r, montgomery.V_FACTOR)
 
 	u.Select(montgomery.ONE, u, isSquare)
 	v.Select(montgomery.ONE, v, isSquare)
@@ -93,12 +233,170 @@ func MontgomeryFlavor(r *field.Element) (*field.Element, *field.Element) {
 	negV := new(field.Element).Negate(v)
 	v.Select(negV, v, isSquare^v.IsNegative())
 
-	return u, v
+	t1.Zero()
+	t2.Zero()
+	t3.Zero()
+	negV.Zero()
+
+	return isSquare
 }
 
 // EdwardsFlavor calculates and returns the Edwards point corresponding
 // to the representative r (Elligator2 direct map).
 func EdwardsFlavor(r *field.Element) *edwards25519.Point {
-	u, v := MontgomeryFlavor(r)
-	return montgomery.ToEdwardsPoint(u, v)
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p edwards25519.Point
+	edwardsFlavor(&p, r)
+	return &p
+}
+
+// EdwardsFlavorWithHint calculates and returns the Edwards point
+// corresponding to the representative r like EdwardsFlavor, along with
+// 1 iff the square branch of the map was taken (`is_square(gx1)` in
+// RFC 9380 terms), 0 otherwise.
+func EdwardsFlavorWithHint(r *field.Element) (*edwards25519.Point, int) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p edwards25519.Point
+	isSquare := edwardsFlavor(&p, r)
+	return &p, isSquare
+}
+
+func edwardsFlavor(p *edwards25519.Point, r *field.Element) int {
+	var u, v field.Element
+	isSquare := montgomeryFlavor(&u, &v, r)
+	montgomery.SetEdwardsPoint(p, &u, &v)
+
+	u.Zero()
+	v.Zero()
+
+	return isSquare
+}
+
+// MontgomeryFlavorInverse calculates the RepresentativeSize-byte
+// encoded representative of a Montgomery point with the u-coordinate
+// u (Elligator2 inverse map) with the HighBitsRandomize convention,
+// matching Monocypher's crypto_elligator_rev.
+//
+// As it is common to only have the u-coordinate (eg: X25519 public
+// keys), the least significant bit of tweak selects the sign of the
+// v-coordinate (and hence which of the 2 possible representatives is
+// returned).  The 2 most significant bits of tweak are used as the
+// padding bits of the representative.  For the representative to be
+// indistinguishable from uniform random, tweak MUST be uniform random.
+//
+// Only roughly half of all u-coordinates have a representative, and ok
+// will be false for those outside the image of the map.
+func MontgomeryFlavorInverse(u *field.Element, tweak byte) (repr []byte, ok bool) {
+	return HighBitsRandomize.MontgomeryFlavorInverse(u, tweak)
+}
+
+// MontgomeryFlavorInverse calculates the RepresentativeSize-byte
+// encoded representative of a Montgomery point with the u-coordinate
+// u with the convention h.  The 2 most significant bits of tweak are
+// only used with HighBitsRandomize.
+func (h HighBits) MontgomeryFlavorInverse(u *field.Element, tweak byte) (repr []byte, ok bool) {
+	var r field.Element
+	if montgomeryFlavorInverse(&r, u, int(tweak&1)) != 1 {
+		return nil, false
+	}
+
+	repr = r.Bytes()
+	r.Zero()
+
+	switch h {
+	case HighBitsRandomize:
+		repr[31] |= tweak & highBitsMask
+	case HighBitsIgnore, HighBitsReject:
+	default:
+		panic("elligator2: invalid HighBits convention")
+	}
+
+	return repr, true
+}
+
+// EdwardsFlavorInverse calculates the representative r of the Edwards
+// point p (Elligator2 inverse map), such that `EdwardsFlavor(r) == p`.
+// As r and -r map to the same point, the representative returned is
+// the one that is <= (p - 1) / 2, so `r.Bytes()` always has the 2 most
+// significant bits cleared.
+//
+// Only roughly half of all points have a representative, and ok will
+// be false for points outside the image of the map.  See
+// EdwardsFlavorInverseCoset for a variant that may substitute a
+// different small order component.
+func EdwardsFlavorInverse(p *edwards25519.Point) (r *field.Element, ok bool) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var fe field.Element
+	if edwardsFlavorInverse(&fe, p) != 1 {
+		return nil, false
+	}
+	return &fe, true
+}
+
+func edwardsFlavorInverse(r *field.Element, p *edwards25519.Point) int {
+	var u, v field.Element
+	montgomery.SetFromEdwardsPoint(&u, &v, p)
+
+	// The identity element and the point of order 2 (0, -1) are both
+	// converted to (0, 0), but only the former is in the image of the
+	// map.
+	X, Y, Z, _ := p.ExtendedCoordinates()
+	zPlusY := new(field.Element).Add(Z, Y)
+	isOrder2 := X.Equal(montgomery.ZERO) & zPlusY.Equal(montgomery.ZERO)
+
+	ok := montgomeryFlavorInverse(r, &u, v.IsNegative())
+
+	u.Zero()
+	v.Zero()
+	for _, fe := range []*field.Element{X, Y, Z, zPlusY} {
+		fe.Zero()
+	}
+
+	return ok &^ isOrder2
+}
+
+// montgomeryFlavorInverse sets r to the representative (<= (p - 1) / 2)
+// of the Montgomery point with u-coordinate u, and a v-coordinate with
+// the sign vIsNegative, returning 1 iff such a representative exists.
+func montgomeryFlavorInverse(r, u *field.Element, vIsNegative int) int {
+	// This is based off the fast_curve_to_hash routine in Monocypher's
+	// python implementation (tests/gen/elligator.py), to match the
+	// direct map.
+	//
+	// The direct map produces a point with a negative v-coordinate iff
+	// `u = -A / (1 + 2r^2)`, and one with a non-negative v-coordinate
+	// iff `u = -A - (-A / (1 + 2r^2))`, hence:
+	//   r = sqrt(-(u + A) / 2u) = (u + A) / sqrt(-2u(u + A)) (v negative)
+	//   r = sqrt(-u / 2(u + A)) = u / sqrt(-2u(u + A))       (otherwise)
+
+	t := new(field.Element).Add(u, montgomery.A)
+
+	x := new(field.Element).Multiply(u, t)
+	x.Multiply(x, montgomery.TWO)
+	x.Negate(x)
+
+	isr := new(field.Element)
+	_, isSquare := isr.SqrtRatio(montgomery.ONE, x)
+
+	// u = 0 is the image of r = 0, but SqrtRatio(1, 0) is not square.
+	isSquare |= u.Equal(montgomery.ZERO)
+
+	r.Select(t, u, vIsNegative)
+	r.Multiply(r, isr)
+
+	// Pick the root that is <= (p - 1) / 2, so that the 2 most
+	// significant bits of the encoding are always clear.  2r is odd
+	// iff r > (p - 1) / 2.
+	x.Add(r, r)
+	t.Negate(r)
+	r.Select(t, r, x.IsNegative())
+
+	t.Zero()
+	x.Zero()
+	isr.Zero()
+
+	return isSquare
 }
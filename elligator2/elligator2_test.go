@@ -31,11 +31,13 @@
 package elligator2
 
 import (
+	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"strings"
 	"testing"
 
+	"filippo.io/edwards25519"
 	"filippo.io/edwards25519/field"
 )
 
@@ -60,6 +62,217 @@ func montgomeryFromUniformBytes(in []byte) (*field.Element, *field.Element, erro
 
 func TestElligator2(t *testing.T) {
 	t.Run("Montgomery", testElligator2Montgomery)
+	t.Run("EdwardsInverse", testElligator2EdwardsInverse)
+	t.Run("MontgomeryInverse", testElligator2MontgomeryInverse)
+}
+
+func testElligator2MontgomeryInverse(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		var b [RepresentativeSize + 1]byte
+		for i := 0; i < 256; i++ {
+			if _, err := rand.Read(b[:]); err != nil {
+				t.Fatalf("rand.Read: %v", err)
+			}
+			u, v, err := MontgomeryFlavorFromRepresentative(b[:RepresentativeSize])
+			if err != nil {
+				t.Fatalf("MontgomeryFlavorFromRepresentative: %v", err)
+			}
+
+			tweak := b[RepresentativeSize]&0xfe | byte(v.IsNegative())
+			repr, ok := MontgomeryFlavorInverse(u, tweak)
+			if !ok {
+				t.Fatalf("MontgomeryFlavorInverse(%x): not ok", u.Bytes())
+			}
+			if repr[31]&0xc0 != tweak&0xc0 {
+				t.Fatalf("MontgomeryFlavorInverse: padding mismatch")
+			}
+
+			u2, v2, err := MontgomeryFlavorFromRepresentative(repr)
+			if err != nil {
+				t.Fatalf("MontgomeryFlavorFromRepresentative: %v", err)
+			}
+			if u2.Equal(u) != 1 || v2.Equal(v) != 1 {
+				t.Fatalf("MontgomeryFlavorFromRepresentative(MontgomeryFlavorInverse(u)) != (u, v)")
+			}
+
+			// Flipping the v sign selection bit should produce the
+			// negated point.
+			repr, ok = MontgomeryFlavorInverse(u, tweak^1)
+			if !ok {
+				t.Fatalf("MontgomeryFlavorInverse(%x, -v): not ok", u.Bytes())
+			}
+			u2, v2, _ = MontgomeryFlavorFromRepresentative(repr)
+			if u2.Equal(u) != 1 || v2.Equal(new(field.Element).Negate(v)) != 1 {
+				t.Fatalf("MontgomeryFlavorInverse(u, -v): point mismatch")
+			}
+		}
+	})
+
+	t.Run("IsValidRepresentative", func(t *testing.T) {
+		for _, v := range []struct {
+			n     string
+			b     []byte
+			valid bool
+		}{
+			{"Zero", make([]byte, RepresentativeSize), true},
+			{"Short", make([]byte, RepresentativeSize-1), false},
+			{"Long", make([]byte, RepresentativeSize+1), false},
+			// (p - 1) / 2 = 2^254 - 10, with padding bits set.
+			{"Max", mustUnhex(t, "f6ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"), true},
+			{"MaxPlusOne", mustUnhex(t, "f7ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff3f"), false},
+			{"AllOnes", mustUnhex(t, "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"), false},
+		} {
+			if got := IsValidRepresentative(v.b); got != v.valid {
+				t.Fatalf("IsValidRepresentative(%s): got %v, expected %v", v.n, got, v.valid)
+			}
+		}
+
+		for i := 0; i < 64; i++ {
+			_, _, repr, err := GenerateKeyWithRepresentative(nil)
+			if err != nil {
+				t.Fatalf("GenerateKeyWithRepresentative: %v", err)
+			}
+			if !IsValidRepresentative(repr) {
+				t.Fatalf("IsValidRepresentative(%x): false", repr)
+			}
+		}
+	})
+
+	t.Run("HighBits", func(t *testing.T) {
+		_, pk, _, err := GenerateKeyWithRepresentative(nil)
+		if err != nil {
+			t.Fatalf("GenerateKeyWithRepresentative: %v", err)
+		}
+		u, err := new(field.Element).SetBytes(pk)
+		if err != nil {
+			t.Fatalf("field.Element.SetBytes(pk): %v", err)
+		}
+
+		for _, v := range []struct {
+			n          string
+			h          HighBits
+			expectBits byte
+			rejects    bool
+		}{
+			{"Randomize", HighBitsRandomize, highBitsMask, false},
+			{"Ignore", HighBitsIgnore, 0, false},
+			{"Reject", HighBitsReject, 0, true},
+		} {
+			repr, ok := v.h.MontgomeryFlavorInverse(u, 0xfe)
+			if !ok {
+				t.Fatalf("%s: MontgomeryFlavorInverse: not ok", v.n)
+			}
+			if repr[31]&highBitsMask != v.expectBits {
+				t.Fatalf("%s: MontgomeryFlavorInverse: unexpected high bits", v.n)
+			}
+			if !v.h.IsValidRepresentative(repr) {
+				t.Fatalf("%s: IsValidRepresentative(repr): false", v.n)
+			}
+			u2, _, err := v.h.MontgomeryFlavorFromRepresentative(repr)
+			if err != nil {
+				t.Fatalf("%s: MontgomeryFlavorFromRepresentative: %v", v.n, err)
+			}
+			if u2.Equal(u) != 1 {
+				t.Fatalf("%s: MontgomeryFlavorFromRepresentative(repr) != u", v.n)
+			}
+
+			repr[31] |= highBitsMask
+			_, _, err = v.h.MontgomeryFlavorFromRepresentative(repr)
+			if gotReject := err != nil; gotReject != v.rejects {
+				t.Fatalf("%s: MontgomeryFlavorFromRepresentative(padded): err = %v", v.n, err)
+			}
+			if v.h.IsValidRepresentative(repr) == v.rejects {
+				t.Fatalf("%s: IsValidRepresentative(padded): unexpected result", v.n)
+			}
+		}
+	})
+
+	if _, _, err := MontgomeryFlavorFromRepresentative(make([]byte, RepresentativeSize-1)); err == nil {
+		t.Fatalf("MontgomeryFlavorFromRepresentative(short): expected failure")
+	}
+}
+
+func testElligator2EdwardsInverse(t *testing.T) {
+	t.Run("Identity", func(t *testing.T) {
+		r, ok := EdwardsFlavorInverse(edwards25519.NewIdentityPoint())
+		if !ok {
+			t.Fatalf("EdwardsFlavorInverse(identity): not ok")
+		}
+		if r.Equal(new(field.Element).Zero()) != 1 {
+			t.Fatalf("EdwardsFlavorInverse(identity): %x", r.Bytes())
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		var b [32]byte
+		for i := 0; i < 256; i++ {
+			if _, err := rand.Read(b[:]); err != nil {
+				t.Fatalf("rand.Read: %v", err)
+			}
+			r, err := new(field.Element).SetBytes(b[:])
+			if err != nil {
+				t.Fatalf("SetBytes: %v", err)
+			}
+
+			p := EdwardsFlavor(r)
+			r2, ok := EdwardsFlavorInverse(p)
+			if !ok {
+				t.Fatalf("EdwardsFlavorInverse(EdwardsFlavor(%x)): not ok", r.Bytes())
+			}
+			negR := new(field.Element).Negate(r)
+			if r2.Equal(r) != 1 && r2.Equal(negR) != 1 {
+				t.Fatalf("EdwardsFlavorInverse(EdwardsFlavor(%x)): %x", r.Bytes(), r2.Bytes())
+			}
+			if r2.Bytes()[31]&0xc0 != 0 {
+				t.Fatalf("EdwardsFlavorInverse: high bits set")
+			}
+		}
+	})
+
+	t.Run("RandomPoints", func(t *testing.T) {
+		var (
+			b     [64]byte
+			nOk   int
+			nIter = 256
+		)
+		for i := 0; i < nIter; i++ {
+			if _, err := rand.Read(b[:]); err != nil {
+				t.Fatalf("rand.Read: %v", err)
+			}
+			s, _ := edwards25519.NewScalar().SetUniformBytes(b[:])
+			p := new(edwards25519.Point).ScalarBaseMult(s)
+
+			r, ok := EdwardsFlavorInverse(p)
+			if !ok {
+				continue
+			}
+			nOk++
+			if EdwardsFlavor(r).Equal(p) != 1 {
+				t.Fatalf("EdwardsFlavor(EdwardsFlavorInverse(p)) != p")
+			}
+		}
+		if nOk == 0 || nOk == nIter {
+			t.Fatalf("EdwardsFlavorInverse: implausible success count: %d/%d", nOk, nIter)
+		}
+	})
+
+	t.Run("Order2", func(t *testing.T) {
+		// (0, -1) is the point of order 2, which is not in the image
+		// of the map.
+		var b [32]byte
+		b[0] = 0xec
+		for i := 1; i < 31; i++ {
+			b[i] = 0xff
+		}
+		b[31] = 0x7f
+		p, err := new(edwards25519.Point).SetBytes(b[:])
+		if err != nil {
+			t.Fatalf("SetBytes: %v", err)
+		}
+		if _, ok := EdwardsFlavorInverse(p); ok {
+			t.Fatalf("EdwardsFlavorInverse(order 2): ok")
+		}
+	})
 }
 
 func testElligator2Montgomery(t *testing.T) {
@@ -140,6 +353,16 @@ func testElligator2Montgomery(t *testing.T) {
 		if u.Equal(v.expected) != 1 {
 			t.Fatalf("p[%d] != vector[%d] (Got: %v)", i, i, u)
 		}
+
+		// MontgomeryFlavorFromRepresentative ignores the 2 most
+		// significant bits, like Monocypher.
+		u, _, err = MontgomeryFlavorFromRepresentative(v.repr)
+		if err != nil {
+			t.Fatalf("MontgomeryFlavorFromRepresentative(v[%d].repr): %v", i, err)
+		}
+		if u.Equal(v.expected) != 1 {
+			t.Fatalf("MontgomeryFlavorFromRepresentative[%d] != vector[%d] (Got: %v)", i, i, u)
+		}
 	}
 }
 
@@ -162,3 +385,58 @@ func mustUnhex(t *testing.T, x string) []byte {
 
 	return b
 }
+
+func BenchmarkElligator2(b *testing.B) {
+	var buf [RepresentativeSize]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		b.Fatalf("rand.Read: %v", err)
+	}
+	buf[31] &= 0x3f
+	r, err := new(field.Element).SetBytes(buf[:])
+	if err != nil {
+		b.Fatalf("field.Element.SetBytes: %v", err)
+	}
+
+	b.Run("MontgomeryFlavor", func(b *testing.B) {
+		var u, v field.Element
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			montgomeryFlavor(&u, &v, r)
+		}
+	})
+	b.Run("EdwardsFlavor", func(b *testing.B) {
+		var p edwards25519.Point
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			edwardsFlavor(&p, r)
+		}
+	})
+}
+
+func TestElligator2Allocs(t *testing.T) {
+	var (
+		b    [RepresentativeSize]byte
+		repr Representative
+	)
+	r := new(field.Element).One()
+
+	for _, v := range []struct {
+		n  string
+		fn func()
+	}{
+		{"MontgomeryFlavor", func() { _, _ = MontgomeryFlavor(r) }},
+		{"MontgomeryFlavorFromRepresentative", func() { _, _, _ = MontgomeryFlavorFromRepresentative(b[:]) }},
+		{"HighBits.MontgomeryFlavorFromRepresentative", func() { _, _, _ = HighBitsReject.MontgomeryFlavorFromRepresentative(b[:]) }},
+		{"Representative.MontgomeryFlavor", func() { _, _ = repr.MontgomeryFlavor() }},
+		{"EdwardsFlavor", func() { _ = EdwardsFlavor(r) }},
+		{"EdwardsFlavorWithHint", func() { _, _ = EdwardsFlavorWithHint(r) }},
+		{"LibsodiumFromUniform", func() { _, _ = LibsodiumFromUniform(b[:]) }},
+		{"TwistFlavor", func() { _, _ = TwistFlavor(r) }},
+	} {
+		if n := testing.AllocsPerRun(100, v.fn); n != 0 {
+			t.Errorf("%s: %v allocations", v.n, n)
+		}
+	}
+}
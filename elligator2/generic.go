@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	"errors"
+
+	"filippo.io/edwards25519/field"
+)
+
+// MontgomeryCurve is a Montgomery curve `B*v^2 = u^3 + A*u^2 + u` over
+// GF(2^255-19), with arbitrary parameters, for use with the generic
+// Elligator2 map.
+//
+// The generic map is considerably slower than MontgomeryFlavor, and is
+// intended for curves other than curve25519 (eg: test curves).
+type MontgomeryCurve struct {
+	a, b, z field.Element
+
+	// Precomputed values, per RFC 9380 6.7.1.
+	jOverK      field.Element // J / K
+	negJOverK   field.Element // -J / K
+	invKSquared field.Element // 1 / K^2
+}
+
+// NewMontgomeryCurve creates a new MontgomeryCurve with the parameters A
+// and B, and the non-square z used by the map.
+func NewMontgomeryCurve(a, b, z *field.Element) (*MontgomeryCurve, error) {
+	var zero, one, four, tmp field.Element
+	zero.Zero()
+	one.One()
+	four.Add(&one, &one)
+	four.Add(&four, &four)
+
+	// A != 0, B != 0, and A^2 != 4, such that the curve is
+	// non-singular and the map is defined.
+	tmp.Square(a)
+	tmp.Subtract(&tmp, &four)
+	if a.Equal(&zero) == 1 || b.Equal(&zero) == 1 || tmp.Equal(&zero) == 1 {
+		return nil, errors.New("elligator2: invalid curve parameters")
+	}
+	if _, isSquare := tmp.SqrtRatio(z, &one); isSquare == 1 {
+		return nil, errors.New("elligator2: z is a square")
+	}
+
+	c := &MontgomeryCurve{}
+	c.a.Set(a)
+	c.b.Set(b)
+	c.z.Set(z)
+
+	invK := new(field.Element).Invert(b)
+	c.jOverK.Multiply(a, invK)
+	c.negJOverK.Negate(&c.jOverK)
+	c.invKSquared.Square(invK)
+
+	return c, nil
+}
+
+// Map calculates the point on the curve corresponding to the
+// representative r, returning the u and v coordinates (RFC 9380
+// map_to_curve_elligator2).
+func (c *MontgomeryCurve) Map(r *field.Element) (*field.Element, *field.Element) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var u, v field.Element
+	c.mapToCurve(&u, &v, r)
+	return &u, &v
+}
+
+func (c *MontgomeryCurve) mapToCurve(u, v, r *field.Element) {
+	var (
+		one, tmp        field.Element
+		x1, x2, x       field.Element
+		gx1, gx2        field.Element
+		y1, y2, y, negY field.Element
+	)
+	one.One()
+
+	// 1. x1 = -(J / K) * inv0(1 + Z * r^2)
+	tmp.Square(r)
+	tmp.Multiply(&tmp, &c.z)
+	tmp.Add(&tmp, &one)
+	tmp.Invert(&tmp)
+	x1.Multiply(&c.negJOverK, &tmp)
+
+	// 2. If x1 == 0, set x1 = -(J / K)
+	tmp.Zero()
+	x1.Select(&c.negJOverK, &x1, x1.Equal(&tmp))
+
+	// 3. gx1 = x1^3 + (J / K) * x1^2 + x1 / K^2
+	c.g(&gx1, &x1)
+
+	// 4. x2 = -x1 - (J / K)
+	x2.Negate(&x1)
+	x2.Subtract(&x2, &c.jOverK)
+
+	// 5. gx2 = x2^3 + (J / K) * x2^2 + x2 / K^2
+	c.g(&gx2, &x2)
+
+	// 6-7. If is_square(gx1), x = x1, y = sqrt(gx1) with sgn0(y) == 1,
+	// otherwise x = x2, y = sqrt(gx2) with sgn0(y) == 0.
+	_, e1 := y1.SqrtRatio(&gx1, &one)
+	y2.SqrtRatio(&gx2, &one)
+	x.Select(&x1, &x2, e1)
+	y.Select(&y1, &y2, e1)
+	negY.Negate(&y)
+	y.Select(&negY, &y, y.IsNegative()^e1)
+
+	// 8-9. s = x * K, t = y * K
+	u.Multiply(&x, &c.b)
+	v.Multiply(&y, &c.b)
+
+	for _, fe := range []*field.Element{&tmp, &x1, &x2, &x, &gx1, &gx2, &y1, &y2, &y, &negY} {
+		fe.Zero()
+	}
+}
+
+// IsOnCurve returns true iff (u, v) is on the curve.
+func (c *MontgomeryCurve) IsOnCurve(u, v *field.Element) bool {
+	var lhs, rhs, tmp field.Element
+	lhs.Square(v)
+	lhs.Multiply(&lhs, &c.b)
+
+	rhs.Square(u)
+	tmp.Multiply(&rhs, &c.a)
+	rhs.Multiply(&rhs, u)
+	rhs.Add(&rhs, &tmp)
+	rhs.Add(&rhs, u)
+
+	return lhs.Equal(&rhs) == 1
+}
+
+// g sets out to x^3 + (J / K) * x^2 + x / K^2.
+func (c *MontgomeryCurve) g(out, x *field.Element) {
+	var x2, tmp field.Element
+	x2.Square(x)
+	out.Multiply(&x2, x)
+	tmp.Multiply(&x2, &c.jOverK)
+	out.Add(out, &tmp)
+	tmp.Multiply(x, &c.invKSquared)
+	out.Add(out, &tmp)
+}
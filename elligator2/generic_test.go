@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"filippo.io/edwards25519/field"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+func TestMontgomeryCurve(t *testing.T) {
+	one := new(field.Element).One()
+
+	curve25519, err := NewMontgomeryCurve(montgomery.A, one, montgomery.TWO)
+	if err != nil {
+		t.Fatalf("NewMontgomeryCurve(curve25519): %v", err)
+	}
+
+	// B = 2 is a non-square, so this is (isomorphic to) the quadratic
+	// twist of curve25519.
+	twist, err := NewMontgomeryCurve(montgomery.A, montgomery.TWO, montgomery.TWO)
+	if err != nil {
+		t.Fatalf("NewMontgomeryCurve(twist): %v", err)
+	}
+
+	var b [RepresentativeSize]byte
+	for i := 0; i < 256; i++ {
+		if _, err := rand.Read(b[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		r, err := new(field.Element).SetBytes(b[:])
+		if err != nil {
+			t.Fatalf("field.Element.SetBytes: %v", err)
+		}
+
+		u, v := curve25519.Map(r)
+		expectedU, expectedV := MontgomeryFlavor(r)
+		if u.Equal(expectedU) != 1 || v.Equal(expectedV) != 1 {
+			t.Fatalf("curve25519.Map(%x) != MontgomeryFlavor", b)
+		}
+
+		u, v = twist.Map(r)
+		if !twist.IsOnCurve(u, v) {
+			t.Fatalf("twist.Map(%x) is not on the curve", b)
+		}
+		if curve25519.IsOnCurve(u, v) && v.Equal(new(field.Element).Zero()) != 1 {
+			t.Fatalf("twist.Map(%x) is on curve25519", b)
+		}
+	}
+
+	r := new(field.Element).Zero()
+	u, v := curve25519.Map(r)
+	expectedU, expectedV := MontgomeryFlavor(r)
+	if u.Equal(expectedU) != 1 || v.Equal(expectedV) != 1 {
+		t.Fatalf("curve25519.Map(0) != MontgomeryFlavor(0)")
+	}
+
+	if _, err = NewMontgomeryCurve(montgomery.A, one, one); err == nil {
+		t.Fatalf("NewMontgomeryCurve(z = 1): expected failure")
+	}
+	if _, err = NewMontgomeryCurve(montgomery.TWO, one, montgomery.TWO); err == nil {
+		t.Fatalf("NewMontgomeryCurve(A = 2): expected failure")
+	}
+}
+
+func TestEdwardsFlavorWithHint(t *testing.T) {
+	one := new(field.Element).One()
+	curve25519, err := NewMontgomeryCurve(montgomery.A, one, montgomery.TWO)
+	if err != nil {
+		t.Fatalf("NewMontgomeryCurve(curve25519): %v", err)
+	}
+
+	var (
+		b             [RepresentativeSize]byte
+		nSq, nNonSq   int
+		x1, gx1, tmp1 field.Element
+	)
+	for i := 0; i < 256; i++ {
+		if _, err := rand.Read(b[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		r, err := new(field.Element).SetBytes(b[:])
+		if err != nil {
+			t.Fatalf("field.Element.SetBytes: %v", err)
+		}
+
+		p, hint := EdwardsFlavorWithHint(r)
+		if p.Equal(EdwardsFlavor(r)) != 1 {
+			t.Fatalf("EdwardsFlavorWithHint(%x) != EdwardsFlavor", b)
+		}
+
+		// x1 = -A / (1 + 2 * r^2), is_square(gx1)
+		tmp1.Square(r)
+		tmp1.Multiply(&tmp1, montgomery.TWO)
+		tmp1.Add(&tmp1, one)
+		tmp1.Invert(&tmp1)
+		x1.Multiply(montgomery.NEG_A, &tmp1)
+		curve25519.g(&gx1, &x1)
+		_, expected := tmp1.SqrtRatio(&gx1, one)
+
+		if hint != expected {
+			t.Fatalf("EdwardsFlavorWithHint(%x): hint %d, expected %d", b, hint, expected)
+		}
+		if hint == 1 {
+			nSq++
+		} else {
+			nNonSq++
+		}
+	}
+	if nSq == 0 || nNonSq == 0 {
+		t.Fatalf("EdwardsFlavorWithHint: suspicious distribution: %d square, %d non-square", nSq, nNonSq)
+	}
+}
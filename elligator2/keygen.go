@@ -0,0 +1,242 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+// X25519KeySize is the size of X25519 private and public keys in bytes.
+const X25519KeySize = 32
+
+const representativeTweakDomain = "elligator2: RepresentativeForPrivateKey tweak"
+
+// lowOrderPoint is a point of order 8 on edwards25519.
+var lowOrderPoint = mustPointFromBytes([]byte{
+	0x26, 0xe8, 0x95, 0x8f, 0xc2, 0xb2, 0x27, 0xb0,
+	0x45, 0xc3, 0xf4, 0x89, 0xf2, 0xef, 0x98, 0xf0,
+	0xd5, 0xdf, 0xac, 0x05, 0xd3, 0xc6, 0x33, 0x39,
+	0xb1, 0x38, 0x02, 0x88, 0x6d, 0x53, 0xfc, 0x05,
+})
+
+// GenerateKeyWithRepresentative generates a X25519 key pair, such that
+// the public key has a Elligator2 representative, and returns the
+// private key, public key, and the RepresentativeSize-byte encoded
+// representative.  If rand is nil, crypto/rand.Reader will be used.
+//
+// Only the prime order subgroup can be reached by a naive scalar
+// basepoint multiply, which makes the representatives of such public
+// keys trivially distinguishable from random.  To avoid this, a random
+// low order component (derived from the 3 least significant bits of the
+// private key that X25519 ignores) is added to the public key.  As such
+// the public key will NOT match `X25519(privateKey, Basepoint)`, however
+// the low order component is cleared by the peer's scalar multiply, so
+// the shared secret is the same.
+func GenerateKeyWithRepresentative(rand io.Reader) (privateKey, publicKey, representative []byte, err error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	var (
+		b    [X25519KeySize + 1]byte
+		u, r field.Element
+	)
+	defer func() {
+		for i := range b {
+			b[i] = 0
+		}
+		u.Zero()
+		r.Zero()
+	}()
+
+	for {
+		// The final byte is the tweak.
+		if _, err = io.ReadFull(rand, b[:]); err != nil {
+			return nil, nil, nil, fmt.Errorf("elligator2: failed to read random bytes: %w", err)
+		}
+		sk, tweak := b[:X25519KeySize], b[X25519KeySize]
+
+		if err = setDirtyPublicKey(&u, sk); err != nil {
+			return nil, nil, nil, err
+		}
+		if montgomeryFlavorInverse(&r, &u, int(tweak&1)) != 1 {
+			continue
+		}
+
+		privateKey = append([]byte{}, sk...)
+		publicKey = u.Bytes()
+		representative = r.Bytes()
+		representative[31] |= tweak & highBitsMask
+
+		return privateKey, publicKey, representative, nil
+	}
+}
+
+// X25519DirtyPublicKey returns the "dirty" X25519 public key
+// corresponding to privateKey.
+//
+// The public key is `X25519(privateKey, Basepoint)` with a low order
+// component selected by the 3 least significant bits of the private key
+// added, so that the public keys cover all of the cofactor cosets
+// rather than just the prime order subgroup.  This is required for the
+// Elligator2 representatives of public keys to be indistinguishable
+// from random.  The shared secret derived by a peer from the dirty
+// public key is identical to that derived from the clean public key.
+// The mapping from the low bits to the low order component is specific
+// to this package, and the output is not guaranteed to match other
+// "dirty" key generation routines (eg: Monocypher's).
+//
+// Note that EdwardsFlavor/MontgomeryFlavor and their inverses already
+// operate on the full group, and do not need a dirty variant.
+func X25519DirtyPublicKey(privateKey []byte) ([]byte, error) {
+	var u field.Element
+	if err := setDirtyPublicKey(&u, privateKey); err != nil {
+		return nil, err
+	}
+	return u.Bytes(), nil
+}
+
+// RepresentativeForPublicKey returns the RepresentativeSize-byte encoded
+// representative of the X25519 public key publicKey, with tweak used as
+// in MontgomeryFlavorInverse.  ok will be false if the public key is
+// not a canonical encoding, or if it is outside the image of the map.
+//
+// Note that public keys generated with a naive scalar basepoint
+// multiply are always in the prime order subgroup, and such
+// representatives are distinguishable from random.  See
+// GenerateKeyWithRepresentative and X25519DirtyPublicKey.
+func RepresentativeForPublicKey(publicKey []byte, tweak byte) (repr []byte, ok bool) {
+	if len(publicKey) != X25519KeySize {
+		return nil, false
+	}
+
+	var u field.Element
+	if _, err := u.SetBytes(publicKey); err != nil {
+		return nil, false
+	}
+	if subtle.ConstantTimeCompare(u.Bytes(), publicKey) != 1 {
+		return nil, false
+	}
+
+	return MontgomeryFlavorInverse(&u, tweak)
+}
+
+// RepresentativeForPrivateKey returns the RepresentativeSize-byte
+// encoded representative of the dirty public key corresponding to an
+// existing X25519 private key (see X25519DirtyPublicKey), if it exists.
+// Only roughly half of all private keys have a representative, and ok
+// will be false for the rest.
+//
+// As there is no source of entropy, the tweak used to select the
+// v-coordinate sign and the padding bits is deterministically derived
+// from the private key, so the same private key will always yield the
+// same representative.
+func RepresentativeForPrivateKey(privateKey []byte) (repr []byte, ok bool) {
+	var u, r field.Element
+	defer func() {
+		u.Zero()
+		r.Zero()
+	}()
+
+	if err := setDirtyPublicKey(&u, privateKey); err != nil {
+		return nil, false
+	}
+
+	h := sha512.New()
+	_, _ = h.Write([]byte(representativeTweakDomain))
+	_, _ = h.Write(privateKey)
+	digest := h.Sum(nil)
+	tweak := digest[0]
+	for i := range digest {
+		digest[i] = 0
+	}
+
+	if montgomeryFlavorInverse(&r, &u, int(tweak&1)) != 1 {
+		return nil, false
+	}
+
+	repr = r.Bytes()
+	repr[31] |= tweak & highBitsMask
+
+	return repr, true
+}
+
+func setDirtyPublicKey(u *field.Element, privateKey []byte) error {
+	if len(privateKey) != X25519KeySize {
+		return errors.New("elligator2: invalid private key size")
+	}
+
+	var (
+		s, lo   edwards25519.Scalar
+		p, t    edwards25519.Point
+		loBytes [32]byte
+	)
+	defer func() {
+		loBytes[0] = 0
+		s.Set(edwards25519.NewScalar())
+		lo.Set(edwards25519.NewScalar())
+		p.Set(edwards25519.NewIdentityPoint())
+		t.Set(edwards25519.NewIdentityPoint())
+	}()
+
+	if _, err := s.SetBytesWithClamping(privateKey); err != nil {
+		return fmt.Errorf("elligator2: failed to clamp scalar: %w", err)
+	}
+	p.ScalarBaseMult(&s)
+
+	loBytes[0] = privateKey[0] & 7
+	if _, err := lo.SetCanonicalBytes(loBytes[:]); err != nil {
+		return fmt.Errorf("elligator2: failed to decode scalar: %w", err)
+	}
+	t.ScalarMult(&lo, lowOrderPoint)
+	p.Add(&p, &t)
+
+	montgomery.SetUFromEdwardsPoint(u, &p)
+
+	return nil
+}
+
+func mustPointFromBytes(b []byte) *edwards25519.Point {
+	p, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil {
+		panic("elligator2: failed to decode point: " + err.Error())
+	}
+	return p
+}
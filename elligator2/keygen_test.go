@@ -0,0 +1,243 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestGenerateKeyWithRepresentative(t *testing.T) {
+	t.Run("LowOrderPoint", func(t *testing.T) {
+		var p edwards25519.Point
+		p.MultByCofactor(lowOrderPoint)
+		if p.Equal(edwards25519.NewIdentityPoint()) != 1 {
+			t.Fatalf("8 * lowOrderPoint != identity")
+		}
+
+		p.Add(lowOrderPoint, lowOrderPoint)
+		p.Add(&p, &p)
+		if p.Equal(edwards25519.NewIdentityPoint()) == 1 {
+			t.Fatalf("4 * lowOrderPoint == identity")
+		}
+	})
+
+	t.Run("DirtyCosets", func(t *testing.T) {
+		// Every one of the 8 cosets should be reachable, as selected by
+		// the 3 least significant bits of the private key, and a peer
+		// must derive the same shared secret from the dirty public key
+		// as from the clean one.
+		var sk, peerSk [X25519KeySize]byte
+		if _, err := rand.Read(sk[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		if _, err := rand.Read(peerSk[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		seen := make(map[string]bool)
+		for i := 0; i < 8; i++ {
+			sk[0] = sk[0]&^7 | byte(i)
+			pk, err := X25519DirtyPublicKey(sk[:])
+			if err != nil {
+				t.Fatalf("X25519DirtyPublicKey: %v", err)
+			}
+			seen[string(pk)] = true
+
+			cleanPk, err := curve25519.X25519(sk[:], curve25519.Basepoint)
+			if err != nil {
+				t.Fatalf("X25519(sk, Basepoint): %v", err)
+			}
+			ss, err := curve25519.X25519(peerSk[:], pk)
+			if err != nil {
+				t.Fatalf("X25519(peerSk, pk): %v", err)
+			}
+			cleanSs, err := curve25519.X25519(peerSk[:], cleanPk)
+			if err != nil {
+				t.Fatalf("X25519(peerSk, cleanPk): %v", err)
+			}
+			if !bytes.Equal(ss, cleanSs) {
+				t.Fatalf("X25519DirtyPublicKey: shared secret mismatch (lsb %d)", i)
+			}
+		}
+		if len(seen) != 8 {
+			t.Fatalf("X25519DirtyPublicKey: only %d distinct public keys", len(seen))
+		}
+
+		if _, err := X25519DirtyPublicKey(sk[:X25519KeySize-1]); err == nil {
+			t.Fatalf("X25519DirtyPublicKey(short): expected failure")
+		}
+	})
+
+	t.Run("RepresentativeForPublicKey", func(t *testing.T) {
+		_, pk, _, err := GenerateKeyWithRepresentative(nil)
+		if err != nil {
+			t.Fatalf("GenerateKeyWithRepresentative: %v", err)
+		}
+
+		for _, tweak := range []byte{0x00, 0x01, 0xc0, 0xff} {
+			repr, ok := RepresentativeForPublicKey(pk, tweak)
+			if !ok {
+				t.Fatalf("RepresentativeForPublicKey(pk, %02x): not ok", tweak)
+			}
+			if repr[31]&highBitsMask != tweak&highBitsMask {
+				t.Fatalf("RepresentativeForPublicKey(pk, %02x): padding mismatch", tweak)
+			}
+			u, _, err := MontgomeryFlavorFromRepresentative(repr)
+			if err != nil {
+				t.Fatalf("MontgomeryFlavorFromRepresentative: %v", err)
+			}
+			if !bytes.Equal(u.Bytes(), pk) {
+				t.Fatalf("MontgomeryFlavorFromRepresentative(repr) != pk")
+			}
+		}
+
+		// p + 1 is a non-canonical encoding of 1.
+		nonCanonical := mustUnhex(t, "eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+		if _, ok := RepresentativeForPublicKey(nonCanonical, 0); ok {
+			t.Fatalf("RepresentativeForPublicKey(nonCanonical): expected failure")
+		}
+		if _, ok := RepresentativeForPublicKey(pk[:X25519KeySize-1], 0); ok {
+			t.Fatalf("RepresentativeForPublicKey(short): expected failure")
+		}
+	})
+
+	t.Run("RepresentativeForPrivateKey", func(t *testing.T) {
+		var (
+			sk          [X25519KeySize]byte
+			nOk, nNotOk int
+		)
+		for i := 0; i < 64; i++ {
+			if _, err := rand.Read(sk[:]); err != nil {
+				t.Fatalf("rand.Read: %v", err)
+			}
+			repr, ok := RepresentativeForPrivateKey(sk[:])
+			if !ok {
+				nNotOk++
+				continue
+			}
+			nOk++
+
+			repr2, _ := RepresentativeForPrivateKey(sk[:])
+			if !bytes.Equal(repr, repr2) {
+				t.Fatalf("RepresentativeForPrivateKey is not deterministic")
+			}
+
+			pk, err := X25519DirtyPublicKey(sk[:])
+			if err != nil {
+				t.Fatalf("X25519DirtyPublicKey: %v", err)
+			}
+			u, _, err := MontgomeryFlavorFromRepresentative(repr)
+			if err != nil {
+				t.Fatalf("MontgomeryFlavorFromRepresentative: %v", err)
+			}
+			if !bytes.Equal(u.Bytes(), pk) {
+				t.Fatalf("MontgomeryFlavorFromRepresentative(repr) != pk")
+			}
+		}
+		if nOk == 0 || nNotOk == 0 {
+			t.Fatalf("RepresentativeForPrivateKey: suspicious distribution: %d ok, %d not ok", nOk, nNotOk)
+		}
+
+		// GenerateKeyWithRepresentative keys always have one.
+		sk2, _, _, err := GenerateKeyWithRepresentative(nil)
+		if err != nil {
+			t.Fatalf("GenerateKeyWithRepresentative: %v", err)
+		}
+		if _, ok := RepresentativeForPrivateKey(sk2); !ok {
+			t.Fatalf("RepresentativeForPrivateKey(generated): not ok")
+		}
+
+		if _, ok := RepresentativeForPrivateKey(sk[:X25519KeySize-1]); ok {
+			t.Fatalf("RepresentativeForPrivateKey(short): expected failure")
+		}
+	})
+
+	for i := 0; i < 64; i++ {
+		sk, pk, repr, err := GenerateKeyWithRepresentative(nil)
+		if err != nil {
+			t.Fatalf("GenerateKeyWithRepresentative: %v", err)
+		}
+		if len(sk) != X25519KeySize || len(pk) != X25519KeySize || len(repr) != RepresentativeSize {
+			t.Fatalf("GenerateKeyWithRepresentative: invalid output sizes")
+		}
+
+		dirtyPk, err := X25519DirtyPublicKey(sk)
+		if err != nil {
+			t.Fatalf("X25519DirtyPublicKey: %v", err)
+		}
+		if !bytes.Equal(dirtyPk, pk) {
+			t.Fatalf("X25519DirtyPublicKey(sk) != pk")
+		}
+
+		u, _, err := MontgomeryFlavorFromRepresentative(repr)
+		if err != nil {
+			t.Fatalf("MontgomeryFlavorFromRepresentative: %v", err)
+		}
+		if !bytes.Equal(u.Bytes(), pk) {
+			t.Fatalf("MontgomeryFlavorFromRepresentative(repr) != pk")
+		}
+
+		// The shared secret must match what would be derived from
+		// the "clean" public key.
+		cleanPk, err := curve25519.X25519(sk, curve25519.Basepoint)
+		if err != nil {
+			t.Fatalf("X25519(sk, Basepoint): %v", err)
+		}
+
+		var peerSk [X25519KeySize]byte
+		if _, err = rand.Read(peerSk[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		peerPk, err := curve25519.X25519(peerSk[:], curve25519.Basepoint)
+		if err != nil {
+			t.Fatalf("X25519(peerSk, Basepoint): %v", err)
+		}
+
+		ss, err := curve25519.X25519(sk, peerPk)
+		if err != nil {
+			t.Fatalf("X25519(sk, peerPk): %v", err)
+		}
+		peerSs, err := curve25519.X25519(peerSk[:], pk)
+		if err != nil {
+			t.Fatalf("X25519(peerSk, pk): %v", err)
+		}
+		cleanSs, err := curve25519.X25519(peerSk[:], cleanPk)
+		if err != nil {
+			t.Fatalf("X25519(peerSk, cleanPk): %v", err)
+		}
+		if !bytes.Equal(ss, peerSs) || !bytes.Equal(ss, cleanSs) {
+			t.Fatalf("shared secret mismatch")
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+// LibsodiumFromUniform calculates the Edwards point corresponding to the
+// RepresentativeSize-byte string r, matching libsodium's
+// crypto_core_ed25519_from_uniform.
+//
+// Unlike EdwardsFlavor, the most significant bit of r is not part of
+// the representative, and instead selects the sign of the Edwards
+// x-coordinate, and the cofactor is cleared by multiplying by 8.  The
+// output is always in the prime order subgroup.
+func LibsodiumFromUniform(r []byte) (*edwards25519.Point, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p edwards25519.Point
+	return libsodiumFromUniformClearCofactor(&p, r)
+}
+
+func libsodiumFromUniformClearCofactor(p *edwards25519.Point, r []byte) (*edwards25519.Point, error) {
+	if err := libsodiumFromUniform(p, r); err != nil {
+		return nil, err
+	}
+	return p.MultByCofactor(p), nil
+}
+
+// libsodiumFromUniform sets p to the Edwards point corresponding to the
+// RepresentativeSize-byte string r, prior to the cofactor being cleared.
+func libsodiumFromUniform(p *edwards25519.Point, r []byte) error {
+	if len(r) != RepresentativeSize {
+		return ErrInvalidRepresentative
+	}
+
+	var (
+		b    [RepresentativeSize]byte
+		fe   field.Element
+		u, v field.Element
+		t    field.Element
+	)
+	defer func() {
+		for i := range b {
+			b[i] = 0
+		}
+		fe.Zero()
+		u.Zero()
+		v.Zero()
+		t.Zero()
+	}()
+
+	copy(b[:], r)
+	xSign := b[31] & 0x80
+	b[31] &= 0x7f
+
+	if _, err := fe.SetBytes(b[:]); err != nil {
+		return err
+	}
+
+	// libsodium's Elligator2 treats gx1 = 0 as square, and selects the
+	// same u-coordinate as the RFC 9380 map.  The v-coordinate is
+	// discarded, as the sign of the Edwards x-coordinate is taken from
+	// the input instead.
+	montgomeryFlavor(&u, &v, &fe)
+
+	// y = (u - 1) / (u + 1)
+	t.Add(&u, montgomery.ONE)
+	t.Invert(&t)
+	u.Subtract(&u, montgomery.ONE)
+	u.Multiply(&u, &t)
+
+	copy(b[:], u.Bytes())
+	b[31] |= xSign
+
+	// This can not fail, as y always corresponds to a point on the
+	// curve.
+	if _, err := p.SetBytes(b[:]); err != nil {
+		panic("elligator2: failed to decompress point: " + err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+func TestLibsodiumFromUniform(t *testing.T) {
+	var b [RepresentativeSize]byte
+	for i := 0; i < 256; i++ {
+		if _, err := rand.Read(b[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+
+		var p edwards25519.Point
+		if err := libsodiumFromUniform(&p, b[:]); err != nil {
+			t.Fatalf("libsodiumFromUniform: %v", err)
+		}
+
+		// The sign of the x-coordinate is taken from the MSB.
+		if p.Bytes()[31]&0x80 != b[31]&0x80 {
+			t.Fatalf("libsodiumFromUniform(%x): x sign mismatch", b)
+		}
+
+		// The point should match the RFC 9380 map, up to the sign of
+		// the x-coordinate.
+		var tmp [RepresentativeSize]byte
+		copy(tmp[:], b[:])
+		tmp[31] &= 0x7f
+		r, err := new(field.Element).SetBytes(tmp[:])
+		if err != nil {
+			t.Fatalf("field.Element.SetBytes: %v", err)
+		}
+		q := EdwardsFlavor(r)
+		negQ := new(edwards25519.Point).Negate(q)
+		if p.Equal(q) != 1 && p.Equal(negQ) != 1 {
+			t.Fatalf("libsodiumFromUniform(%x) != +-EdwardsFlavor(r)", b)
+		}
+
+		p2, err := LibsodiumFromUniform(b[:])
+		if err != nil {
+			t.Fatalf("LibsodiumFromUniform: %v", err)
+		}
+		p.MultByCofactor(&p)
+		if p2.Equal(&p) != 1 {
+			t.Fatalf("LibsodiumFromUniform(%x): cofactor not cleared", b)
+		}
+	}
+
+	if _, err := LibsodiumFromUniform(b[:RepresentativeSize-1]); err == nil {
+		t.Fatalf("LibsodiumFromUniform(short): expected failure")
+	}
+}
+
+func TestLibsodiumFromUniformVectors(t *testing.T) {
+	// r = 0 maps to u = 0 (libsodium treats gx1 = 0 as square), which
+	// is the point (0, -1) of order 2, so the output is the identity
+	// regardless of the x-coordinate sign bit.
+	identity := edwards25519.NewIdentityPoint().Bytes()
+	for _, v := range []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000080",
+	} {
+		p, err := LibsodiumFromUniform(mustUnhex(t, v))
+		if err != nil {
+			t.Fatalf("LibsodiumFromUniform(%s): %v", v, err)
+		}
+		if !bytes.Equal(p.Bytes(), identity) {
+			t.Fatalf("LibsodiumFromUniform(%s): %x, expected identity", v, p.Bytes())
+		}
+	}
+
+	// Check against a direct transcription of libsodium's
+	// ge25519_from_uniform, with inputs that have the top bit set, and
+	// non-canonical field element encodings.
+	inputs := [][]byte{
+		mustUnhex(t, "0000000000000000000000000000000000000000000000000000000000000000"),
+		mustUnhex(t, "0000000000000000000000000000000000000000000000000000000000000080"),
+		mustUnhex(t, "0100000000000000000000000000000000000000000000000000000000000000"),
+		mustUnhex(t, "0100000000000000000000000000000000000000000000000000000000000080"),
+		mustUnhex(t, "edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"),
+		mustUnhex(t, "eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+		mustUnhex(t, "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+	}
+	for i := 0; i < 256; i++ {
+		b := make([]byte, RepresentativeSize)
+		if _, err := rand.Read(b); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		inputs = append(inputs, b)
+	}
+	for _, b := range inputs {
+		p, err := LibsodiumFromUniform(b)
+		if err != nil {
+			t.Fatalf("LibsodiumFromUniform(%x): %v", b, err)
+		}
+		if expected := libsodiumFromUniformRef(t, b); !bytes.Equal(p.Bytes(), expected) {
+			t.Fatalf("LibsodiumFromUniform(%x): %x, expected %x", b, p.Bytes(), expected)
+		}
+	}
+}
+
+// libsodiumFromUniformRef follows libsodium's ge25519_from_uniform
+// step by step, without using any of this package's map internals.
+func libsodiumFromUniformRef(t *testing.T, r []byte) []byte {
+	var s [32]byte
+	copy(s[:], r)
+	xSign := s[31] & 0x80
+	s[31] &= 0x7f
+
+	var rr2, x, x2, x3, e, negx, yed, tmp field.Element
+	if _, err := rr2.SetBytes(s[:]); err != nil {
+		t.Fatalf("field.Element.SetBytes: %v", err)
+	}
+
+	// elligator
+	rr2.Square(&rr2)
+	rr2.Add(&rr2, &rr2)
+	rr2.Add(&rr2, montgomery.ONE)
+	rr2.Invert(&rr2)
+	x.Multiply(montgomery.A, &rr2)
+	x.Negate(&x)
+
+	x2.Square(&x)
+	x3.Multiply(&x, &x2)
+	e.Add(&x3, &x)
+	x2.Multiply(&x2, montgomery.A)
+	e.Add(&x2, &e)
+
+	// chi25519: e^((p-1)/2) = (e^((p-5)/8))^4 * e^2
+	tmp.Square(&e)
+	e.Pow22523(&e)
+	e.Square(&e)
+	e.Square(&e)
+	e.Multiply(&e, &tmp)
+
+	copy(s[:], e.Bytes())
+	eIsMinus1 := int(s[1] & 1)
+	negx.Negate(&x)
+	x.Select(&negx, &x, eIsMinus1)
+	x2.Zero()
+	x2.Select(montgomery.A, &x2, eIsMinus1)
+	x.Subtract(&x, &x2)
+
+	// yed = (x-1)/(x+1)
+	tmp.Add(&x, montgomery.ONE)
+	tmp.Invert(&tmp)
+	yed.Subtract(&x, montgomery.ONE)
+	yed.Multiply(&yed, &tmp)
+
+	// recover x
+	copy(s[:], yed.Bytes())
+	s[31] |= xSign
+	p, err := new(edwards25519.Point).SetBytes(s[:])
+	if err != nil {
+		t.Fatalf("edwards25519.Point.SetBytes(%x): %v", s, err)
+	}
+
+	// multiply by the cofactor
+	p.Add(p, p)
+	p.Add(p, p)
+	p.Add(p, p)
+
+	return p.Bytes()
+}
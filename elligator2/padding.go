@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	cryptorand "crypto/rand"
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519/field"
+)
+
+// PadRepresentative overwrites the 2 most significant (padding) bits of
+// the RepresentativeSize-byte encoded representative repr with random
+// bits read from rand, such that the encoding is uniform over all
+// RepresentativeSize-byte strings.  If rand is nil, crypto/rand.Reader
+// will be used.
+//
+// This is intended for representatives produced with the padding bits
+// cleared (eg: `EdwardsFlavorInverse(p).Bytes()`, or with
+// HighBitsIgnore).  The padding bits are ignored when decoding with
+// the default convention.
+func PadRepresentative(repr []byte, rand io.Reader) error {
+	if len(repr) != RepresentativeSize {
+		return ErrInvalidRepresentative
+	}
+
+	tweak, err := randomTweak(rand)
+	if err != nil {
+		return err
+	}
+	repr[31] = repr[31]&^highBitsMask | tweak&highBitsMask
+
+	return nil
+}
+
+// MontgomeryFlavorInverseRandom calculates the RepresentativeSize-byte
+// encoded representative of a Montgomery point with the u-coordinate u
+// like MontgomeryFlavorInverse, with the tweak read from rand.  If rand
+// is nil, crypto/rand.Reader will be used.
+//
+// As the tweak also selects the sign of the v-coordinate, this is
+// intended for u-coordinate only protocols (eg: X25519).
+func MontgomeryFlavorInverseRandom(u *field.Element, rand io.Reader) (repr []byte, ok bool, err error) {
+	tweak, err := randomTweak(rand)
+	if err != nil {
+		return nil, false, err
+	}
+	repr, ok = MontgomeryFlavorInverse(u, tweak)
+	return repr, ok, nil
+}
+
+func randomTweak(rand io.Reader) (byte, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	var b [1]byte
+	if _, err := io.ReadFull(rand, b[:]); err != nil {
+		return 0, fmt.Errorf("elligator2: failed to read random tweak: %w", err)
+	}
+	return b[0], nil
+}
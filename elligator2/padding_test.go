@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	"bytes"
+	"testing"
+
+	"filippo.io/edwards25519/field"
+)
+
+func TestPadding(t *testing.T) {
+	_, pk, _, err := GenerateKeyWithRepresentative(nil)
+	if err != nil {
+		t.Fatalf("GenerateKeyWithRepresentative: %v", err)
+	}
+	u, err := new(field.Element).SetBytes(pk)
+	if err != nil {
+		t.Fatalf("field.Element.SetBytes: %v", err)
+	}
+
+	t.Run("PadRepresentative", func(t *testing.T) {
+		repr, ok := HighBitsIgnore.MontgomeryFlavorInverse(u, 0)
+		if !ok {
+			t.Fatalf("MontgomeryFlavorInverse: not ok")
+		}
+		unpadded := append([]byte{}, repr...)
+
+		// The injected randomness determines the padding.
+		if err = PadRepresentative(repr, bytes.NewReader([]byte{0x80})); err != nil {
+			t.Fatalf("PadRepresentative: %v", err)
+		}
+		if repr[31]&highBitsMask != 0x80 {
+			t.Fatalf("PadRepresentative: unexpected padding: %02x", repr[31])
+		}
+		if err = PadRepresentative(repr, bytes.NewReader([]byte{0x40})); err != nil {
+			t.Fatalf("PadRepresentative: %v", err)
+		}
+		if repr[31]&highBitsMask != 0x40 {
+			t.Fatalf("PadRepresentative: unexpected padding: %02x", repr[31])
+		}
+		repr[31] &^= highBitsMask
+		if !bytes.Equal(repr, unpadded) {
+			t.Fatalf("PadRepresentative: modified non-padding bits")
+		}
+
+		if err = PadRepresentative(repr, bytes.NewReader(nil)); err == nil {
+			t.Fatalf("PadRepresentative(empty reader): expected failure")
+		}
+		if err = PadRepresentative(repr[:RepresentativeSize-1], nil); err == nil {
+			t.Fatalf("PadRepresentative(short): expected failure")
+		}
+	})
+
+	t.Run("MontgomeryFlavorInverseRandom", func(t *testing.T) {
+		repr, ok, err := MontgomeryFlavorInverseRandom(u, bytes.NewReader([]byte{0xc1}))
+		if err != nil {
+			t.Fatalf("MontgomeryFlavorInverseRandom: %v", err)
+		}
+		if !ok {
+			t.Fatalf("MontgomeryFlavorInverseRandom: not ok")
+		}
+		expected, _ := MontgomeryFlavorInverse(u, 0xc1)
+		if !bytes.Equal(repr, expected) {
+			t.Fatalf("MontgomeryFlavorInverseRandom != MontgomeryFlavorInverse")
+		}
+
+		if _, ok, err = MontgomeryFlavorInverseRandom(u, nil); err != nil || !ok {
+			t.Fatalf("MontgomeryFlavorInverseRandom(nil): %v %v", ok, err)
+		}
+		if _, _, err = MontgomeryFlavorInverseRandom(u, bytes.NewReader(nil)); err == nil {
+			t.Fatalf("MontgomeryFlavorInverseRandom(empty reader): expected failure")
+		}
+	})
+}
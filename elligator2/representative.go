@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	"crypto/subtle"
+
+	"filippo.io/edwards25519/field"
+)
+
+// Representative is a RepresentativeSize-byte encoded Elligator2
+// representative, with the HighBitsRandomize convention.  The zero
+// value is the representative of the point (0, 0).
+type Representative struct {
+	b [RepresentativeSize]byte
+}
+
+// NewRepresentative returns a new Representative set to b, or an error
+// if b is not a valid representative (see IsValidRepresentative).
+func NewRepresentative(b []byte) (*Representative, error) {
+	return new(Representative).SetBytes(b)
+}
+
+// SetBytes sets r to b, and returns r, or an error if b is not a valid
+// representative (see IsValidRepresentative).  On error, r is left
+// unchanged.
+func (r *Representative) SetBytes(b []byte) (*Representative, error) {
+	if !IsValidRepresentative(b) {
+		return nil, ErrInvalidRepresentative
+	}
+	copy(r.b[:], b)
+	return r, nil
+}
+
+// Bytes returns the RepresentativeSize-byte encoding of r.
+func (r *Representative) Bytes() []byte {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var buf [RepresentativeSize]byte
+	return r.bytes(&buf)
+}
+
+func (r *Representative) bytes(buf *[RepresentativeSize]byte) []byte {
+	copy(buf[:], r.b[:])
+	return buf[:]
+}
+
+// Equal returns 1 if r and other are equal, and 0 otherwise, in
+// constant time.  The padding bits are included in the comparison, so
+// representatives that only differ in the padding bits (and hence map
+// to the same point) are not equal.
+func (r *Representative) Equal(other *Representative) int {
+	return subtle.ConstantTimeCompare(r.b[:], other.b[:])
+}
+
+// MontgomeryFlavor calculates the Montgomery point corresponding to r,
+// returning the u and v coordinates.
+func (r *Representative) MontgomeryFlavor() (*field.Element, *field.Element) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var u, v field.Element
+	r.montgomeryFlavor(&u, &v)
+	return &u, &v
+}
+
+func (r *Representative) montgomeryFlavor(u, v *field.Element) {
+	var fe field.Element
+	if err := HighBitsRandomize.decodeRepresentative(&fe, r.b[:]); err != nil {
+		panic("elligator2: failed to decode representative: " + err.Error())
+	}
+	montgomeryFlavor(u, v, &fe)
+	fe.Zero()
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRepresentative(t *testing.T) {
+	_, pk, repr, err := GenerateKeyWithRepresentative(nil)
+	if err != nil {
+		t.Fatalf("GenerateKeyWithRepresentative: %v", err)
+	}
+
+	r, err := NewRepresentative(repr)
+	if err != nil {
+		t.Fatalf("NewRepresentative: %v", err)
+	}
+	if !bytes.Equal(r.Bytes(), repr) {
+		t.Fatalf("r.Bytes() != repr")
+	}
+	if u, _ := r.MontgomeryFlavor(); !bytes.Equal(u.Bytes(), pk) {
+		t.Fatalf("r.MontgomeryFlavor() != pk")
+	}
+
+	r2, err := new(Representative).SetBytes(r.Bytes())
+	if err != nil {
+		t.Fatalf("r2.SetBytes: %v", err)
+	}
+	if r.Equal(r2) != 1 {
+		t.Fatalf("r != r2")
+	}
+
+	// Flip a padding bit, the representative maps to the same point
+	// but is not equal.
+	padded := r.Bytes()
+	padded[31] ^= 0x80
+	if _, err = r2.SetBytes(padded); err != nil {
+		t.Fatalf("r2.SetBytes(padded): %v", err)
+	}
+	if r.Equal(r2) != 0 {
+		t.Fatalf("r == r2 (padding differs)")
+	}
+
+	// (p - 1) / 2 + 1 is not canonical.
+	nonCanonical := mustUnhex(t, "f7ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff3f")
+	if _, err = r2.SetBytes(nonCanonical); err == nil {
+		t.Fatalf("r2.SetBytes(nonCanonical): expected failure")
+	}
+	if !bytes.Equal(r2.Bytes(), padded) {
+		t.Fatalf("r2.SetBytes modified r2 on failure")
+	}
+	var zero Representative
+	zeroBytes := make([]byte, 32)
+	if u, v := zero.MontgomeryFlavor(); !bytes.Equal(u.Bytes(), zeroBytes) || !bytes.Equal(v.Bytes(), zeroBytes) {
+		t.Fatalf("zero.MontgomeryFlavor() != (0, 0)")
+	}
+
+	if _, err = NewRepresentative(repr[:RepresentativeSize-1]); err == nil {
+		t.Fatalf("NewRepresentative(short): expected failure")
+	}
+}
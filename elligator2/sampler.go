@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+// samplerWideSize is the number of bytes consumed to produce each of
+// the 2 representatives used per point.
+const samplerWideSize = 64
+
+// Sampler produces a stream of edwards25519 points by applying the
+// Elligator2 map to bytes read from an io.Reader.
+//
+// Each point is derived from 2*64 bytes, by reducing each half into a
+// field element, adding the 2 mapped points, and clearing the
+// cofactor.  If the source is uniform random, the output is
+// statistically indistinguishable from a uniform element of the prime
+// order subgroup.  As the output is fully determined by the source, a
+// seeded XOF (eg: SHAKE) may be used to reproducibly generate a large
+// number of points.
+type Sampler struct {
+	r   io.Reader
+	buf [2 * samplerWideSize]byte
+}
+
+// NewSampler creates a new Sampler reading from r.
+func NewSampler(r io.Reader) *Sampler {
+	return &Sampler{
+		r: r,
+	}
+}
+
+// Next returns the next point from the stream.
+func (s *Sampler) Next() (*edwards25519.Point, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p edwards25519.Point
+	if err := s.next(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (s *Sampler) next(p *edwards25519.Point) error {
+	defer func() {
+		for i := range s.buf {
+			s.buf[i] = 0
+		}
+	}()
+
+	if _, err := io.ReadFull(s.r, s.buf[:]); err != nil {
+		return fmt.Errorf("elligator2: failed to read sampler input: %w", err)
+	}
+
+	var (
+		r0, r1 field.Element
+		q      edwards25519.Point
+	)
+	if _, err := r0.SetWideBytes(s.buf[:samplerWideSize]); err != nil {
+		return err
+	}
+	if _, err := r1.SetWideBytes(s.buf[samplerWideSize:]); err != nil {
+		return err
+	}
+
+	edwardsFlavor(p, &r0)
+	edwardsFlavor(&q, &r1)
+	p.Add(p, &q)
+	p.MultByCofactor(p)
+
+	r0.Zero()
+	r1.Zero()
+	q.Set(edwards25519.NewIdentityPoint())
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestSampler(t *testing.T) {
+	newStream := func() io.Reader {
+		xof := sha3.NewShake128()
+		_, _ = xof.Write([]byte("elligator2 sampler test"))
+		return xof
+	}
+
+	s1, s2 := NewSampler(newStream()), NewSampler(newStream())
+	ref := newStream()
+
+	var buf [2 * samplerWideSize]byte
+	for i := 0; i < 32; i++ {
+		p1, err := s1.Next()
+		if err != nil {
+			t.Fatalf("s1.Next: %v", err)
+		}
+		p2, err := s2.Next()
+		if err != nil {
+			t.Fatalf("s2.Next: %v", err)
+		}
+		if p1.Equal(p2) != 1 {
+			t.Fatalf("sampler output is not deterministic")
+		}
+
+		_, _ = io.ReadFull(ref, buf[:])
+		r0, _ := new(field.Element).SetWideBytes(buf[:samplerWideSize])
+		r1, _ := new(field.Element).SetWideBytes(buf[samplerWideSize:])
+		expected := new(edwards25519.Point).Add(EdwardsFlavor(r0), EdwardsFlavor(r1))
+		expected.MultByCofactor(expected)
+		if p1.Equal(expected) != 1 {
+			t.Fatalf("sampler output[%d] mismatch", i)
+		}
+	}
+
+	s := NewSampler(bytes.NewReader(make([]byte, 2*samplerWideSize+1)))
+	if _, err := s.Next(); err != nil {
+		t.Fatalf("s.Next: %v", err)
+	}
+	if _, err := s.Next(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("s.Next(truncated): unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	"filippo.io/edwards25519/field"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+// twistCurve is the quadratic twist of curve25519, `2*v^2 = u^3 +
+// A*u^2 + u`.
+var twistCurve = mustNewMontgomeryCurve(montgomery.A, montgomery.TWO, montgomery.TWO)
+
+// TwistFlavor calculates the point on the quadratic twist of curve25519
+// (`2*v^2 = u^3 + A*u^2 + u`) corresponding to the representative r,
+// returning the u and v coordinates.
+//
+// Every u-coordinate that is not on curve25519 is on the twist, so
+// X25519 style protocols that only use the u-coordinate can combine
+// MontgomeryFlavor and TwistFlavor to cover every field element.  The
+// u-coordinate returned is the one of the 2 Elligator2 candidates
+// (`-A / (1 + 2*r^2)` and `-u - A`) that MontgomeryFlavor does not
+// pick.
+func TwistFlavor(r *field.Element) (*field.Element, *field.Element) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var u, v field.Element
+	twistCurve.mapToCurve(&u, &v, r)
+	return &u, &v
+}
+
+// TwistFlavorInverse calculates the RepresentativeSize-byte encoded
+// representative of a point on the quadratic twist of curve25519 with
+// the u-coordinate u, such that `TwistFlavor(r)` returns the point,
+// with the HighBitsRandomize convention.  The least significant bit of
+// tweak selects the sign of v / 2 (as with RFC 9380's map for curves
+// with B != 1, the sign convention applies to v / B rather than v), and
+// the 2 most significant bits of tweak are used as the padding bits.
+//
+// ok will be false if u is not on the twist, or if it is outside the
+// image of the map.
+func TwistFlavorInverse(u *field.Element, tweak byte) (repr []byte, ok bool) {
+	// The inverse map only depends on the u-coordinate, so it is
+	// shared with curve25519, but the point must actually be on the
+	// twist (u^3 + A*u^2 + u is non-square).
+	var gu, tmp field.Element
+	gu.Square(u)
+	tmp.Multiply(&gu, montgomery.A)
+	gu.Multiply(&gu, u)
+	gu.Add(&gu, &tmp)
+	gu.Add(&gu, u)
+	_, isSquare := tmp.SqrtRatio(&gu, montgomery.ONE)
+
+	var r field.Element
+	isInvertible := montgomeryFlavorInverse(&r, u, int(tweak&1))
+	if isInvertible&(isSquare^1) != 1 {
+		return nil, false
+	}
+
+	repr = r.Bytes()
+	repr[31] |= tweak & highBitsMask
+	r.Zero()
+
+	return repr, true
+}
+
+func mustNewMontgomeryCurve(a, b, z *field.Element) *MontgomeryCurve {
+	c, err := NewMontgomeryCurve(a, b, z)
+	if err != nil {
+		panic("elligator2: failed to create curve: " + err.Error())
+	}
+	return c
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package elligator2
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"filippo.io/edwards25519/field"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+func TestTwist(t *testing.T) {
+	inv2 := new(field.Element).Invert(montgomery.TWO)
+
+	var b [RepresentativeSize + 1]byte
+	for i := 0; i < 256; i++ {
+		if _, err := rand.Read(b[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		r, err := new(field.Element).SetBytes(b[:RepresentativeSize])
+		if err != nil {
+			t.Fatalf("field.Element.SetBytes: %v", err)
+		}
+
+		u, v := TwistFlavor(r)
+		if !twistCurve.IsOnCurve(u, v) {
+			t.Fatalf("TwistFlavor(%x) is not on the twist", b)
+		}
+
+		// The curve25519 map must pick the other candidate.
+		u2, _ := MontgomeryFlavor(r)
+		if u2.Equal(u) == 1 {
+			t.Fatalf("TwistFlavor(%x) == MontgomeryFlavor", b)
+		}
+		if _, ok := TwistFlavorInverse(u2, 0); ok {
+			t.Fatalf("TwistFlavorInverse(curve point): expected failure")
+		}
+
+		// The sign convention applies to y = v / 2 (RFC 9380 6.7.1).
+		y := new(field.Element).Multiply(v, inv2)
+		tweak := b[RepresentativeSize]&0xfe | byte(y.IsNegative())
+		repr, ok := TwistFlavorInverse(u, tweak)
+		if !ok {
+			t.Fatalf("TwistFlavorInverse(%x): not ok", u.Bytes())
+		}
+		if repr[31]&highBitsMask != tweak&highBitsMask {
+			t.Fatalf("TwistFlavorInverse: padding mismatch")
+		}
+		if !IsValidRepresentative(repr) {
+			t.Fatalf("TwistFlavorInverse: non-canonical representative")
+		}
+
+		repr[31] &^= highBitsMask
+		r2, err := new(field.Element).SetBytes(repr)
+		if err != nil {
+			t.Fatalf("field.Element.SetBytes: %v", err)
+		}
+		u3, v3 := TwistFlavor(r2)
+		if u3.Equal(u) != 1 || v3.Equal(v) != 1 {
+			t.Fatalf("TwistFlavor(TwistFlavorInverse(u)) != (u, v)")
+		}
+	}
+}
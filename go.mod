@@ -4,5 +4,7 @@ go 1.16
 
 require (
 	filippo.io/edwards25519 v1.0.0
+	github.com/klauspost/cpuid/v2 v2.0.12 // indirect
 	golang.org/x/crypto v0.13.0
+	lukechampine.com/blake3 v1.1.7
 )
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"crypto"
+	"errors"
+
+	"filippo.io/edwards25519"
+)
+
+// ErrZeroBlind is the error returned by HashToCurveBlinded when the
+// blinding scalar is zero.
+var ErrZeroBlind = errors.New("h2c: blinding scalar is zero")
+
+// HashToCurveBlinded implements the edwards25519_XMD:SHA-512_ELL2_RO_
+// suite, returning `blind * hash_to_curve(msg)`, as used by the client
+// side of OPRF/VOPRF protocols.
+//
+// Note: The cofactor clearing can not be folded into the scalar
+// multiplication as `(8 * blind mod l)`, as the point prior to cofactor
+// clearing is not in the prime order subgroup.  It is 3 doublings, and
+// is done separately.
+func HashToCurveBlinded(domainSeparator, message []byte, blind *edwards25519.Scalar) (*edwards25519.Point, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p edwards25519.Point
+	return hashToCurveBlinded(&p, domainSeparator, message, blind)
+}
+
+func hashToCurveBlinded(p *edwards25519.Point, domainSeparator, message []byte, blind *edwards25519.Scalar) (*edwards25519.Point, error) {
+	if blind.Equal(edwards25519.NewScalar()) == 1 {
+		return nil, ErrZeroBlind
+	}
+
+	if _, err := edwards25519XMDELL2RO(p, crypto.SHA512, domainSeparator, message); err != nil {
+		return nil, err
+	}
+	return p.ScalarMult(blind, p), nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+// Edwards25519_XMD_SHA512_ELL2_RO_Bytes implements the
+// edwards25519_XMD:SHA-512_ELL2_RO_ suite, returning the 32-byte
+// compressed encoding of the point.
+func Edwards25519_XMD_SHA512_ELL2_RO_Bytes(domainSeparator, message []byte) ([]byte, error) {
+	p, err := Edwards25519_XMD_SHA512_ELL2_RO(domainSeparator, message)
+	if err != nil {
+		return nil, err
+	}
+	return p.Bytes(), nil
+}
+
+// Edwards25519_XMD_SHA512_ELL2_NU_Bytes implements the
+// edwards25519_XMD:SHA-512_ELL2_NU_ suite, returning the 32-byte
+// compressed encoding of the point.
+func Edwards25519_XMD_SHA512_ELL2_NU_Bytes(domainSeparator, message []byte) ([]byte, error) {
+	p, err := Edwards25519_XMD_SHA512_ELL2_NU(domainSeparator, message)
+	if err != nil {
+		return nil, err
+	}
+	return p.Bytes(), nil
+}
+
+// Curve25519_XMD_SHA512_ELL2_RO_Bytes implements the
+// curve25519_XMD:SHA-512_ELL2_RO_ suite, returning the 32-byte
+// encoding of the u-coordinate of the point.
+func Curve25519_XMD_SHA512_ELL2_RO_Bytes(domainSeparator, message []byte) ([]byte, error) {
+	p, err := Curve25519_XMD_SHA512_ELL2_RO_Point(domainSeparator, message)
+	if err != nil {
+		return nil, err
+	}
+	return p.Bytes(), nil
+}
+
+// Curve25519_XMD_SHA512_ELL2_NU_Bytes implements the
+// curve25519_XMD:SHA-512_ELL2_NU_ suite, returning the 32-byte
+// encoding of the u-coordinate of the point.
+func Curve25519_XMD_SHA512_ELL2_NU_Bytes(domainSeparator, message []byte) ([]byte, error) {
+	p, err := Curve25519_XMD_SHA512_ELL2_NU_Point(domainSeparator, message)
+	if err != nil {
+		return nil, err
+	}
+	return p.Bytes(), nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBytes(t *testing.T) {
+	msg := []byte("abc")
+
+	for _, v := range []struct {
+		n       string
+		dst     string
+		fn      func([]byte, []byte) ([]byte, error)
+		pointFn func([]byte, []byte) ([]byte, error)
+	}{
+		{
+			"edwards25519_XMD:SHA-512_ELL2_RO_",
+			"QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_",
+			Edwards25519_XMD_SHA512_ELL2_RO_Bytes,
+			func(dst, msg []byte) ([]byte, error) {
+				p, err := Edwards25519_XMD_SHA512_ELL2_RO(dst, msg)
+				if err != nil {
+					return nil, err
+				}
+				return p.Bytes(), nil
+			},
+		},
+		{
+			"edwards25519_XMD:SHA-512_ELL2_NU_",
+			"QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_NU_",
+			Edwards25519_XMD_SHA512_ELL2_NU_Bytes,
+			func(dst, msg []byte) ([]byte, error) {
+				p, err := Edwards25519_XMD_SHA512_ELL2_NU(dst, msg)
+				if err != nil {
+					return nil, err
+				}
+				return p.Bytes(), nil
+			},
+		},
+		{
+			"curve25519_XMD:SHA-512_ELL2_RO_",
+			"QUUX-V01-CS02-with-curve25519_XMD:SHA-512_ELL2_RO_",
+			Curve25519_XMD_SHA512_ELL2_RO_Bytes,
+			func(dst, msg []byte) ([]byte, error) {
+				p, err := Curve25519_XMD_SHA512_ELL2_RO_Point(dst, msg)
+				if err != nil {
+					return nil, err
+				}
+				return p.U().Bytes(), nil
+			},
+		},
+		{
+			"curve25519_XMD:SHA-512_ELL2_NU_",
+			"QUUX-V01-CS02-with-curve25519_XMD:SHA-512_ELL2_NU_",
+			Curve25519_XMD_SHA512_ELL2_NU_Bytes,
+			func(dst, msg []byte) ([]byte, error) {
+				p, err := Curve25519_XMD_SHA512_ELL2_NU_Point(dst, msg)
+				if err != nil {
+					return nil, err
+				}
+				return p.U().Bytes(), nil
+			},
+		},
+	} {
+		b, err := v.fn([]byte(v.dst), msg)
+		if err != nil {
+			t.Fatalf("%s: %v", v.n, err)
+		}
+		expected, err := v.pointFn([]byte(v.dst), msg)
+		if err != nil {
+			t.Fatalf("%s: %v", v.n, err)
+		}
+		if len(b) != 32 || !bytes.Equal(b, expected) {
+			t.Fatalf("%s: encoding mismatch (Got: '%x')", v.n, b)
+		}
+	}
+}
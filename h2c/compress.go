@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+// CompressBatch returns the 32-byte compressed encodings of points, as
+// per edwards25519.Point.Bytes.  The field inversions required to
+// convert each point to affine coordinates are shared across the batch,
+// so this is considerably faster than calling Bytes on each point.
+func CompressBatch(points []*edwards25519.Point) [][]byte {
+	n := len(points)
+	if n == 0 {
+		return nil
+	}
+
+	xs := make([]field.Element, n)
+	ys := make([]field.Element, n)
+	zs := make([]field.Element, n)
+	for i, p := range points {
+		X, Y, Z, _ := p.ExtendedCoordinates()
+		xs[i].Set(X)
+		ys[i].Set(Y)
+		zs[i].Set(Z)
+	}
+
+	// Z is never 0 for a valid point.
+	montgomery.BatchInvert(zs)
+
+	out := make([][]byte, 0, n)
+	for i := range points {
+		xs[i].Multiply(&xs[i], &zs[i])
+		ys[i].Multiply(&ys[i], &zs[i])
+
+		b := ys[i].Bytes()
+		b[31] |= byte(xs[i].IsNegative() << 7)
+		out = append(out, b)
+	}
+
+	return out
+}
+
+// CompressBatchCurve25519 returns the 32-byte encodings of the
+// u-coordinates of the curve25519 points corresponding to the
+// edwards25519 points, as per MontgomeryPoint.Bytes (and as used by
+// X25519).  The field inversions are shared across the batch.
+//
+// The identity element (and the point of order 2) are encoded as 0.
+func CompressBatchCurve25519(points []*edwards25519.Point) [][]byte {
+	n := len(points)
+	if n == 0 {
+		return nil
+	}
+
+	// u = (Z+Y)/(Z-Y), with a 0 denominator resulting in u = 0.
+	nums := make([]field.Element, n)
+	dens := make([]field.Element, n)
+	for i, p := range points {
+		_, Y, Z, _ := p.ExtendedCoordinates()
+		nums[i].Add(Z, Y)
+		dens[i].Subtract(Z, Y)
+	}
+
+	montgomery.BatchInvert(dens)
+
+	out := make([][]byte, 0, n)
+	for i := range points {
+		nums[i].Multiply(&nums[i], &dens[i])
+		out = append(out, nums[i].Bytes())
+	}
+
+	return out
+}
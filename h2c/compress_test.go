@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+func TestCompressBatch(t *testing.T) {
+	if out := CompressBatch(nil); out != nil {
+		t.Fatalf("CompressBatch(nil): %v", out)
+	}
+	if out := CompressBatchCurve25519(nil); out != nil {
+		t.Fatalf("CompressBatchCurve25519(nil): %v", out)
+	}
+
+	points := []*edwards25519.Point{
+		edwards25519.NewIdentityPoint(),
+		edwards25519.NewGeneratorPoint(),
+	}
+	for i := 0; i < 16; i++ {
+		p, err := RandomPoint(rand.Reader)
+		if err != nil {
+			t.Fatalf("RandomPoint: %v", err)
+		}
+		points = append(points, p)
+	}
+	points = append(points, edwards25519.NewIdentityPoint())
+
+	compressed := CompressBatch(points)
+	u := CompressBatchCurve25519(points)
+	if len(compressed) != len(points) || len(u) != len(points) {
+		t.Fatalf("unexpected output length")
+	}
+	for i, p := range points {
+		if !bytes.Equal(compressed[i], p.Bytes()) {
+			t.Fatalf("CompressBatch: point %d mismatch", i)
+		}
+		if !bytes.Equal(u[i], montgomeryFromEdwards(p).Bytes()) {
+			t.Fatalf("CompressBatchCurve25519: point %d mismatch", i)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	// SuiteEdwards25519XMDSHA512ELL2RO is the suite ID of the
+	// edwards25519_XMD:SHA-512_ELL2_RO_ suite.
+	SuiteEdwards25519XMDSHA512ELL2RO = "edwards25519_XMD:SHA-512_ELL2_RO_"
+
+	// SuiteEdwards25519XMDSHA512ELL2NU is the suite ID of the
+	// edwards25519_XMD:SHA-512_ELL2_NU_ suite.
+	SuiteEdwards25519XMDSHA512ELL2NU = "edwards25519_XMD:SHA-512_ELL2_NU_"
+
+	// SuiteCurve25519XMDSHA512ELL2RO is the suite ID of the
+	// curve25519_XMD:SHA-512_ELL2_RO_ suite.
+	SuiteCurve25519XMDSHA512ELL2RO = "curve25519_XMD:SHA-512_ELL2_RO_"
+
+	// SuiteCurve25519XMDSHA512ELL2NU is the suite ID of the
+	// curve25519_XMD:SHA-512_ELL2_NU_ suite.
+	SuiteCurve25519XMDSHA512ELL2NU = "curve25519_XMD:SHA-512_ELL2_NU_"
+)
+
+// BuildDST constructs a domain separation tag of the form recommended by
+// RFC 9380 Section 3.1, `application-version-with-suiteID`.
+//
+// For example, BuildDST("QUUX", "V01-CS02", SuiteEdwards25519XMDSHA512ELL2RO)
+// returns the tag used by the edwards25519_XMD:SHA-512_ELL2_RO_ test
+// vectors.
+func BuildDST(application, version, suiteID string) ([]byte, error) {
+	for _, s := range []string{application, version, suiteID} {
+		if len(s) == 0 {
+			return nil, errors.New("h2c: empty DST component")
+		}
+		for i := 0; i < len(s); i++ {
+			if s[i] < 0x21 || s[i] > 0x7e {
+				return nil, errors.New("h2c: DST components must be printable ASCII")
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(application)
+	b.WriteByte('-')
+	b.WriteString(version)
+	b.WriteString("-with-")
+	b.WriteString(suiteID)
+
+	return []byte(b.String()), nil
+}
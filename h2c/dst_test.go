@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import "testing"
+
+func TestBuildDST(t *testing.T) {
+	dst, err := BuildDST("QUUX", "V01-CS02", SuiteEdwards25519XMDSHA512ELL2RO)
+	if err != nil {
+		t.Fatalf("BuildDST: %v", err)
+	}
+	if s := string(dst); s != "QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_" {
+		t.Fatalf("BuildDST: unexpected DST: '%s'", s)
+	}
+
+	for _, v := range [][]string{
+		{"", "V01", SuiteCurve25519XMDSHA512ELL2NU},
+		{"QUUX", "", SuiteCurve25519XMDSHA512ELL2NU},
+		{"QUUX", "V01", ""},
+		{"QU UX", "V01", SuiteCurve25519XMDSHA512ELL2NU},
+	} {
+		if _, err = BuildDST(v[0], v[1], v[2]); err == nil {
+			t.Fatalf("BuildDST(%q, %q, %q): expected error", v[0], v[1], v[2])
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// HashDualDST hashes the message to a point on the suite's curve, with
+// each of the two field elements derived under a distinct domain
+// separation tag, ie: `u[i] = hash_to_field(msg, 1)` with `DST = dsts[i]`.
+// The rest of the computation is identical to the suite's random oracle
+// construction.
+//
+// WARNING: This is NOT `hash_to_curve` as specified in RFC 9380, and
+// will produce different output from Hash.  It exists for constructions
+// in the literature that require the two field elements to be derived
+// independently.  It is only defined for random oracle suites.
+func (s *Suite) HashDualDST(domainSeparator0, domainSeparator1, message []byte) (Point, error) {
+	if !s.isRO {
+		return nil, errors.New("h2c: dual-DST hashing requires a random oracle suite")
+	}
+	if bytes.Equal(domainSeparator0, domainSeparator1) {
+		return nil, errors.New("h2c: dual-DST hashing requires distinct DSTs")
+	}
+
+	l := s.curve.FieldElementSize()
+	uniformBytes := make([]byte, 2*l)
+	defer wipeBytes(uniformBytes)
+
+	for i, dst := range [][]byte{domainSeparator0, domainSeparator1} {
+		if err := s.expander.ExpandMessage(uniformBytes[i*l:(i+1)*l], dst, message); err != nil {
+			return nil, fmt.Errorf("h2c: failed to expand message (DST %d): %w", i, err)
+		}
+	}
+	return s.mapUniform(uniformBytes), nil
+}
@@ -0,0 +1,78 @@
+//go:build go1.20
+// +build go1.20
+
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"testing"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestECDHPublicKey(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-curve25519_XMD:SHA-512_ELL2_RO_")
+
+	p, err := Curve25519_XMD_SHA512_ELL2_RO_Point(dst, []byte("abc"))
+	if err != nil {
+		t.Fatalf("Curve25519_XMD_SHA512_ELL2_RO_Point: %v", err)
+	}
+
+	pk, err := p.ECDHPublicKey()
+	if err != nil {
+		t.Fatalf("ECDHPublicKey: %v", err)
+	}
+	if pk.Curve() != ecdh.X25519() {
+		t.Fatalf("ECDHPublicKey: unexpected curve")
+	}
+	if !bytes.Equal(pk.Bytes(), p.Bytes()) {
+		t.Fatalf("ECDHPublicKey: encoding mismatch")
+	}
+
+	sk, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	shared, err := sk.ECDH(pk)
+	if err != nil {
+		t.Fatalf("ECDH: %v", err)
+	}
+	expected, err := curve25519.X25519(sk.Bytes(), p.Bytes())
+	if err != nil {
+		t.Fatalf("X25519: %v", err)
+	}
+	if !bytes.Equal(shared, expected) {
+		t.Fatalf("ECDH: shared secret mismatch")
+	}
+}
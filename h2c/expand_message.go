@@ -31,75 +31,482 @@ package h2c
 
 import (
 	"crypto"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"math"
+	"sync"
 
-	"golang.org/x/crypto/sha3"
+	"golang.org/x/crypto/hkdf"
 )
 
-var oversizeDST = []byte("H2C-OVERSIZE-DST-")
+var (
+	// ErrInvalidHash is the error returned when the hash function used
+	// with `expand_message_xmd` has an insufficiently large output.
+	ErrInvalidHash = errors.New("h2c: b_in_bytes insufficiently large")
+
+	// ErrZeroLength is the error returned when the requested output
+	// length is zero.
+	ErrZeroLength = errors.New("h2c: len_in_bytes is zero")
+
+	// ErrOutputTooLong is the error returned when the requested output
+	// length is larger than what the expander supports.
+	ErrOutputTooLong = errors.New("h2c: len_in_bytes too large")
+
+	// ErrEmptyDST is the error returned when an expander that requires
+	// a non-empty domain separation tag is given an empty one.
+	ErrEmptyDST = errors.New("h2c: empty domain separation tag")
+
+	// ErrBatchMismatch is the error returned when the number of outputs
+	// and messages passed to a batch expander differ.
+	ErrBatchMismatch = errors.New("h2c: batch output/message count mismatch")
+
+	oversizeDST = []byte("H2C-OVERSIZE-DST-")
+)
+
+func checkOutputLength(lenInBytes int) error {
+	switch {
+	case lenInBytes == 0:
+		return ErrZeroLength
+	case lenInBytes > math.MaxUint16:
+		return fmt.Errorf("%w: %d", ErrOutputTooLong, lenInBytes)
+	}
+	return nil
+}
+
+// Expander is an implementation of `expand_message`.
+type Expander interface {
+	// ID returns the expander portion of a suite ID (eg: `XMD:SHA-512`).
+	ID() string
+
+	// ExpandMessage overwrites out with uniformly random data generated
+	// from the domain separation tag and message.
+	ExpandMessage(out, domainSeparator, message []byte) error
+}
+
+// preparableExpander is an Expander that can precompute the message
+// independent state for a domain separation tag.
+type preparableExpander interface {
+	Expander
+
+	prepare(domainSeparator []byte) (preparedExpander, error)
+}
+
+// preparedExpander is an Expander bound to a domain separation tag.
+// Implementations MUST be immutable, and safe for concurrent use.
+type preparedExpander interface {
+	// newMessageStream returns a messageStream that will produce
+	// lenInBytes bytes of output.
+	newMessageStream(lenInBytes int) (messageStream, error)
+}
+
+// messageStream is an in-progress `expand_message` invocation.  The
+// message is written to the stream, after which finish is called exactly
+// once with an output of the length that the stream was created with.
+type messageStream interface {
+	io.Writer
+
+	finish(out []byte) error
+}
+
+func prepareExpander(e Expander, domainSeparator []byte) (preparedExpander, error) {
+	if pe, ok := e.(preparableExpander); ok {
+		return pe.prepare(domainSeparator)
+	}
+	return &bufferedPreparedExpander{
+		expander:        e,
+		domainSeparator: append([]byte{}, domainSeparator...),
+	}, nil
+}
+
+func expandPrepared(out []byte, pe preparedExpander, message []byte) error {
+	s, err := pe.newMessageStream(len(out))
+	if err != nil {
+		return err
+	}
+	_, _ = s.Write(message) // msg
+
+	return s.finish(out)
+}
+
+// bufferedPreparedExpander is the fallback preparedExpander used for
+// Expanders that do not support precomputation or streaming.
+type bufferedPreparedExpander struct {
+	expander        Expander
+	domainSeparator []byte
+}
+
+func (pe *bufferedPreparedExpander) newMessageStream(lenInBytes int) (messageStream, error) {
+	return &bufferedStream{
+		pe:         pe,
+		lenInBytes: lenInBytes,
+	}, nil
+}
+
+type bufferedStream struct {
+	pe         *bufferedPreparedExpander
+	buf        []byte
+	lenInBytes int
+}
+
+func (s *bufferedStream) Write(p []byte) (int, error) {
+	s.buf = append(s.buf, p...)
+	return len(p), nil
+}
+
+func (s *bufferedStream) finish(out []byte) error {
+	defer wipeBytes(s.buf)
+	if len(out) != s.lenInBytes {
+		return fmt.Errorf("h2c: unexpected output length: %d", len(out))
+	}
+	return s.pe.expander.ExpandMessage(out, s.pe.domainSeparator, s.buf)
+}
+
+type expanderXMD struct {
+	hFunc crypto.Hash
+}
+
+func (e *expanderXMD) ID() string {
+	return "XMD:" + e.hFunc.String()
+}
+
+func (e *expanderXMD) ExpandMessage(out, domainSeparator, message []byte) error {
+	return ExpandMessageXMD(out, e.hFunc, domainSeparator, message)
+}
+
+func (e *expanderXMD) prepare(domainSeparator []byte) (preparedExpander, error) {
+	return newXMDParams(e.hFunc, domainSeparator)
+}
+
+// NewExpanderXMD returns an Expander implementing `expand_message_xmd`
+// with the provided hash function.
+func NewExpanderXMD(hFunc crypto.Hash) Expander {
+	return &expanderXMD{
+		hFunc: hFunc,
+	}
+}
 
 // ExpandMessageXMD implements expand_message_xmd, overwriting out with
 // uniformly random data generated by the provided hash function, domain
 // separation tag, and message.
 func ExpandMessageXMD(out []byte, hFunc crypto.Hash, domainSeparator, message []byte) error {
+	x, err := newXMDState(hFunc, domainSeparator)
+	if err != nil {
+		return err
+	}
+	defer x.release()
+
+	return x.expand(out, message)
+}
+
+// ExpandMessageXMDBatch implements expand_message_xmd for multiple messages
+// under a single domain separation tag, overwriting each outs[i] with
+// uniformly random data generated from msgs[i].  This is equivalent to
+// calling ExpandMessageXMD for each message, but reuses the DST_prime,
+// the hash instance, and the scratch buffers across messages.
+//
+// On failure, the contents of outs are undefined.
+func ExpandMessageXMDBatch(outs [][]byte, hFunc crypto.Hash, domainSeparator []byte, msgs [][]byte) error {
+	if len(outs) != len(msgs) {
+		return fmt.Errorf("%w: %d outputs, %d messages", ErrBatchMismatch, len(outs), len(msgs))
+	}
+
+	x, err := newXMDState(hFunc, domainSeparator)
+	if err != nil {
+		return err
+	}
+	defer x.release()
+
+	for i, out := range outs {
+		if err = x.expand(out, msgs[i]); err != nil {
+			return fmt.Errorf("h2c: batch entry %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// ExpandMessageHKDF implements a legacy HKDF based message expansion,
+// overwriting out with `HKDF-Expand(HKDF-Extract(DST, msg), info, len(out))`.
+//
+// This is NOT `expand_message` as specified in RFC 9380, and exists only
+// to reproduce outputs from systems that predate it (eg: early drafts of
+// the hash-to-curve specification, where DST was used as the HKDF salt).
+// New code MUST use ExpandMessageXMD or ExpandMessageXOF instead.
+func ExpandMessageHKDF(out []byte, hFunc crypto.Hash, domainSeparator, info, message []byte) error {
 	lenInBytes := len(out)
 	bInBytes := hFunc.Size()
 
-	h := hFunc.New()
-	rInBytes := h.BlockSize()
+	if bInBytes < 2*kay/8 {
+		return fmt.Errorf("%w: %d", ErrInvalidHash, bInBytes)
+	}
+	if lenInBytes == 0 {
+		return ErrZeroLength
+	}
+	if lenInBytes > 255*bInBytes {
+		return fmt.Errorf("%w: %d", ErrOutputTooLong, lenInBytes)
+	}
+
+	prk := hkdf.Extract(hFunc.New, message, domainSeparator)
+	defer wipeBytes(prk)
+
+	if _, err := io.ReadFull(hkdf.Expand(hFunc.New, prk, info), out); err != nil {
+		return fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+
+	return nil
+}
+
+// ReduceDSTXMD returns the domain separation tag that `expand_message_xmd`
+// with the provided hash function will use for domainSeparator.  For
+// DSTs longer than 255 bytes, this is `H("H2C-OVERSIZE-DST-" || DST)`,
+// otherwise it is a copy of domainSeparator.
+//
+// As the reduced DST is at most 255 bytes long, it can be used in place
+// of the original DST to avoid recomputing the reduction on every call.
+func ReduceDSTXMD(hFunc crypto.Hash, domainSeparator []byte) ([]byte, error) {
+	if _, _, err := lookupHashParams(hFunc); err != nil {
+		return nil, err
+	}
+	if len(domainSeparator) <= math.MaxUint8 {
+		return append([]byte{}, domainSeparator...), nil
+	}
+	return reduceDSTXMD(nil, hFunc.New(), domainSeparator), nil
+}
+
+// reduceDSTXMD appends `H("H2C-OVERSIZE-DST-" || a_very_long_DST)` to
+// dst, and returns the resulting slice.
+func reduceDSTXMD(dst []byte, h hash.Hash, domainSeparator []byte) []byte {
+	h.Reset()
+	_, _ = h.Write(oversizeDST)
+	_, _ = h.Write(domainSeparator)
+	dst = h.Sum(dst)
+	h.Reset()
+
+	return dst
+}
+
+// xmdParams is the message independent state used by expand_message_xmd.
+// It is immutable once created.
+type xmdParams struct {
+	hFunc    crypto.Hash
+	bInBytes int
+
+	zPad     []byte // Z_pad (I2OSP(0, r_in_bytes))
+	dstPrime []byte // DST || I2OSP(len(DST), 1)
+}
+
+func newXMDParams(hFunc crypto.Hash, domainSeparator []byte) (*xmdParams, error) {
+	rInBytes, bInBytes, err := lookupHashParams(hFunc)
+	if err != nil {
+		return nil, err
+	}
 
 	// 0. Ensure parameters are sensible.
 	if bInBytes < 2*kay/8 {
-		return fmt.Errorf("h2c: b_in_bytes insufficiently large: %d", bInBytes)
-	}
-	if lenInBytes == 0 || lenInBytes > math.MaxUint16 {
-		return fmt.Errorf("h2c: len_in_bytes out of range: %d", lenInBytes)
+		return nil, fmt.Errorf("%w: %d", ErrInvalidHash, bInBytes)
 	}
 
 	// 5.3.3 Using DSTs longer than 255 bytes.
 	DST := domainSeparator
-	lenDST := len(domainSeparator)
-	if lenDST > math.MaxUint8 {
-		// DST = H("H2C-OVERSIZE-DST-" || a_very_long_DST)
-		_, _ = h.Write(oversizeDST)
-		_, _ = h.Write(DST)
+	if len(DST) > math.MaxUint8 {
+		DST = reduceDSTXMD(nil, hFunc.New(), DST)
+	}
 
-		DST = h.Sum(nil)
-		lenDST = len(DST)
+	// DST_prime = DST || I2OSP(len(DST), 1)
+	dstPrime := make([]byte, 0, len(DST)+1)
+	dstPrime = append(dstPrime, DST...)
+	dstPrime = append(dstPrime, byte(len(DST)))
+
+	return &xmdParams{
+		hFunc:    hFunc,
+		bInBytes: bInBytes,
+		zPad:     make([]byte, rInBytes),
+		dstPrime: dstPrime,
+	}, nil
+}
 
-		h.Reset()
+func (p *xmdParams) newState() *xmdState {
+	x := getPooledXMDState(p.hFunc)
+	if x == nil {
+		x = allocXMDState(p.hFunc, len(p.zPad), p.bInBytes)
+	}
+	x.xmdParams = p
+	return x
+}
+
+func (p *xmdParams) newMessageStream(lenInBytes int) (messageStream, error) {
+	x := p.newState()
+	if err := x.begin(lenInBytes); err != nil {
+		x.release()
+		return nil, err
+	}
+	return &xmdStream{
+		x:          x,
+		lenInBytes: lenInBytes,
+	}, nil
+}
+
+// xmdStatePools caches xmdState instances by hash function, so that
+// repeated calls do not need to allocate hash instances or buffers.
+// The params of a hash function never change once they can be looked
+// up, as RegisterHashParams does not allow overriding them.
+var xmdStatePools [32]sync.Pool
+
+// xmdState is the per-invocation state used by expand_message_xmd.
+type xmdState struct {
+	*xmdParams
+
+	h hash.Hash
+
+	b0     []byte
+	b1     []byte
+	xorBuf []byte
+	ctrBuf []byte // l_i_b_str || I2OSP(0, 1), or I2OSP(i, 1)
+
+	// ownParams backs xmdParams for one-shot use, with dstPrime
+	// reused across invocations.
+	ownParams xmdParams
+}
+
+func newXMDState(hFunc crypto.Hash, domainSeparator []byte) (*xmdState, error) {
+	x := getPooledXMDState(hFunc)
+	if x == nil {
+		rInBytes, bInBytes, err := lookupHashParams(hFunc)
+		if err != nil {
+			return nil, err
+		}
+
+		// 0. Ensure parameters are sensible.
+		if bInBytes < 2*kay/8 {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidHash, bInBytes)
+		}
+
+		x = allocXMDState(hFunc, rInBytes, bInBytes)
+	}
+
+	// 5.3.3 Using DSTs longer than 255 bytes.
+	p := &x.ownParams
+	dstPrime := p.dstPrime[:0]
+	if len(domainSeparator) > math.MaxUint8 {
+		dstPrime = reduceDSTXMD(dstPrime, x.h, domainSeparator)
+	} else {
+		dstPrime = append(dstPrime, domainSeparator...)
+	}
+
+	// DST_prime = DST || I2OSP(len(DST), 1)
+	p.dstPrime = append(dstPrime, byte(len(dstPrime)))
+	x.xmdParams = p
+
+	return x, nil
+}
+
+func allocXMDState(hFunc crypto.Hash, rInBytes, bInBytes int) *xmdState {
+	x := &xmdState{
+		h:      hFunc.New(),
+		b0:     make([]byte, 0, bInBytes),
+		b1:     make([]byte, 0, bInBytes),
+		xorBuf: make([]byte, 0, bInBytes),
+		ctrBuf: make([]byte, 3),
+		ownParams: xmdParams{
+			hFunc:    hFunc,
+			bInBytes: bInBytes,
+			zPad:     make([]byte, rInBytes),
+			dstPrime: make([]byte, 0, math.MaxUint8+1),
+		},
+	}
+	x.xmdParams = &x.ownParams
+	return x
+}
+
+func getPooledXMDState(hFunc crypto.Hash) *xmdState {
+	if uint(hFunc) >= uint(len(xmdStatePools)) {
+		return nil
+	}
+	x, _ := xmdStatePools[hFunc].Get().(*xmdState)
+	return x
+}
+
+// release wipes the state, and returns it to the pool.
+func (x *xmdState) release() {
+	x.wipe()
+	wipeBytes(x.ownParams.dstPrime)
+	x.ownParams.dstPrime = x.ownParams.dstPrime[:0]
+	x.xmdParams = &x.ownParams
+
+	if hFunc := x.hFunc; uint(hFunc) < uint(len(xmdStatePools)) {
+		xmdStatePools[hFunc].Put(x)
+	}
+}
+
+func (x *xmdState) expand(out, message []byte) error {
+	if err := x.begin(len(out)); err != nil {
+		return err
+	}
+	_, _ = x.h.Write(message) // msg
+
+	return x.finish(out)
+}
+
+// begin validates len_in_bytes, and starts computing b_0.  After begin
+// returns, the message should be written to x.h, followed by a call to
+// finish with an output of len_in_bytes.
+func (x *xmdState) begin(lenInBytes int) error {
+	if err := x.checkLength(lenInBytes); err != nil {
+		return err
+	}
+
+	x.h.Reset()
+	_, _ = x.h.Write(x.zPad) // Z_pad (I2OSP(0, r_in_bytes))
+
+	return nil
+}
+
+// checkLength validates len_in_bytes.
+func (x *xmdState) checkLength(lenInBytes int) error {
+	if err := checkOutputLength(lenInBytes); err != nil {
+		return err
 	}
 
 	// 1. ell = ceil(len_in_bytes / b_in_bytes)
-	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	ell := (lenInBytes + x.bInBytes - 1) / x.bInBytes
 
 	// 2. ABORT if ell > 255
 	if ell > 255 {
-		return fmt.Errorf("h2c: ell out of range: %d", ell)
+		return fmt.Errorf("%w: ell out of range: %d", ErrOutputTooLong, ell)
 	}
 
+	return nil
+}
+
+func (x *xmdState) finish(out []byte) error {
+	lenInBytes := len(out)
+	bInBytes := x.bInBytes
+	h := x.h
+
+	// Small writes go through ctrBuf, as literals passed to the hash
+	// would escape to the heap.
+	ctr := x.ctrBuf
+
 	// 7. b_0 = H(msg_prime)
-	_, _ = h.Write(make([]byte, rInBytes))                             // Z_pad (I2OSP(0, r_in_bytes))
-	_, _ = h.Write(message)                                            // msg
-	_, _ = h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0}) // l_i_b_str || I2OSP(0, 1)
-	_, _ = h.Write(DST)                                                // DST
-	_, _ = h.Write([]byte{byte(lenDST)})                               // I2OSP(len(DST), 1)
-	b0 := h.Sum(nil)
+	ctr[0], ctr[1], ctr[2] = byte(lenInBytes>>8), byte(lenInBytes), 0
+	_, _ = h.Write(ctr[:3])    // l_i_b_str || I2OSP(0, 1)
+	_, _ = h.Write(x.dstPrime) // DST || I2OSP(len(DST), 1)
+	b0 := h.Sum(x.b0[:0])
 
 	// 8. b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
 	h.Reset()
-	_, _ = h.Write(b0)                   // b_0
-	_, _ = h.Write([]byte{1})            // I2OSP(1, 1)
-	_, _ = h.Write(DST)                  // DST
-	_, _ = h.Write([]byte{byte(lenDST)}) // I2OSP(len(DST), 1)
-	b1 := h.Sum(nil)
+	ctr[0] = 1
+	_, _ = h.Write(b0)         // b_0
+	_, _ = h.Write(ctr[:1])    // I2OSP(1, 1)
+	_, _ = h.Write(x.dstPrime) // DST || I2OSP(len(DST), 1)
+	b1 := h.Sum(x.b1[:0])
 
 	// We attempt to be somewhat more efficient about the remaining steps
 	// by:
 	//  * Seeing if we can service the request just with b_1 (len_in_bytes <= b_in_bytes)
-	//  * Allocating a b_in_bytes sized temporary buffer to store:
+	//  * Using a b_in_bytes sized temporary buffer to store:
 	//     * b_(i - 1) (Initialized to b_1)
 	//     * strxor(b_0, b_(i - 1))
 	//     * b_i, which becomes b_(i - 1)
@@ -113,8 +520,7 @@ func ExpandMessageXMD(out []byte, hFunc crypto.Hash, domainSeparator, message []
 
 	// Reuse a temporary buffer to hold both the xored portion of the hash
 	// input and b_(i - 1).
-	xorBuf := make([]byte, 0, bInBytes)
-	xorBuf = append(xorBuf, b1...)
+	xorBuf := append(x.xorBuf[:0], b1...)
 
 	// Append b_1 to the output, since we know we need all of it.
 	copy(out, b1) // 11. uniform_bytes = b_1 || ...
@@ -128,11 +534,11 @@ func ExpandMessageXMD(out []byte, hFunc crypto.Hash, domainSeparator, message []
 		}
 
 		h.Reset()
-		_, _ = h.Write(xorBuf)               // strxor(b_0, b_(i - 1))
-		_, _ = h.Write([]byte{byte(i)})      // I2OSP(i, 1)
-		_, _ = h.Write(DST)                  // DST
-		_, _ = h.Write([]byte{byte(lenDST)}) // I2OSP(len(DST), 1)
-		h.Sum(xorBuf[:0])                    // xorBuf = b_i
+		ctr[0] = byte(i)
+		_, _ = h.Write(xorBuf)     // strxor(b_0, b_(i - 1))
+		_, _ = h.Write(ctr[:1])    // I2OSP(i, 1)
+		_, _ = h.Write(x.dstPrime) // DST || I2OSP(len(DST), 1)
+		h.Sum(xorBuf[:0])          // xorBuf = b_i
 
 		// Append up to b_in_bytes from b_i (this handles the substr)
 		toAppend := wanted
@@ -148,64 +554,31 @@ func ExpandMessageXMD(out []byte, hFunc crypto.Hash, domainSeparator, message []
 	return nil
 }
 
-func newXOF(xofFunc sha3.ShakeHash) sha3.ShakeHash {
-	xof := xofFunc.Clone()
-	xof.Reset()
-
-	return xof
+type xmdStream struct {
+	x          *xmdState
+	lenInBytes int
 }
 
-// ExpandMessageXOF implements expand_message_xof, overwriting out with
-// uniformly random data generated by the provided extensible-output
-// function, domain separation tag, and message.
-//
-// Note: This needs to use the Clone() method of the XOF to instantiate
-// a new instance of the XOF.  At present there are 3 different XOF
-// interfaces in the x/crypto package, all mutually incompatible due
-// to the return type of Clone().  Complain to the x/crypto developers,
-// not me.
-func ExpandMessageXOF(out []byte, xofFunc sha3.ShakeHash, domainSeparator, message []byte) error {
-	lenInBytes := len(out)
-
-	// 0. Ensure parameters are sensible.
-	if lenInBytes == 0 || lenInBytes > math.MaxUint16 {
-		return fmt.Errorf("h2c: len_in_bytes out of range: %d", lenInBytes)
-	}
-
-	// Get a fresh instance of the XOF to work with.
-	xof := newXOF(xofFunc)
-
-	// Feed input into the XOF.  Since we have an XOF, we can feed the
-	// inputs into the XOF one-by-one instead of allocating a temporary
-	// buffer.
-
-	// 2. msg_prime = msg || I2OSP(len_in_bytes, 2) || DST_prime (appended next)
-	_, _ = xof.Write(message)                                         // msg
-	_, _ = xof.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes)}) // I2OSP(len_in_bytes, 2)
-
-	// 1. DST_prime = DST || I2OSP(len(DST), 1)
-	DST := domainSeparator
-	lenDST := len(domainSeparator)
-	if lenDST > math.MaxUint8 {
-		newDST := make([]byte, 2*kay/8)
-
-		dstXOF := newXOF(xofFunc)
-		_, _ = dstXOF.Write(oversizeDST)
-		_, _ = dstXOF.Write(DST)
-		if _, err := io.ReadFull(dstXOF, newDST); err != nil {
-			return fmt.Errorf("h2c: failed to read shortened DST: %w", err)
-		}
+func (s *xmdStream) Write(p []byte) (int, error) {
+	return s.x.h.Write(p)
+}
 
-		DST = newDST
-		lenDST = len(DST)
-	}
-	_, _ = xof.Write(DST)                  // DST
-	_, _ = xof.Write([]byte{byte(lenDST)}) // I2OSP(len(DST), 1)
+func (s *xmdStream) finish(out []byte) error {
+	// The state is returned to the pool, so drop the reference to it.
+	x := s.x
+	s.x = nil
+	defer x.release()
 
-	// 3. uniform_bytes = H(msg_prime, len_in_bytes)
-	if _, err := io.ReadFull(xof, out); err != nil {
-		return fmt.Errorf("h2c: failed to read XOF output: %w", err)
+	if len(out) != s.lenInBytes {
+		return fmt.Errorf("h2c: unexpected output length: %d", len(out))
 	}
+	return x.finish(out)
+}
 
-	return nil
+func (x *xmdState) wipe() {
+	wipeBytes(x.b0[:cap(x.b0)])
+	wipeBytes(x.b1[:cap(x.b1)])
+	wipeBytes(x.xorBuf[:cap(x.xorBuf)])
+	wipeBytes(x.ctrBuf)
+	x.h.Reset()
 }
@@ -0,0 +1,154 @@
+//go:build h2c_blake3
+// +build h2c_blake3
+
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"lukechampine.com/blake3"
+)
+
+// ExpanderXOFBLAKE3 is an Expander implementing `expand_message_xof`
+// with BLAKE3 in XOF mode as the extensible-output function.
+//
+// WARNING: BLAKE3 is not one of the XOFs specified in RFC 9380, so
+// suites using this expander are not interoperable with other
+// implementations unless they also support it.  This is only available
+// when built with the `h2c_blake3` build tag.
+var ExpanderXOFBLAKE3 Expander = &expanderXOFBLAKE3{}
+
+type expanderXOFBLAKE3 struct{}
+
+func (e *expanderXOFBLAKE3) ID() string {
+	return "XOF:BLAKE3"
+}
+
+func (e *expanderXOFBLAKE3) ExpandMessage(out, domainSeparator, message []byte) error {
+	return ExpandMessageXOFBLAKE3(out, domainSeparator, message)
+}
+
+func (e *expanderXOFBLAKE3) prepare(domainSeparator []byte) (preparedExpander, error) {
+	return newBLAKE3Params(domainSeparator)
+}
+
+// ExpandMessageXOFBLAKE3 implements expand_message_xof with BLAKE3 in
+// XOF mode, overwriting out with uniformly random data generated from
+// the provided domain separation tag, and message.
+func ExpandMessageXOFBLAKE3(out, domainSeparator, message []byte) error {
+	p, err := newBLAKE3Params(domainSeparator)
+	if err != nil {
+		return err
+	}
+	return expandPrepared(out, p, message)
+}
+
+// blake3Params is the message independent state used by
+// expand_message_xof with BLAKE3.  It is immutable once created.
+type blake3Params struct {
+	dstPrime []byte // DST || I2OSP(len(DST), 1)
+}
+
+func newBLAKE3Params(domainSeparator []byte) (*blake3Params, error) {
+	// 1. DST_prime = DST || I2OSP(len(DST), 1)
+	DST := domainSeparator
+	if len(DST) > math.MaxUint8 {
+		var err error
+		if DST, err = reduceDSTBLAKE3(DST); err != nil {
+			return nil, err
+		}
+	}
+	dstPrime := make([]byte, 0, len(DST)+1)
+	dstPrime = append(dstPrime, DST...)
+	dstPrime = append(dstPrime, byte(len(DST)))
+
+	return &blake3Params{
+		dstPrime: dstPrime,
+	}, nil
+}
+
+func (p *blake3Params) newMessageStream(lenInBytes int) (messageStream, error) {
+	// 0. Ensure parameters are sensible.
+	if err := checkOutputLength(lenInBytes); err != nil {
+		return nil, err
+	}
+
+	return &blake3Stream{
+		h:          blake3.New(2*kay/8, nil),
+		dstPrime:   p.dstPrime,
+		lenInBytes: lenInBytes,
+	}, nil
+}
+
+type blake3Stream struct {
+	h          *blake3.Hasher
+	dstPrime   []byte
+	lenInBytes int
+}
+
+func (s *blake3Stream) Write(p []byte) (int, error) {
+	return s.h.Write(p)
+}
+
+func (s *blake3Stream) finish(out []byte) error {
+	lenInBytes := s.lenInBytes
+	if len(out) != lenInBytes {
+		return fmt.Errorf("h2c: unexpected output length: %d", len(out))
+	}
+
+	// 2. msg_prime = msg || I2OSP(len_in_bytes, 2) || DST_prime
+	_, _ = s.h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes)}) // I2OSP(len_in_bytes, 2)
+	_, _ = s.h.Write(s.dstPrime)                                      // DST || I2OSP(len(DST), 1)
+
+	// 3. uniform_bytes = H(msg_prime, len_in_bytes)
+	if _, err := io.ReadFull(s.h.XOF(), out); err != nil {
+		return fmt.Errorf("h2c: failed to read XOF output: %w", err)
+	}
+	s.h.Reset()
+
+	return nil
+}
+
+func reduceDSTBLAKE3(domainSeparator []byte) ([]byte, error) {
+	DST := make([]byte, 2*kay/8)
+
+	h := blake3.New(len(DST), nil)
+	_, _ = h.Write(oversizeDST)
+	_, _ = h.Write(domainSeparator)
+	if _, err := io.ReadFull(h.XOF(), DST); err != nil {
+		return nil, fmt.Errorf("h2c: failed to read shortened DST: %w", err)
+	}
+
+	return DST, nil
+}
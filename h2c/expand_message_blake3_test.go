@@ -0,0 +1,107 @@
+//go:build h2c_blake3
+// +build h2c_blake3
+
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"testing"
+
+	"lukechampine.com/blake3"
+)
+
+func TestExpandMessageXOFBLAKE3(t *testing.T) {
+	msg := []byte("abc")
+
+	for _, dst := range [][]byte{
+		[]byte("QUUX-V01-CS02-with-expander-BLAKE3"),
+		bytes.Repeat([]byte{'D'}, 300),
+	} {
+		DST := dst
+		if len(DST) > 255 {
+			h := blake3.New(32, nil)
+			_, _ = h.Write(oversizeDST)
+			_, _ = h.Write(DST)
+			DST = h.Sum(nil)
+		}
+
+		for _, lenInBytes := range []int{32, 128, 1000} {
+			// msg_prime = msg || I2OSP(len_in_bytes, 2) || DST || I2OSP(len(DST), 1)
+			var msgPrime []byte
+			msgPrime = append(msgPrime, msg...)
+			msgPrime = append(msgPrime, byte(lenInBytes>>8), byte(lenInBytes))
+			msgPrime = append(msgPrime, DST...)
+			msgPrime = append(msgPrime, byte(len(DST)))
+
+			expected := make([]byte, lenInBytes)
+			h := blake3.New(32, nil)
+			_, _ = h.Write(msgPrime)
+			_, _ = h.XOF().Read(expected)
+
+			out := make([]byte, lenInBytes)
+			if err := ExpandMessageXOFBLAKE3(out, dst, msg); err != nil {
+				t.Fatalf("ExpandMessageXOFBLAKE3: %v", err)
+			}
+			if !bytes.Equal(out, expected) {
+				t.Fatalf("ExpandMessageXOFBLAKE3(%d, %d): output mismatch", len(dst), lenInBytes)
+			}
+		}
+	}
+
+	if err := ExpandMessageXOFBLAKE3(nil, []byte("DST"), msg); err == nil {
+		t.Fatalf("ExpandMessageXOFBLAKE3(nil): expected failure")
+	}
+
+	t.Run("Suite", func(t *testing.T) {
+		suite, err := NewSuite(Edwards25519, ExpanderXOFBLAKE3, true)
+		if err != nil {
+			t.Fatalf("NewSuite: %v", err)
+		}
+		if id := suite.ID(); id != "edwards25519_XOF:BLAKE3_ELL2_RO_" {
+			t.Fatalf("suite.ID: unexpected ID: '%s'", id)
+		}
+
+		dst := []byte("QUUX-V01-CS02-with-edwards25519_XOF:BLAKE3_ELL2_RO_")
+		p, err := suite.Hash(dst, msg)
+		if err != nil {
+			t.Fatalf("Hash: %v", err)
+		}
+
+		h := suite.NewHasher(dst)
+		_, _ = h.Write(msg)
+		q, err := h.SumPoint()
+		if err != nil {
+			t.Fatalf("SumPoint: %v", err)
+		}
+		testHasherEqual(t, Edwards25519, p, q)
+	})
+}
@@ -0,0 +1,134 @@
+//go:build !h2c_noxof
+// +build !h2c_noxof
+
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"golang.org/x/crypto/sha3"
+)
+
+type expanderCSHAKE struct {
+	xofID     string
+	newCShake func(N, S []byte) sha3.ShakeHash
+
+	// Cache of the XOF state after absorbing the most recently used
+	// customization string (DST), to avoid recomputing it.
+	l        sync.Mutex
+	cacheDST []byte
+	cacheXOF sha3.ShakeHash
+}
+
+func (e *expanderCSHAKE) ID() string {
+	return "CSHAKE:" + e.xofID
+}
+
+func (e *expanderCSHAKE) ExpandMessage(out, domainSeparator, message []byte) error {
+	if err := checkCSHAKEParams(len(out), domainSeparator); err != nil {
+		return err
+	}
+
+	e.l.Lock()
+	if e.cacheXOF == nil || !bytes.Equal(e.cacheDST, domainSeparator) {
+		e.cacheDST = append(e.cacheDST[:0], domainSeparator...)
+		e.cacheXOF = e.newCShake(nil, domainSeparator)
+	}
+	xof := e.cacheXOF.Clone()
+	e.l.Unlock()
+
+	return expandMessageCSHAKE(out, xof, message)
+}
+
+// NewExpanderCSHAKE returns an Expander that uses cSHAKE with the domain
+// separation tag as the customization string, with the provided cSHAKE
+// constructor (eg: `sha3.NewCShake256`), where xofID is the name of the
+// XOF as used in suite IDs (eg: `CSHAKE256`).
+//
+// The returned Expander caches the cSHAKE state for the most recently
+// used domain separation tag, and is safe for concurrent use.
+//
+// Note: This is not one of the `expand_message` variants specified in
+// RFC 9380, and is not interoperable with `expand_message_xof`.
+func NewExpanderCSHAKE(xofID string, newCShake func(N, S []byte) sha3.ShakeHash) Expander {
+	return &expanderCSHAKE{
+		xofID:     xofID,
+		newCShake: newCShake,
+	}
+}
+
+// ExpandMessageCSHAKE overwrites out with uniformly random data
+// generated by `cSHAKE(msg || I2OSP(len_in_bytes, 2), N="", S=DST)`,
+// with the provided cSHAKE constructor (eg: `sha3.NewCShake256`).
+//
+// As cSHAKE provides domain separation via the customization string,
+// this does not require the `DST_prime` suffix, and DSTs longer than
+// 255 bytes are used as-is.
+//
+// Note: This is not one of the `expand_message` variants specified in
+// RFC 9380, and is not interoperable with `expand_message_xof`.
+func ExpandMessageCSHAKE(out []byte, newCShake func(N, S []byte) sha3.ShakeHash, domainSeparator, message []byte) error {
+	if err := checkCSHAKEParams(len(out), domainSeparator); err != nil {
+		return err
+	}
+	return expandMessageCSHAKE(out, newCShake(nil, domainSeparator), message)
+}
+
+func checkCSHAKEParams(lenInBytes int, domainSeparator []byte) error {
+	if err := checkOutputLength(lenInBytes); err != nil {
+		return err
+	}
+
+	// With both N and S empty, cSHAKE is SHAKE, so require a DST.
+	if len(domainSeparator) == 0 {
+		return ErrEmptyDST
+	}
+
+	return nil
+}
+
+func expandMessageCSHAKE(out []byte, xof sha3.ShakeHash, message []byte) error {
+	lenInBytes := len(out)
+
+	_, _ = xof.Write(message)                                         // msg
+	_, _ = xof.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes)}) // I2OSP(len_in_bytes, 2)
+
+	if _, err := io.ReadFull(xof, out); err != nil {
+		return fmt.Errorf("h2c: failed to read XOF output: %w", err)
+	}
+	xof.Reset()
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"crypto"
+	"encoding"
+	"fmt"
+)
+
+// PrefixExpanderXMD is `expand_message_xmd` for messages that share a
+// common prefix, with the hash state after absorbing `Z_pad || prefix`
+// precomputed.  This saves at least one compression function invocation
+// (for `Z_pad`) per message, and more for long prefixes, which is useful
+// for things like verifier loops where the message is `PK || alpha`,
+// with a fixed PK.
+//
+// The uniform bytes can be mapped to the curve with the `FromUniform`
+// suite variants (eg: Edwards25519_ELL2_NU_FromUniform).
+//
+// PrefixExpanderXMD instances are immutable, and are safe for concurrent
+// use.
+type PrefixExpanderXMD struct {
+	params      *xmdParams
+	prefixState []byte
+}
+
+// NewPrefixExpanderXMD creates a new PrefixExpanderXMD for the hash
+// function, domain separation tag, and message prefix.  The hash
+// function's hash.Hash implementation must also implement
+// encoding.BinaryMarshaler and encoding.BinaryUnmarshaler, which is
+// the case for all of the standard library hash functions.
+func NewPrefixExpanderXMD(hFunc crypto.Hash, domainSeparator, prefix []byte) (*PrefixExpanderXMD, error) {
+	p, err := newXMDParams(hFunc, domainSeparator)
+	if err != nil {
+		return nil, err
+	}
+
+	h := hFunc.New()
+	m, ok := h.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("%w: hash state is not serializable", ErrInvalidHash)
+	}
+	if _, ok = h.(encoding.BinaryUnmarshaler); !ok {
+		return nil, fmt.Errorf("%w: hash state is not deserializable", ErrInvalidHash)
+	}
+
+	_, _ = h.Write(p.zPad) // Z_pad (I2OSP(0, r_in_bytes))
+	_, _ = h.Write(prefix) // msg (prefix)
+
+	prefixState, err := m.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("h2c: failed to serialize hash state: %w", err)
+	}
+
+	return &PrefixExpanderXMD{
+		params:      p,
+		prefixState: prefixState,
+	}, nil
+}
+
+// ExpandMessage overwrites out with uniformly random data generated
+// from `prefix || suffix`.  This is equivalent to calling
+// ExpandMessageXMD with the concatenated message.
+func (pe *PrefixExpanderXMD) ExpandMessage(out, suffix []byte) error {
+	x := pe.params.newState()
+	defer x.release()
+
+	if err := x.checkLength(len(out)); err != nil {
+		return err
+	}
+	if err := x.h.(encoding.BinaryUnmarshaler).UnmarshalBinary(pe.prefixState); err != nil {
+		return fmt.Errorf("h2c: failed to restore hash state: %w", err)
+	}
+	_, _ = x.h.Write(suffix) // msg (suffix)
+
+	return x.finish(out)
+}
@@ -30,14 +30,17 @@
 package h2c
 
 import (
+	"bytes"
 	"crypto"
+	"crypto/md5"
+	"crypto/sha1"
 	_ "crypto/sha256"
 	_ "crypto/sha512"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"hash"
 	"testing"
-
-	"golang.org/x/crypto/sha3"
 )
 
 type expandMessageTestVector struct {
@@ -60,24 +63,326 @@ func (vec *expandMessageTestVector) checkXMD(hFunc crypto.Hash, dst []byte) erro
 	return nil
 }
 
-func (vec *expandMessageTestVector) checkXOF(xofFunc sha3.ShakeHash, dst []byte) error {
-	outLen := len(vec.expected) / 2 // Hex string to bytes
-	out := make([]byte, outLen)
+func TestExpandMessage(t *testing.T) {
+	t.Run("XMD", testExpandMessageXMD)
+	t.Run("Errors", testExpandMessageErrors)
+	t.Run("XMDBatch", testExpandMessageXMDBatch)
+	t.Run("HKDF", testExpandMessageHKDF)
+	t.Run("HashParams", testExpandMessageHashParams)
+	t.Run("ExpanderAlg", testExpandMessageExpanderAlg)
+	t.Run("ReduceDST", testExpandMessageReduceDST)
+	t.Run("Prefix", testExpandMessagePrefix)
+}
 
-	if err := ExpandMessageXOF(out, xofFunc, dst, []byte(vec.msg)); err != nil {
-		return err
+func testExpandMessagePrefix(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander")
+
+	for _, hFunc := range []crypto.Hash{crypto.SHA256, crypto.SHA512} {
+		for _, prefixLen := range []int{0, 32, 300} {
+			prefix := bytes.Repeat([]byte{'P'}, prefixLen)
+
+			pe, err := NewPrefixExpanderXMD(hFunc, dst, prefix)
+			if err != nil {
+				t.Fatalf("NewPrefixExpanderXMD: %v", err)
+			}
+
+			for _, suffix := range [][]byte{nil, []byte("abc"), bytes.Repeat([]byte{'S'}, 200)} {
+				for _, outLen := range []int{32, 128, 255} {
+					msg := append(append([]byte{}, prefix...), suffix...)
+
+					expected := make([]byte, outLen)
+					if err = ExpandMessageXMD(expected, hFunc, dst, msg); err != nil {
+						t.Fatalf("ExpandMessageXMD: %v", err)
+					}
+
+					out := make([]byte, outLen)
+					if err = pe.ExpandMessage(out, suffix); err != nil {
+						t.Fatalf("PrefixExpanderXMD.ExpandMessage: %v", err)
+					}
+					if !bytes.Equal(out, expected) {
+						t.Fatalf("%v/%d/%d/%d: output mismatch", hFunc, prefixLen, len(suffix), outLen)
+					}
+				}
+			}
+
+			if err = pe.ExpandMessage(nil, nil); !errors.Is(err, ErrZeroLength) {
+				t.Fatalf("PrefixExpanderXMD.ExpandMessage(nil): unexpected error: %v", err)
+			}
+		}
 	}
+}
 
-	if outHex := hex.EncodeToString(out); outHex != vec.expected {
-		return fmt.Errorf("output mismatch: got '%s'", outHex)
+func testExpandMessageReduceDST(t *testing.T) {
+	msg := []byte("abc")
+
+	for _, dst := range [][]byte{
+		[]byte("QUUX-V01-CS02-with-expander"),
+		bytes.Repeat([]byte{'D'}, 300),
+	} {
+		reduced, err := ReduceDSTXMD(crypto.SHA256, dst)
+		if err != nil {
+			t.Fatalf("ReduceDSTXMD: %v", err)
+		}
+		if len(dst) <= 255 && !bytes.Equal(reduced, dst) {
+			t.Fatalf("ReduceDSTXMD: short DST modified")
+		}
+		if len(dst) > 255 && len(reduced) != crypto.SHA256.Size() {
+			t.Fatalf("ReduceDSTXMD: unexpected length: %d", len(reduced))
+		}
+
+		expected := make([]byte, 128)
+		if err = ExpandMessageXMD(expected, crypto.SHA256, dst, msg); err != nil {
+			t.Fatalf("ExpandMessageXMD: %v", err)
+		}
+		out := make([]byte, 128)
+		if err = ExpandMessageXMD(out, crypto.SHA256, reduced, msg); err != nil {
+			t.Fatalf("ExpandMessageXMD(reduced): %v", err)
+		}
+		if !bytes.Equal(out, expected) {
+			t.Fatalf("ExpandMessageXMD(reduced): output mismatch")
+		}
 	}
 
-	return nil
+	if _, err := ReduceDSTXMD(crypto.MD4, []byte("DST")); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("ReduceDSTXMD(MD4): unexpected error: %v", err)
+	}
 }
 
-func TestExpandMessage(t *testing.T) {
-	t.Run("XMD", testExpandMessageXMD)
-	t.Run("XOF", testExpandMessageXOF)
+func testExpandMessageExpanderAlg(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander")
+	msg := []byte("abc")
+
+	for _, v := range []struct {
+		alg   ExpanderAlg
+		id    string
+		hFunc crypto.Hash
+	}{
+		{ExpanderXMDSHA256, "XMD:SHA-256", crypto.SHA256},
+		{ExpanderXMDSHA384, "XMD:SHA-384", crypto.SHA384},
+		{ExpanderXMDSHA512, "XMD:SHA-512", crypto.SHA512},
+	} {
+		if id := v.alg.String(); id != v.id {
+			t.Fatalf("%v: unexpected ID: '%s'", v.alg, id)
+		}
+
+		expected := make([]byte, 128)
+		if err := ExpandMessageXMD(expected, v.hFunc, dst, msg); err != nil {
+			t.Fatalf("%v: ExpandMessageXMD: %v", v.alg, err)
+		}
+		out := make([]byte, 128)
+		if err := ExpandMessage(out, v.alg, dst, msg); err != nil {
+			t.Fatalf("%v: ExpandMessage: %v", v.alg, err)
+		}
+		if !bytes.Equal(out, expected) {
+			t.Fatalf("%v: output mismatch", v.alg)
+		}
+	}
+
+	out := make([]byte, 32)
+	for _, alg := range []ExpanderAlg{0, ExpanderXOFSHAKE256 + 1} {
+		if err := ExpandMessage(out, alg, dst, msg); !errors.Is(err, ErrUnsupportedExpander) {
+			t.Fatalf("%v: unexpected error: %v", alg, err)
+		}
+	}
+}
+
+// md5sha1 is MD5 || SHA-1 (crypto.MD5SHA1), which does not report a
+// block size.
+type md5sha1 struct {
+	md5, sha1 hash.Hash
+}
+
+func (h *md5sha1) Write(p []byte) (int, error) {
+	_, _ = h.md5.Write(p)
+	return h.sha1.Write(p)
+}
+
+func (h *md5sha1) Sum(b []byte) []byte {
+	return h.sha1.Sum(h.md5.Sum(b))
+}
+
+func (h *md5sha1) Reset() {
+	h.md5.Reset()
+	h.sha1.Reset()
+}
+
+func (h *md5sha1) Size() int      { return md5.Size + sha1.Size }
+func (h *md5sha1) BlockSize() int { return 0 }
+
+func testExpandMessageHashParams(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander")
+	msg := []byte("abc")
+
+	// Nothing else uses MD5SHA1, so it is safe to register it, and
+	// params for it.  Registration is one-shot, so only do so once
+	// when the test is run multiple times.
+	hFunc := crypto.MD5SHA1
+	out := make([]byte, 64)
+	if !hFunc.Available() {
+		crypto.RegisterHash(hFunc, func() hash.Hash {
+			return &md5sha1{md5: md5.New(), sha1: sha1.New()}
+		})
+
+		if err := ExpandMessageXMD(out, hFunc, dst, msg); err == nil {
+			t.Fatalf("ExpandMessageXMD: accepted unknown block size")
+		}
+
+		if err := RegisterHashParams(hFunc, 64, 64); err == nil {
+			t.Fatalf("RegisterHashParams: accepted invalid output size")
+		}
+		if err := RegisterHashParams(hFunc, 0, hFunc.Size()); err == nil {
+			t.Fatalf("RegisterHashParams: accepted invalid block size")
+		}
+		if err := RegisterHashParams(hFunc, 64, hFunc.Size()); err != nil {
+			t.Fatalf("RegisterHashParams: %v", err)
+		}
+	}
+	if err := ExpandMessageXMD(out, hFunc, dst, msg); err != nil {
+		t.Fatalf("ExpandMessageXMD: %v", err)
+	}
+	if err := RegisterHashParams(hFunc, 128, hFunc.Size()); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("RegisterHashParams(again): unexpected error: %v", err)
+	}
+
+	// Hash functions that report a block size can not be redefined.
+	for _, hFunc := range []crypto.Hash{crypto.SHA256, crypto.SHA512, crypto.SHA512_256} {
+		expected := make([]byte, 64)
+		if err := ExpandMessageXMD(expected, hFunc, dst, msg); err != nil {
+			t.Fatalf("ExpandMessageXMD(%v): %v", hFunc, err)
+		}
+		if err := RegisterHashParams(hFunc, 64, hFunc.Size()); !errors.Is(err, ErrInvalidHash) {
+			t.Fatalf("RegisterHashParams(%v): unexpected error: %v", hFunc, err)
+		}
+		if err := ExpandMessageXMD(out, hFunc, dst, msg); err != nil {
+			t.Fatalf("ExpandMessageXMD(%v): %v", hFunc, err)
+		}
+		if !bytes.Equal(out, expected) {
+			t.Fatalf("ExpandMessageXMD(%v): output changed", hFunc)
+		}
+	}
+
+	if err := RegisterHashParams(crypto.MD4, 64, 16); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("RegisterHashParams(MD4): unexpected error: %v", err)
+	}
+	if err := ExpandMessageXMD(out, crypto.MD4, dst, msg); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("ExpandMessageXMD(MD4): unexpected error: %v", err)
+	}
+}
+
+func TestExpandMessageXMDAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool is nondeterministic with the race detector")
+	}
+
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	longDST := bytes.Repeat([]byte("a"), 256)
+	msg := []byte("abc")
+	out := make([]byte, 128)
+
+	for _, hFunc := range []crypto.Hash{crypto.SHA256, crypto.SHA512} {
+		for _, d := range [][]byte{dst, longDST} {
+			if n := testing.AllocsPerRun(100, func() {
+				_ = ExpandMessageXMD(out, hFunc, d, msg)
+			}); n != 0 {
+				t.Fatalf("ExpandMessageXMD(%v, len(DST) = %d): %v allocations", hFunc, len(d), n)
+			}
+		}
+	}
+}
+
+func testExpandMessageHKDF(t *testing.T) {
+	// RFC 5869 A.1. Test Case 1
+	ikm := bytes.Repeat([]byte{0x0b}, 22)
+	salt, _ := hex.DecodeString("000102030405060708090a0b0c")
+	info, _ := hex.DecodeString("f0f1f2f3f4f5f6f7f8f9")
+	expected := "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865"
+
+	out := make([]byte, 42)
+	if err := ExpandMessageHKDF(out, crypto.SHA256, salt, info, ikm); err != nil {
+		t.Fatalf("ExpandMessageHKDF: %v", err)
+	}
+	if outHex := hex.EncodeToString(out); outHex != expected {
+		t.Fatalf("ExpandMessageHKDF: output mismatch: got '%s'", outHex)
+	}
+
+	for _, v := range []struct {
+		n        string
+		hFunc    crypto.Hash
+		outLen   int
+		expected error
+	}{
+		{"ZeroLength", crypto.SHA256, 0, ErrZeroLength},
+		{"LenInBytes", crypto.SHA256, 255*32 + 1, ErrOutputTooLong},
+		{"BInBytes", crypto.SHA1, 32, ErrInvalidHash},
+	} {
+		out := make([]byte, v.outLen)
+		err := ExpandMessageHKDF(out, v.hFunc, salt, info, ikm)
+		if !errors.Is(err, v.expected) {
+			t.Fatalf("%s: unexpected error: %v", v.n, err)
+		}
+	}
+}
+
+func testExpandMessageXMDBatch(t *testing.T) {
+	for _, dst := range [][]byte{
+		[]byte("QUUX-V01-CS02-with-expander"),
+		bytes.Repeat([]byte{'D'}, 300),
+	} {
+		msgs := [][]byte{
+			[]byte(""),
+			[]byte("abc"),
+			[]byte("abcdef0123456789"),
+			bytes.Repeat([]byte{'q'}, 128),
+		}
+		outs := make([][]byte, 0, len(msgs))
+		for i := range msgs {
+			outs = append(outs, make([]byte, 32+i*48))
+		}
+
+		if err := ExpandMessageXMDBatch(outs, crypto.SHA512, dst, msgs); err != nil {
+			t.Fatalf("ExpandMessageXMDBatch: %v", err)
+		}
+		for i, msg := range msgs {
+			expected := make([]byte, len(outs[i]))
+			if err := ExpandMessageXMD(expected, crypto.SHA512, dst, msg); err != nil {
+				t.Fatalf("ExpandMessageXMD[%d]: %v", i, err)
+			}
+			if !bytes.Equal(outs[i], expected) {
+				t.Fatalf("ExpandMessageXMDBatch[%d]: output mismatch", i)
+			}
+		}
+	}
+
+	dst := []byte("QUUX-V01-CS02-with-expander")
+	err := ExpandMessageXMDBatch(make([][]byte, 2), crypto.SHA512, dst, make([][]byte, 1))
+	if !errors.Is(err, ErrBatchMismatch) {
+		t.Fatalf("ExpandMessageXMDBatch: unexpected error: %v", err)
+	}
+	err = ExpandMessageXMDBatch([][]byte{make([]byte, 32), nil}, crypto.SHA512, dst, make([][]byte, 2))
+	if !errors.Is(err, ErrZeroLength) {
+		t.Fatalf("ExpandMessageXMDBatch: unexpected error: %v", err)
+	}
+}
+
+func testExpandMessageErrors(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander")
+	for _, v := range []struct {
+		n        string
+		hFunc    crypto.Hash
+		outLen   int
+		expected error
+	}{
+		{"ZeroLength", crypto.SHA256, 0, ErrZeroLength},
+		{"LenInBytes", crypto.SHA512, 65536, ErrOutputTooLong},
+		{"Ell", crypto.SHA256, 255*32 + 1, ErrOutputTooLong},
+		{"BInBytes", crypto.SHA1, 32, ErrInvalidHash},
+	} {
+		out := make([]byte, v.outLen)
+		err := ExpandMessageXMD(out, v.hFunc, dst, []byte("abc"))
+		if !errors.Is(err, v.expected) {
+			t.Fatalf("%s: unexpected error: %v", v.n, err)
+		}
+	}
 }
 
 func testExpandMessageXMD(t *testing.T) {
@@ -186,110 +491,3 @@ func testExpandMessageXMD(t *testing.T) {
 		}
 	})
 }
-
-func testExpandMessageXOF(t *testing.T) {
-	t.Run("SHAKE128", func(t *testing.T) {
-		// K.3. expand_message_xof(SHAKE128)
-		dst := []byte("QUUX-V01-CS02-with-expander")
-		vecs := []expandMessageTestVector{
-			{
-				msg:      "",
-				expected: "eca3fe8f7f5f1d52d7ed3691c321adc7d2a0fef1f843d221f7002530070746de",
-			},
-			{
-				msg:      "abc",
-				expected: "c79b8ea0af10fd8871eda98334ea9d54e9e5282be97521678f987718b187bc08",
-			},
-			{
-				msg:      "abcdef0123456789",
-				expected: "fb6f4af2a83f6276e9d41784f1e29da5e27566167c33e5cf2682c30096878b73",
-			},
-			{
-				msg:      "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
-				expected: "125d05850db915e0683d17d044d87477e6e7b3f70a450dd097761e18d1d1dcdf",
-			},
-			{
-				msg:      "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
-				expected: "beafd026cb942c86f6a2b31bb8e6bf7173fb1b0caf3c21ea4b3b9d05d904fd23",
-			},
-			{
-				msg:      "",
-				expected: "15733b3fb22fac0e0902c220aeea48e5e47d39f36c2cc03eac34367c48f2a3ebbcb3baa8a0cf17ab12fff4defc7ce22aed47188b6c163e828741473bd89cc646a082cb68b8e835b1374ea9a6315d61db0043f4abf506c26386e84668e077c85ebd9d632f4390559b979e70e9e7affbd0ac2a212c03b698efbbe940f2d164732b",
-			},
-			{
-				msg:      "abc",
-				expected: "4ccafb6d95b91537798d1fbb25b9fbe1a5bbe1683f43a4f6f03ef540b811235317bfc0aefb217faca055e1b8f32dfde9eb102cdc026ed27caa71530e361b3adbb92ccf68da35aed8b9dc7e4e6b5db0666c607a31df05513ddaf4c8ee23b0ee7f395a6e8be32eb13ca97da289f2643616ac30fe9104bb0d3a67a0a525837c2dc6",
-			},
-			{
-				msg:      "abcdef0123456789",
-				expected: "c8ee0e12736efbc9b47781db9d1e5db9c853684344a6776eb362d75b354f4b74cf60ba1373dc2e22c68efb76a022ed5391f67c77990802018c8cdc7af6d00c86b66a3b3ccad3f18d90f4437a165186f6601cf0bb281ea5d80d1de20fe22bb2e2d8acab0c043e76e3a0f34e0a1e66c9ade4fef9ef3b431130ad6f232babe9fe68",
-			},
-			{
-				msg:      "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
-				expected: "3eebe6721b2ec746629856dc2dd3f03a830dabfefd7e2d1e72aaf2127d6ad17c988b5762f32e6edf61972378a4106dc4b63fa108ad03b793eedf4588f34c4df2a95b30995a464cb3ee31d6dca30adbfc90ffdf5414d7893082c55b269d9ec9cd6d2a715b9c4fad4eb70ed56f878b55a17b5994ef0de5b338675aad35354195cd",
-			},
-			{
-				msg:      "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
-				expected: "858cb4a6a5668a97d0f7039b5d6d574dde18dd2323cf6b203945c66df86477d1f747b46401903b3fa66d1276108ea7187b4411b7499acf4600080ce34ff6d21555c2af16f091adf8b285c8439f2e47fa0553c3a6ef5a4227a13f34406241b7d7fd8853a080bad25ec4804cdfe4fda500e1c872e71b8c61a8e160691894b96058",
-			},
-		}
-
-		for i, vec := range vecs {
-			if err := vec.checkXOF(sha3.NewShake128(), dst); err != nil {
-				t.Fatalf("Test vector[%d]: %v", i, err)
-			}
-		}
-	})
-	t.Run("SHAKE256", func(t *testing.T) {
-		// K.4. expand_message_xof(SHAKE256)
-		dst := []byte("QUUX-V01-CS02-with-expander")
-		vecs := []expandMessageTestVector{
-			{
-				msg:      "",
-				expected: "58e90433d81860c47d350b0bb6fb94f98f6b0f9657efd04d410ae743260c096d",
-			},
-			{
-				msg:      "abc",
-				expected: "c7f5e3c044790033707e24f21d971aaa03a760dfda6215bf0c8634da9012c8f8",
-			},
-			{
-				msg:      "abcdef0123456789",
-				expected: "f46930964d5d5006ef992f5878d7c255c9a92aed1032c9b9d4743ec1470a91e8",
-			},
-			{
-				msg:      "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
-				expected: "885baaf4841ad28aa853022289cb4841cc6c1bf200c579e8aebb8d005a8ff37f",
-			},
-			{
-				msg:      "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
-				expected: "4a9884b31a64772244df05622222db6cb9942034370d2400e39bb853cca727f7",
-			},
-			{
-				msg:      "",
-				expected: "4cbc65744a4a26c059472822a4647887abb4a3220d5c1e1155c4180a04c69541d437b77676fc5b6450faa5cb906d88a8fa7e1c6807d0a66f0092cc022812368e75ba41dcb4daab00a17e752d485f5e21f835ac36f05b9d0217c79376045e1360faa4652db9d7752af1ffb76ae14cf6aabd7b08b19032d213415d2cef8cd6b62f",
-			},
-			{
-				msg:      "abc",
-				expected: "c5f366dc668697014a0a90a40ae27c19edcb8500f6ad5d4234fbf4204f64df524a44adaecb42102fffeb7686949aa6785142b2510a419dd29dadf1f2b455688c043f6bc2fd76b101dd8e41cca4042514a6b15d137d958735961e3c32a49e0640ad564d533d20adc203c5befdb1186ca18646b729a5cb4531922d24a17b4389ea",
-			},
-			{
-				msg:      "abcdef0123456789",
-				expected: "34dcc64cee945b94c5e29a9aa6b859b8a9705fe020bf7443eaab4c8269e739904e2703cef64e1823b5c848570db97b28da7869f52c24573d8f759b7181726e186dcff940eea5f70a11ebd14b4c90c3b17805ce91dc3157ce635e9d11fe56d86dfa76a79e84c11e253653350d2f954922077f2ea6a17104dd0fd963d7fe4568d3",
-			},
-			{
-				msg:      "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
-				expected: "b0adc10a4326ae3ddf11c42afb89058625f8812c76b2a0fb17570f7a2acb030e8dd20036d1326984fd0d973197d80fbf461fe18ef394b9a22e609e61d710df43476ddf3a8ca4d32b737bc265d14a204f32173e447db74bc68938b6a6a08e3e9a31968e5d05a0ca213c977e94cffc9a535b5c5198a6c5892bbce1a35ecc7ab2bd",
-			},
-			{
-				msg:      "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
-				expected: "50a0cb335f3102a15f3dfed981b0a5fecb3136112532e129d39369a2a92c32a9b0a0181af9839039c0e98a3b66a0d209fa019134991055284c3f475c9f7c91169dea57aad442f0c98418d36e50fad68e8863109dac6d8cfc6c5fa63e8f1c0468af9980066e87b62caa87f4b3feef0dba8ef894f2957105d111439597d3265b1f",
-			},
-		}
-
-		for i, vec := range vecs {
-			if err := vec.checkXOF(sha3.NewShake256(), dst); err != nil {
-				t.Fatalf("Test vector[%d]: %v", i, err)
-			}
-		}
-	})
-}
@@ -0,0 +1,209 @@
+//go:build !h2c_noxof
+// +build !h2c_noxof
+
+// Copyright (c) 2021 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"golang.org/x/crypto/sha3"
+)
+
+type expanderXOF struct {
+	xofID  string
+	newXOF func() sha3.ShakeHash
+}
+
+func (e *expanderXOF) ID() string {
+	return "XOF:" + e.xofID
+}
+
+func (e *expanderXOF) ExpandMessage(out, domainSeparator, message []byte) error {
+	return ExpandMessageXOFFunc(out, e.newXOF, domainSeparator, message)
+}
+
+// NewExpanderXOF returns an Expander implementing `expand_message_xof`
+// with the provided extensible-output function constructor (eg:
+// `sha3.NewShake256`), where xofID is the name of the XOF as used in
+// suite IDs (eg: `SHAKE256`).
+func NewExpanderXOF(xofID string, newXOF func() sha3.ShakeHash) Expander {
+	return &expanderXOF{
+		xofID:  xofID,
+		newXOF: newXOF,
+	}
+}
+
+func cloneXOFFunc(xofFunc sha3.ShakeHash) func() sha3.ShakeHash {
+	return func() sha3.ShakeHash {
+		xof := xofFunc.Clone()
+		xof.Reset()
+
+		return xof
+	}
+}
+
+// ExpandMessageXOF implements expand_message_xof, overwriting out with
+// uniformly random data generated by the provided extensible-output
+// function, domain separation tag, and message.
+//
+// Note: This needs to use the Clone() method of the XOF to instantiate
+// a new instance of the XOF.  At present there are 3 different XOF
+// interfaces in the x/crypto package, all mutually incompatible due
+// to the return type of Clone().  Complain to the x/crypto developers,
+// not me.
+//
+// The provided XOF instance is only used as a template, and is never
+// written to, however ExpandMessageXOFFunc is preferred.
+func ExpandMessageXOF(out []byte, xofFunc sha3.ShakeHash, domainSeparator, message []byte) error {
+	return ExpandMessageXOFFunc(out, cloneXOFFunc(xofFunc), domainSeparator, message)
+}
+
+// ExpandMessageXOFFunc implements expand_message_xof, overwriting out
+// with uniformly random data generated by the provided extensible-output
+// function constructor (eg: `sha3.NewShake256`), domain separation tag,
+// and message.
+//
+// The constructor must return a new independent instance of the XOF,
+// each time it is called.
+func ExpandMessageXOFFunc(out []byte, newXOF func() sha3.ShakeHash, domainSeparator, message []byte) error {
+	p, err := newXOFParams(newXOF, domainSeparator)
+	if err != nil {
+		return err
+	}
+	return expandPrepared(out, p, message)
+}
+
+func (e *expanderXOF) prepare(domainSeparator []byte) (preparedExpander, error) {
+	return newXOFParams(e.newXOF, domainSeparator)
+}
+
+// xofParams is the message independent state used by expand_message_xof.
+// It is immutable once created.
+type xofParams struct {
+	newXOF   func() sha3.ShakeHash
+	dstPrime []byte // DST || I2OSP(len(DST), 1)
+}
+
+func newXOFParams(newXOF func() sha3.ShakeHash, domainSeparator []byte) (*xofParams, error) {
+	// 1. DST_prime = DST || I2OSP(len(DST), 1)
+	DST := domainSeparator
+	if len(DST) > math.MaxUint8 {
+		var err error
+		if DST, err = reduceDSTXOF(newXOF, DST); err != nil {
+			return nil, err
+		}
+	}
+	dstPrime := make([]byte, 0, len(DST)+1)
+	dstPrime = append(dstPrime, DST...)
+	dstPrime = append(dstPrime, byte(len(DST)))
+
+	return &xofParams{
+		newXOF:   newXOF,
+		dstPrime: dstPrime,
+	}, nil
+}
+
+func (p *xofParams) newMessageStream(lenInBytes int) (messageStream, error) {
+	// 0. Ensure parameters are sensible.
+	if err := checkOutputLength(lenInBytes); err != nil {
+		return nil, err
+	}
+
+	// Get a fresh instance of the XOF to work with.
+	//
+	// Since we have an XOF, we can feed the inputs into the XOF one-by-one
+	// instead of allocating a temporary buffer.
+	return &xofStream{
+		xof:        p.newXOF(),
+		dstPrime:   p.dstPrime,
+		lenInBytes: lenInBytes,
+	}, nil
+}
+
+type xofStream struct {
+	xof        sha3.ShakeHash
+	dstPrime   []byte
+	lenInBytes int
+}
+
+func (s *xofStream) Write(p []byte) (int, error) {
+	return s.xof.Write(p)
+}
+
+func (s *xofStream) finish(out []byte) error {
+	lenInBytes := s.lenInBytes
+	if len(out) != lenInBytes {
+		return fmt.Errorf("h2c: unexpected output length: %d", len(out))
+	}
+
+	// 2. msg_prime = msg || I2OSP(len_in_bytes, 2) || DST_prime
+	_, _ = s.xof.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes)}) // I2OSP(len_in_bytes, 2)
+	_, _ = s.xof.Write(s.dstPrime)                                      // DST || I2OSP(len(DST), 1)
+
+	// 3. uniform_bytes = H(msg_prime, len_in_bytes)
+	if _, err := io.ReadFull(s.xof, out); err != nil {
+		return fmt.Errorf("h2c: failed to read XOF output: %w", err)
+	}
+	s.xof.Reset()
+
+	return nil
+}
+
+// ReduceDSTXOFFunc returns the domain separation tag that
+// `expand_message_xof` with the provided XOF constructor will use for
+// domainSeparator.  For DSTs longer than 255 bytes, this is
+// `H("H2C-OVERSIZE-DST-" || DST, ceil(2 * k / 8))`, otherwise it is a
+// copy of domainSeparator.
+//
+// As the reduced DST is at most 255 bytes long, it can be used in place
+// of the original DST to avoid recomputing the reduction on every call.
+func ReduceDSTXOFFunc(newXOF func() sha3.ShakeHash, domainSeparator []byte) ([]byte, error) {
+	if len(domainSeparator) <= math.MaxUint8 {
+		return append([]byte{}, domainSeparator...), nil
+	}
+	return reduceDSTXOF(newXOF, domainSeparator)
+}
+
+func reduceDSTXOF(newXOF func() sha3.ShakeHash, domainSeparator []byte) ([]byte, error) {
+	DST := make([]byte, 2*kay/8)
+
+	dstXOF := newXOF()
+	_, _ = dstXOF.Write(oversizeDST)
+	_, _ = dstXOF.Write(domainSeparator)
+	if _, err := io.ReadFull(dstXOF, DST); err != nil {
+		return nil, fmt.Errorf("h2c: failed to read shortened DST: %w", err)
+	}
+
+	return DST, nil
+}
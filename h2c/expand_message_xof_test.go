@@ -0,0 +1,296 @@
+//go:build !h2c_noxof
+// +build !h2c_noxof
+
+// Copyright (c) 2021 Oasis Labs Inc. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+)
+
+func (vec *expandMessageTestVector) checkXOF(xofFunc sha3.ShakeHash, dst []byte) error {
+	outLen := len(vec.expected) / 2 // Hex string to bytes
+	out := make([]byte, outLen)
+
+	if err := ExpandMessageXOF(out, xofFunc, dst, []byte(vec.msg)); err != nil {
+		return err
+	}
+
+	if outHex := hex.EncodeToString(out); outHex != vec.expected {
+		return fmt.Errorf("output mismatch: got '%s'", outHex)
+	}
+
+	return nil
+}
+
+func TestExpandMessageXOF(t *testing.T) {
+	t.Run("SHAKE128", func(t *testing.T) {
+		// K.3. expand_message_xof(SHAKE128)
+		dst := []byte("QUUX-V01-CS02-with-expander")
+		vecs := []expandMessageTestVector{
+			{
+				msg:      "",
+				expected: "eca3fe8f7f5f1d52d7ed3691c321adc7d2a0fef1f843d221f7002530070746de",
+			},
+			{
+				msg:      "abc",
+				expected: "c79b8ea0af10fd8871eda98334ea9d54e9e5282be97521678f987718b187bc08",
+			},
+			{
+				msg:      "abcdef0123456789",
+				expected: "fb6f4af2a83f6276e9d41784f1e29da5e27566167c33e5cf2682c30096878b73",
+			},
+			{
+				msg:      "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
+				expected: "125d05850db915e0683d17d044d87477e6e7b3f70a450dd097761e18d1d1dcdf",
+			},
+			{
+				msg:      "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				expected: "beafd026cb942c86f6a2b31bb8e6bf7173fb1b0caf3c21ea4b3b9d05d904fd23",
+			},
+			{
+				msg:      "",
+				expected: "15733b3fb22fac0e0902c220aeea48e5e47d39f36c2cc03eac34367c48f2a3ebbcb3baa8a0cf17ab12fff4defc7ce22aed47188b6c163e828741473bd89cc646a082cb68b8e835b1374ea9a6315d61db0043f4abf506c26386e84668e077c85ebd9d632f4390559b979e70e9e7affbd0ac2a212c03b698efbbe940f2d164732b",
+			},
+			{
+				msg:      "abc",
+				expected: "4ccafb6d95b91537798d1fbb25b9fbe1a5bbe1683f43a4f6f03ef540b811235317bfc0aefb217faca055e1b8f32dfde9eb102cdc026ed27caa71530e361b3adbb92ccf68da35aed8b9dc7e4e6b5db0666c607a31df05513ddaf4c8ee23b0ee7f395a6e8be32eb13ca97da289f2643616ac30fe9104bb0d3a67a0a525837c2dc6",
+			},
+			{
+				msg:      "abcdef0123456789",
+				expected: "c8ee0e12736efbc9b47781db9d1e5db9c853684344a6776eb362d75b354f4b74cf60ba1373dc2e22c68efb76a022ed5391f67c77990802018c8cdc7af6d00c86b66a3b3ccad3f18d90f4437a165186f6601cf0bb281ea5d80d1de20fe22bb2e2d8acab0c043e76e3a0f34e0a1e66c9ade4fef9ef3b431130ad6f232babe9fe68",
+			},
+			{
+				msg:      "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
+				expected: "3eebe6721b2ec746629856dc2dd3f03a830dabfefd7e2d1e72aaf2127d6ad17c988b5762f32e6edf61972378a4106dc4b63fa108ad03b793eedf4588f34c4df2a95b30995a464cb3ee31d6dca30adbfc90ffdf5414d7893082c55b269d9ec9cd6d2a715b9c4fad4eb70ed56f878b55a17b5994ef0de5b338675aad35354195cd",
+			},
+			{
+				msg:      "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				expected: "858cb4a6a5668a97d0f7039b5d6d574dde18dd2323cf6b203945c66df86477d1f747b46401903b3fa66d1276108ea7187b4411b7499acf4600080ce34ff6d21555c2af16f091adf8b285c8439f2e47fa0553c3a6ef5a4227a13f34406241b7d7fd8853a080bad25ec4804cdfe4fda500e1c872e71b8c61a8e160691894b96058",
+			},
+		}
+
+		for i, vec := range vecs {
+			if err := vec.checkXOF(sha3.NewShake128(), dst); err != nil {
+				t.Fatalf("Test vector[%d]: %v", i, err)
+			}
+		}
+	})
+	t.Run("SHAKE256", func(t *testing.T) {
+		// K.4. expand_message_xof(SHAKE256)
+		dst := []byte("QUUX-V01-CS02-with-expander")
+		vecs := []expandMessageTestVector{
+			{
+				msg:      "",
+				expected: "58e90433d81860c47d350b0bb6fb94f98f6b0f9657efd04d410ae743260c096d",
+			},
+			{
+				msg:      "abc",
+				expected: "c7f5e3c044790033707e24f21d971aaa03a760dfda6215bf0c8634da9012c8f8",
+			},
+			{
+				msg:      "abcdef0123456789",
+				expected: "f46930964d5d5006ef992f5878d7c255c9a92aed1032c9b9d4743ec1470a91e8",
+			},
+			{
+				msg:      "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
+				expected: "885baaf4841ad28aa853022289cb4841cc6c1bf200c579e8aebb8d005a8ff37f",
+			},
+			{
+				msg:      "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				expected: "4a9884b31a64772244df05622222db6cb9942034370d2400e39bb853cca727f7",
+			},
+			{
+				msg:      "",
+				expected: "4cbc65744a4a26c059472822a4647887abb4a3220d5c1e1155c4180a04c69541d437b77676fc5b6450faa5cb906d88a8fa7e1c6807d0a66f0092cc022812368e75ba41dcb4daab00a17e752d485f5e21f835ac36f05b9d0217c79376045e1360faa4652db9d7752af1ffb76ae14cf6aabd7b08b19032d213415d2cef8cd6b62f",
+			},
+			{
+				msg:      "abc",
+				expected: "c5f366dc668697014a0a90a40ae27c19edcb8500f6ad5d4234fbf4204f64df524a44adaecb42102fffeb7686949aa6785142b2510a419dd29dadf1f2b455688c043f6bc2fd76b101dd8e41cca4042514a6b15d137d958735961e3c32a49e0640ad564d533d20adc203c5befdb1186ca18646b729a5cb4531922d24a17b4389ea",
+			},
+			{
+				msg:      "abcdef0123456789",
+				expected: "34dcc64cee945b94c5e29a9aa6b859b8a9705fe020bf7443eaab4c8269e739904e2703cef64e1823b5c848570db97b28da7869f52c24573d8f759b7181726e186dcff940eea5f70a11ebd14b4c90c3b17805ce91dc3157ce635e9d11fe56d86dfa76a79e84c11e253653350d2f954922077f2ea6a17104dd0fd963d7fe4568d3",
+			},
+			{
+				msg:      "q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
+				expected: "b0adc10a4326ae3ddf11c42afb89058625f8812c76b2a0fb17570f7a2acb030e8dd20036d1326984fd0d973197d80fbf461fe18ef394b9a22e609e61d710df43476ddf3a8ca4d32b737bc265d14a204f32173e447db74bc68938b6a6a08e3e9a31968e5d05a0ca213c977e94cffc9a535b5c5198a6c5892bbce1a35ecc7ab2bd",
+			},
+			{
+				msg:      "a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				expected: "50a0cb335f3102a15f3dfed981b0a5fecb3136112532e129d39369a2a92c32a9b0a0181af9839039c0e98a3b66a0d209fa019134991055284c3f475c9f7c91169dea57aad442f0c98418d36e50fad68e8863109dac6d8cfc6c5fa63e8f1c0468af9980066e87b62caa87f4b3feef0dba8ef894f2957105d111439597d3265b1f",
+			},
+		}
+
+		for i, vec := range vecs {
+			if err := vec.checkXOF(sha3.NewShake256(), dst); err != nil {
+				t.Fatalf("Test vector[%d]: %v", i, err)
+			}
+		}
+	})
+}
+
+func TestExpanderAlgXOF(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander")
+	msg := []byte("abc")
+
+	for _, v := range []struct {
+		alg ExpanderAlg
+		id  string
+		fn  func([]byte) error
+	}{
+		{ExpanderXMDSHA3_256, "XMD:SHA3-256", func(out []byte) error {
+			return ExpandMessageXMD(out, crypto.SHA3_256, dst, msg)
+		}},
+		{ExpanderXMDSHA3_384, "XMD:SHA3-384", func(out []byte) error {
+			return ExpandMessageXMD(out, crypto.SHA3_384, dst, msg)
+		}},
+		{ExpanderXMDSHA3_512, "XMD:SHA3-512", func(out []byte) error {
+			return ExpandMessageXMD(out, crypto.SHA3_512, dst, msg)
+		}},
+		{ExpanderXOFSHAKE128, "XOF:SHAKE128", func(out []byte) error {
+			return ExpandMessageXOFFunc(out, sha3.NewShake128, dst, msg)
+		}},
+		{ExpanderXOFSHAKE256, "XOF:SHAKE256", func(out []byte) error {
+			return ExpandMessageXOFFunc(out, sha3.NewShake256, dst, msg)
+		}},
+	} {
+		if id := v.alg.String(); id != v.id {
+			t.Fatalf("%v: unexpected ID: '%s'", v.alg, id)
+		}
+
+		expected := make([]byte, 128)
+		if err := v.fn(expected); err != nil {
+			t.Fatalf("%v: expected output: %v", v.alg, err)
+		}
+		out := make([]byte, 128)
+		if err := ExpandMessage(out, v.alg, dst, msg); err != nil {
+			t.Fatalf("%v: ExpandMessage: %v", v.alg, err)
+		}
+		if !bytes.Equal(out, expected) {
+			t.Fatalf("%v: output mismatch", v.alg)
+		}
+	}
+}
+
+func TestExpandMessageCSHAKE(t *testing.T) {
+	msg := []byte("abc")
+
+	for _, newCShake := range []func(N, S []byte) sha3.ShakeHash{
+		sha3.NewCShake128,
+		sha3.NewCShake256,
+	} {
+		e := NewExpanderCSHAKE("CSHAKE", newCShake)
+		for _, dst := range [][]byte{
+			[]byte("QUUX-V01-CS02-with-expander"),
+			bytes.Repeat([]byte{'D'}, 300),
+			[]byte("QUUX-V01-CS02-with-expander"),
+		} {
+			expected := make([]byte, 96)
+			xof := newCShake(nil, dst)
+			_, _ = xof.Write(msg)
+			_, _ = xof.Write([]byte{0, 96})
+			_, _ = xof.Read(expected)
+
+			out := make([]byte, 96)
+			if err := ExpandMessageCSHAKE(out, newCShake, dst, msg); err != nil {
+				t.Fatalf("ExpandMessageCSHAKE: %v", err)
+			}
+			if !bytes.Equal(out, expected) {
+				t.Fatalf("ExpandMessageCSHAKE: output mismatch")
+			}
+
+			for i := 0; i < 2; i++ {
+				out = make([]byte, 96)
+				if err := e.ExpandMessage(out, dst, msg); err != nil {
+					t.Fatalf("e.ExpandMessage: %v", err)
+				}
+				if !bytes.Equal(out, expected) {
+					t.Fatalf("e.ExpandMessage: output mismatch")
+				}
+			}
+		}
+
+		if err := ExpandMessageCSHAKE(make([]byte, 32), newCShake, nil, msg); !errors.Is(err, ErrEmptyDST) {
+			t.Fatalf("ExpandMessageCSHAKE(empty DST): unexpected error: %v", err)
+		}
+		if err := e.ExpandMessage(make([]byte, 32), nil, msg); !errors.Is(err, ErrEmptyDST) {
+			t.Fatalf("e.ExpandMessage(empty DST): unexpected error: %v", err)
+		}
+		if err := e.ExpandMessage(nil, []byte("DST"), msg); !errors.Is(err, ErrZeroLength) {
+			t.Fatalf("e.ExpandMessage: unexpected error: %v", err)
+		}
+	}
+
+	suite, err := NewSuite(Edwards25519, NewExpanderCSHAKE("CSHAKE256", sha3.NewCShake256), true)
+	if err != nil {
+		t.Fatalf("NewSuite: %v", err)
+	}
+	if id := suite.ID(); id != "edwards25519_CSHAKE:CSHAKE256_ELL2_RO_" {
+		t.Fatalf("suite.ID: unexpected ID: '%s'", id)
+	}
+}
+
+func TestReduceDSTXOF(t *testing.T) {
+	msg := []byte("abc")
+
+	for _, dst := range [][]byte{
+		[]byte("QUUX-V01-CS02-with-expander"),
+		bytes.Repeat([]byte{'D'}, 300),
+	} {
+		reduced, err := ReduceDSTXOFFunc(sha3.NewShake128, dst)
+		if err != nil {
+			t.Fatalf("ReduceDSTXOFFunc: %v", err)
+		}
+		if len(dst) <= 255 && !bytes.Equal(reduced, dst) {
+			t.Fatalf("ReduceDSTXOFFunc: short DST modified")
+		}
+
+		expected := make([]byte, 128)
+		if err = ExpandMessageXOFFunc(expected, sha3.NewShake128, dst, msg); err != nil {
+			t.Fatalf("ExpandMessageXOFFunc: %v", err)
+		}
+		out := make([]byte, 128)
+		if err = ExpandMessageXOFFunc(out, sha3.NewShake128, reduced, msg); err != nil {
+			t.Fatalf("ExpandMessageXOFFunc(reduced): %v", err)
+		}
+		if !bytes.Equal(out, expected) {
+			t.Fatalf("ExpandMessageXOFFunc(reduced): output mismatch")
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"crypto"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedExpander is the error returned when an ExpanderAlg is
+// unknown, or is not supported by the current build.
+var ErrUnsupportedExpander = errors.New("h2c: unsupported expander algorithm")
+
+// ExpanderAlg identifies an `expand_message` variant and its underlying
+// hash function or XOF.
+type ExpanderAlg int
+
+const (
+	// ExpanderXMDSHA256 is `expand_message_xmd` with SHA-256.
+	ExpanderXMDSHA256 ExpanderAlg = iota + 1
+	// ExpanderXMDSHA384 is `expand_message_xmd` with SHA-384.
+	ExpanderXMDSHA384
+	// ExpanderXMDSHA512 is `expand_message_xmd` with SHA-512.
+	ExpanderXMDSHA512
+	// ExpanderXMDSHA3_256 is `expand_message_xmd` with SHA3-256.
+	ExpanderXMDSHA3_256
+	// ExpanderXMDSHA3_384 is `expand_message_xmd` with SHA3-384.
+	ExpanderXMDSHA3_384
+	// ExpanderXMDSHA3_512 is `expand_message_xmd` with SHA3-512.
+	ExpanderXMDSHA3_512
+	// ExpanderXOFSHAKE128 is `expand_message_xof` with SHAKE128.
+	ExpanderXOFSHAKE128
+	// ExpanderXOFSHAKE256 is `expand_message_xof` with SHAKE256.
+	ExpanderXOFSHAKE256
+)
+
+var expanderAlgHashes = map[ExpanderAlg]crypto.Hash{
+	ExpanderXMDSHA256:   crypto.SHA256,
+	ExpanderXMDSHA384:   crypto.SHA384,
+	ExpanderXMDSHA512:   crypto.SHA512,
+	ExpanderXMDSHA3_256: crypto.SHA3_256,
+	ExpanderXMDSHA3_384: crypto.SHA3_384,
+	ExpanderXMDSHA3_512: crypto.SHA3_512,
+}
+
+// String returns the expander portion of a suite ID (eg: `XMD:SHA-512`).
+func (alg ExpanderAlg) String() string {
+	if hFunc, ok := expanderAlgHashes[alg]; ok {
+		return "XMD:" + hFunc.String()
+	}
+	switch alg {
+	case ExpanderXOFSHAKE128:
+		return "XOF:SHAKE128"
+	case ExpanderXOFSHAKE256:
+		return "XOF:SHAKE256"
+	}
+	return fmt.Sprintf("ExpanderAlg(%d)", int(alg))
+}
+
+// Expander returns the Expander corresponding to the algorithm.
+func (alg ExpanderAlg) Expander() (Expander, error) {
+	if hFunc, ok := expanderAlgHashes[alg]; ok {
+		if !hFunc.Available() {
+			return nil, fmt.Errorf("%w: %v: hash function unavailable", ErrUnsupportedExpander, alg)
+		}
+		return NewExpanderXMD(hFunc), nil
+	}
+	if e := expanderXOFForAlg(alg); e != nil {
+		return e, nil
+	}
+	return nil, fmt.Errorf("%w: %v", ErrUnsupportedExpander, alg)
+}
+
+// ExpandMessage implements `expand_message` with the expander algorithm
+// selected at runtime, overwriting out with uniformly random data
+// generated from the domain separation tag and message.
+func ExpandMessage(out []byte, alg ExpanderAlg, domainSeparator, message []byte) error {
+	e, err := alg.Expander()
+	if err != nil {
+		return err
+	}
+	return e.ExpandMessage(out, domainSeparator, message)
+}
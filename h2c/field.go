@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"fmt"
+
+	"filippo.io/edwards25519/field"
+)
+
+// FeFromBEBytes returns the field element encoded as 32 big-endian
+// bytes, as used by the IETF test vectors.  Non-canonical encodings
+// (values >= p) are rejected.
+func FeFromBEBytes(b []byte) (*field.Element, error) {
+	if len(b) != 32 {
+		return nil, fmt.Errorf("h2c: invalid field element length: %d", len(b))
+	}
+
+	var fe field.Element
+	if err := setCanonicalBytes(&fe, reversedByteSlice(b)); err != nil {
+		return nil, fmt.Errorf("h2c: invalid field element: %w", err)
+	}
+	return &fe, nil
+}
+
+// FeToBEBytes returns the canonical 32-byte big-endian encoding of the
+// field element, as used by the IETF test vectors.
+func FeToBEBytes(fe *field.Element) []byte {
+	return reversedByteSlice(fe.Bytes())
+}
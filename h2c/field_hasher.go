@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"filippo.io/edwards25519/field"
+)
+
+// FieldHasher derives an arbitrary number of independent GF(2^255-19)
+// field elements from a domain separation tag and message.
+//
+// The i-th element is `hash_to_field(msg || I2OSP(i, 4), 1)` with the
+// provided Expander and domain separation tag.  Note that this differs
+// from `hash_to_field(msg, count)` as specified in RFC 9380, which
+// requires the number of elements to be known in advance.
+//
+// FieldHasher instances are not safe for concurrent use.
+type FieldHasher struct {
+	expander        Expander
+	domainSeparator []byte
+	msgPrime        []byte // msg || I2OSP(i, 4)
+	ctr             uint32
+}
+
+// NewFieldHasher returns a new FieldHasher for the domain separation tag
+// and message.
+func NewFieldHasher(expander Expander, domainSeparator, message []byte) *FieldHasher {
+	msgPrime := make([]byte, 0, len(message)+4)
+	msgPrime = append(msgPrime, message...)
+	msgPrime = append(msgPrime, 0, 0, 0, 0)
+
+	return &FieldHasher{
+		expander:        expander,
+		domainSeparator: append([]byte{}, domainSeparator...),
+		msgPrime:        msgPrime,
+	}
+}
+
+// Element returns the i-th field element for the domain separation tag
+// and message.
+func (fh *FieldHasher) Element(i uint32) (*field.Element, error) {
+	binary.BigEndian.PutUint32(fh.msgPrime[len(fh.msgPrime)-4:], i)
+
+	var uniformBytes [ell]byte
+	defer wipeBytes(uniformBytes[:])
+
+	if err := fh.expander.ExpandMessage(uniformBytes[:], fh.domainSeparator, fh.msgPrime); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+
+	return uniformToField25519(uniformBytes[:]), nil
+}
+
+// Next returns the next field element in the sequence, starting from
+// the 0-th element.
+func (fh *FieldHasher) Next() (*field.Element, error) {
+	fe, err := fh.Element(fh.ctr)
+	if err != nil {
+		return nil, err
+	}
+	fh.ctr++
+
+	return fe, nil
+}
+
+// Reset restarts the sequence returned by Next from the 0-th element.
+func (fh *FieldHasher) Reset() {
+	fh.ctr = 0
+}
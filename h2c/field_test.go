@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFeBEBytes(t *testing.T) {
+	// 9 (the curve25519 basepoint u-coordinate), big-endian.
+	nine := make([]byte, 32)
+	nine[31] = 9
+
+	fe, err := FeFromBEBytes(nine)
+	if err != nil {
+		t.Fatalf("FeFromBEBytes: %v", err)
+	}
+	if b := fe.Bytes(); b[0] != 9 {
+		t.Fatalf("FeFromBEBytes: unexpected value: %x", b)
+	}
+	if b := FeToBEBytes(fe); !bytes.Equal(b, nine) {
+		t.Fatalf("FeToBEBytes: unexpected value: %x", b)
+	}
+
+	// p = 2^255 - 19, big-endian.
+	p := bytes.Repeat([]byte{0xff}, 32)
+	p[0] = 0x7f
+	p[31] = 0xed
+
+	for _, b := range [][]byte{nil, nine[1:], append(nine, 0), p} {
+		if _, err = FeFromBEBytes(b); err == nil {
+			t.Fatalf("FeFromBEBytes(%x): expected failure", b)
+		}
+	}
+}
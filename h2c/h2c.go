@@ -29,18 +29,23 @@
 // SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 
 // Package h2c implements Hashing to Elliptic Curves as specified in RFC 9380.
+//
+// The `expand_message_xof` based functionality depends on
+// golang.org/x/crypto/sha3, and may be omitted by building with the
+// `h2c_noxof` build tag.
 package h2c
 
 import (
 	"crypto"
+	cryptorand "crypto/rand"
 	_ "crypto/sha512"
+	"errors"
 	"fmt"
+	"io"
 
 	"filippo.io/edwards25519"
 	"filippo.io/edwards25519/field"
-	"golang.org/x/crypto/sha3"
 
-	"gitlab.com/yawning/edwards25519-extra/elligator2"
 	"gitlab.com/yawning/edwards25519-extra/internal/montgomery"
 )
 
@@ -52,164 +57,440 @@ const (
 	hashToCurveSize   = ell * 2
 )
 
+const (
+	// EncodeToCurveUniformSize is the size of the uniform bytes consumed
+	// by the nonuniform (encode_to_curve) suites.
+	EncodeToCurveUniformSize = encodeToCurveSize
+
+	// HashToCurveUniformSize is the size of the uniform bytes consumed
+	// by the random oracle (hash_to_curve) suites.
+	HashToCurveUniformSize = hashToCurveSize
+)
+
+var identityPoint = edwards25519.NewIdentityPoint()
+
+// ErrIdentityPoint is the error returned by the `NonIdentity` variants
+// of the nonuniform suites and HashToCurveNonIdentity when the output is
+// the identity element.
+var ErrIdentityPoint = errors.New("h2c: output is the identity element")
+
 // Edwards25519_XMD_SHA512_ELL2_RO implements the edwards25519_XMD:SHA-512_ELL2_RO_
 // suite.
 func Edwards25519_XMD_SHA512_ELL2_RO(domainSeparator, message []byte) (*edwards25519.Point, error) {
-	return Edwards25519_XMD_ELL2_RO(crypto.SHA512, domainSeparator, message)
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p edwards25519.Point
+	return edwards25519XMDELL2RO(&p, crypto.SHA512, domainSeparator, message)
 }
 
 // Edwards25519_XMD_SHA512_ELL2_NU implements the edwards25519_XMD:SHA-512_ELL2_NU_
 // suite.
 func Edwards25519_XMD_SHA512_ELL2_NU(domainSeparator, message []byte) (*edwards25519.Point, error) {
-	return Edwards25519_XMD_ELL2_NU(crypto.SHA512, domainSeparator, message)
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p edwards25519.Point
+	return edwards25519XMDELL2NU(&p, crypto.SHA512, domainSeparator, message)
+}
+
+// Edwards25519_XMD_SHA512_ELL2_NU_NonIdentity implements the
+// edwards25519_XMD:SHA-512_ELL2_NU_ suite, returning ErrIdentityPoint
+// if the output is the identity element.
+func Edwards25519_XMD_SHA512_ELL2_NU_NonIdentity(domainSeparator, message []byte) (*edwards25519.Point, error) {
+	return Edwards25519_XMD_ELL2_NU_NonIdentity(crypto.SHA512, domainSeparator, message)
 }
 
 // Curve25519_XMD_SHA512_ELL2_RO implements the curve25519_XMD:SHA-512_ELL2_RO_
-// suite.
+// suite, returning the u and v-coordinates.
+//
+// Deprecated: Use Curve25519_XMD_SHA512_ELL2_RO_Point.
 func Curve25519_XMD_SHA512_ELL2_RO(domainSeparator, message []byte) (*field.Element, *field.Element, error) {
 	return Curve25519_XMD_ELL2_RO(crypto.SHA512, domainSeparator, message)
 }
 
+// Curve25519_XMD_SHA512_ELL2_RO_Point implements the
+// curve25519_XMD:SHA-512_ELL2_RO_ suite.
+func Curve25519_XMD_SHA512_ELL2_RO_Point(domainSeparator, message []byte) (*MontgomeryPoint, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p MontgomeryPoint
+	return curve25519XMDELL2RO(&p, crypto.SHA512, domainSeparator, message)
+}
+
 // Curve25519_XMD_SHA512_ELL2_NU implements the curve25519_XMD:SHA-512_ELL2_NU_
-// suite.
+// suite, returning the u and v-coordinates.
+//
+// Deprecated: Use Curve25519_XMD_SHA512_ELL2_NU_Point.
 func Curve25519_XMD_SHA512_ELL2_NU(domainSeparator, message []byte) (*field.Element, *field.Element, error) {
 	return Curve25519_XMD_ELL2_NU(crypto.SHA512, domainSeparator, message)
 }
 
+// Curve25519_XMD_SHA512_ELL2_NU_Point implements the
+// curve25519_XMD:SHA-512_ELL2_NU_ suite.
+func Curve25519_XMD_SHA512_ELL2_NU_Point(domainSeparator, message []byte) (*MontgomeryPoint, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p MontgomeryPoint
+	return curve25519XMDELL2NU(&p, crypto.SHA512, domainSeparator, message)
+}
+
+// Curve25519_XMD_SHA512_ELL2_RO_U implements the
+// curve25519_XMD:SHA-512_ELL2_RO_ suite, returning only the u-coordinate.
+func Curve25519_XMD_SHA512_ELL2_RO_U(domainSeparator, message []byte) (*field.Element, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var u field.Element
+	return curve25519XMDELL2ROU(&u, crypto.SHA512, domainSeparator, message)
+}
+
+// Curve25519_XMD_SHA512_ELL2_NU_U implements the
+// curve25519_XMD:SHA-512_ELL2_NU_ suite, returning only the u-coordinate.
+func Curve25519_XMD_SHA512_ELL2_NU_U(domainSeparator, message []byte) (*field.Element, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var u field.Element
+	return curve25519XMDELL2NUU(&u, crypto.SHA512, domainSeparator, message)
+}
+
 // Edwards25519_XMD_ELL2_RO implements a generic edwards25519 random oracle suite
 // using `expand_message_xmd`.
 func Edwards25519_XMD_ELL2_RO(hFunc crypto.Hash, domainSeparator, message []byte) (*edwards25519.Point, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p edwards25519.Point
+	return edwards25519XMDELL2RO(&p, hFunc, domainSeparator, message)
+}
+
+func edwards25519XMDELL2RO(p *edwards25519.Point, hFunc crypto.Hash, domainSeparator, message []byte) (*edwards25519.Point, error) {
 	var uniformBytes [hashToCurveSize]byte
 	if err := ExpandMessageXMD(uniformBytes[:], hFunc, domainSeparator, message); err != nil {
 		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
 	}
-	return hashToCurveEdwards(&uniformBytes), nil
+	return hashToCurveEdwards(p, &uniformBytes), nil
 }
 
 // Edwards25519_XMD_ELL2_NU implements a generic edwards25519 nonuniform suite
 // using `expand_messsage_xmd`.
 func Edwards25519_XMD_ELL2_NU(hFunc crypto.Hash, domainSeparator, message []byte) (*edwards25519.Point, error) {
-	var uniformBytes [encodeToCurveSize]byte
-	if err := ExpandMessageXMD(uniformBytes[:], hFunc, domainSeparator, message); err != nil {
-		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
-	}
-	return encodeToCurveEdwards(&uniformBytes), nil
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p edwards25519.Point
+	return edwards25519XMDELL2NU(&p, hFunc, domainSeparator, message)
 }
 
-// Edwards25519_XOF_ELL2_RO implements a generic edwards25519 random oracle suite
-// using `expand_message_xof`.
-func Edwards25519_XOF_ELL2_RO(xofFunc sha3.ShakeHash, domainSeparator, message []byte) (*edwards25519.Point, error) {
-	var uniformBytes [hashToCurveSize]byte
-	if err := ExpandMessageXOF(uniformBytes[:], xofFunc, domainSeparator, message); err != nil {
+func edwards25519XMDELL2NU(p *edwards25519.Point, hFunc crypto.Hash, domainSeparator, message []byte) (*edwards25519.Point, error) {
+	var uniformBytes [encodeToCurveSize]byte
+	if err := ExpandMessageXMD(uniformBytes[:], hFunc, domainSeparator, message); err != nil {
 		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
 	}
-	return hashToCurveEdwards(&uniformBytes), nil
+	return encodeToCurveEdwards(p, &uniformBytes), nil
 }
 
-// Edwards25519_XOF_ELL2_NU implements a generic edwards25519 nonuniform suite
-// using `expand_messsage_xof`.
-func Edwards25519_XOF_ELL2_NU(xofFunc sha3.ShakeHash, domainSeparator, message []byte) (*edwards25519.Point, error) {
-	var uniformBytes [encodeToCurveSize]byte
-	if err := ExpandMessageXOF(uniformBytes[:], xofFunc, domainSeparator, message); err != nil {
-		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+// Edwards25519_XMD_ELL2_NU_NonIdentity implements a generic edwards25519
+// nonuniform suite using `expand_message_xmd`, returning ErrIdentityPoint
+// if the output is the identity element.
+//
+// As the output of the suite has the cofactor cleared, this also rejects
+// all small-order points.
+func Edwards25519_XMD_ELL2_NU_NonIdentity(hFunc crypto.Hash, domainSeparator, message []byte) (*edwards25519.Point, error) {
+	p, err := Edwards25519_XMD_ELL2_NU(hFunc, domainSeparator, message)
+	if err != nil {
+		return nil, err
 	}
-	return encodeToCurveEdwards(&uniformBytes), nil
+	return checkNonIdentity(p)
 }
 
 // Curve25519_XMD_ELL2_RO implements a generic curve25519 random oracle suite
 // using `expand_message_xmd`, returning the u and v-coordinates.
+//
+// Deprecated: Use Curve25519_XMD_ELL2_RO_Point.
 func Curve25519_XMD_ELL2_RO(hFunc crypto.Hash, domainSeparator, message []byte) (*field.Element, *field.Element, error) {
+	p, err := Curve25519_XMD_ELL2_RO_Point(hFunc, domainSeparator, message)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.U(), p.V(), nil
+}
+
+// Curve25519_XMD_ELL2_RO_Point implements a generic curve25519 random oracle
+// suite using `expand_message_xmd`.
+func Curve25519_XMD_ELL2_RO_Point(hFunc crypto.Hash, domainSeparator, message []byte) (*MontgomeryPoint, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p MontgomeryPoint
+	return curve25519XMDELL2RO(&p, hFunc, domainSeparator, message)
+}
+
+func curve25519XMDELL2RO(p *MontgomeryPoint, hFunc crypto.Hash, domainSeparator, message []byte) (*MontgomeryPoint, error) {
 	var uniformBytes [hashToCurveSize]byte
 	if err := ExpandMessageXMD(uniformBytes[:], hFunc, domainSeparator, message); err != nil {
-		return nil, nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
 	}
-	u, v := hashToCurveMontgomery(&uniformBytes)
-	return u, v, nil
+	return hashToCurveMontgomery(p, &uniformBytes), nil
 }
 
 // Curve25519_XMD_ELL2_NU implements a generic curve25519 nonuniform suite
-// using `expand_messsage_xmd`, returning the u and v-coordiantes.
+// using `expand_message_xmd`, returning the u and v-coordinates.
+//
+// Deprecated: Use Curve25519_XMD_ELL2_NU_Point.
 func Curve25519_XMD_ELL2_NU(hFunc crypto.Hash, domainSeparator, message []byte) (*field.Element, *field.Element, error) {
+	p, err := Curve25519_XMD_ELL2_NU_Point(hFunc, domainSeparator, message)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.U(), p.V(), nil
+}
+
+// Curve25519_XMD_ELL2_NU_Point implements a generic curve25519 nonuniform
+// suite using `expand_message_xmd`.
+func Curve25519_XMD_ELL2_NU_Point(hFunc crypto.Hash, domainSeparator, message []byte) (*MontgomeryPoint, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p MontgomeryPoint
+	return curve25519XMDELL2NU(&p, hFunc, domainSeparator, message)
+}
+
+func curve25519XMDELL2NU(p *MontgomeryPoint, hFunc crypto.Hash, domainSeparator, message []byte) (*MontgomeryPoint, error) {
 	var uniformBytes [encodeToCurveSize]byte
 	if err := ExpandMessageXMD(uniformBytes[:], hFunc, domainSeparator, message); err != nil {
-		return nil, nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
 	}
-	u, v := encodeToCurveMontgomery(&uniformBytes)
-	return u, v, nil
+	return encodeToCurveMontgomery(p, &uniformBytes), nil
+}
+
+// Curve25519_XMD_ELL2_RO_U implements a generic curve25519 random oracle
+// suite using `expand_message_xmd`, returning only the u-coordinate.
+//
+// This is cheaper than Curve25519_XMD_ELL2_RO_Point, and is intended for
+// X25519 style consumers that have no use for the v-coordinate.
+func Curve25519_XMD_ELL2_RO_U(hFunc crypto.Hash, domainSeparator, message []byte) (*field.Element, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var u field.Element
+	return curve25519XMDELL2ROU(&u, hFunc, domainSeparator, message)
 }
 
-// Curve25519_XOF_ELL2_RO implements a generic curve25519 random oracle suite
-// using `expand_message_xof`, returning the u and v-coordinates.
-func Curve25519_XOF_ELL2_RO(xofFunc sha3.ShakeHash, domainSeparator, message []byte) (*field.Element, *field.Element, error) {
+func curve25519XMDELL2ROU(u *field.Element, hFunc crypto.Hash, domainSeparator, message []byte) (*field.Element, error) {
 	var uniformBytes [hashToCurveSize]byte
-	if err := ExpandMessageXOF(uniformBytes[:], xofFunc, domainSeparator, message); err != nil {
-		return nil, nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	if err := ExpandMessageXMD(uniformBytes[:], hFunc, domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
 	}
-	u, v := hashToCurveMontgomery(&uniformBytes)
-	return u, v, nil
+	return hashToCurveMontgomeryU(u, &uniformBytes), nil
 }
 
-// Curve5519_XOF_ELL2_NU implements a generic curve25519 nonuniform suite
-// using `expand_messsage_xof`, returning the u and v-coordinates.
-func Curve25519_XOF_ELL2_NU(xofFunc sha3.ShakeHash, domainSeparator, message []byte) (*field.Element, *field.Element, error) {
+// Curve25519_XMD_ELL2_NU_U implements a generic curve25519 nonuniform
+// suite using `expand_message_xmd`, returning only the u-coordinate.
+//
+// This is cheaper than Curve25519_XMD_ELL2_NU_Point, and is intended for
+// X25519 style consumers that have no use for the v-coordinate.
+func Curve25519_XMD_ELL2_NU_U(hFunc crypto.Hash, domainSeparator, message []byte) (*field.Element, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var u field.Element
+	return curve25519XMDELL2NUU(&u, hFunc, domainSeparator, message)
+}
+
+func curve25519XMDELL2NUU(u *field.Element, hFunc crypto.Hash, domainSeparator, message []byte) (*field.Element, error) {
 	var uniformBytes [encodeToCurveSize]byte
-	if err := ExpandMessageXOF(uniformBytes[:], xofFunc, domainSeparator, message); err != nil {
-		return nil, nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	if err := ExpandMessageXMD(uniformBytes[:], hFunc, domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
 	}
-	u, v := encodeToCurveMontgomery(&uniformBytes)
-	return u, v, nil
+	return encodeToCurveMontgomeryU(u, &uniformBytes), nil
+}
+
+// Edwards25519_ELL2_RO_FromUniform implements the edwards25519 random
+// oracle suite, using the provided uniform bytes in place of the output
+// of `expand_message`.
+func Edwards25519_ELL2_RO_FromUniform(uniformBytes *[HashToCurveUniformSize]byte) *edwards25519.Point {
+	var p edwards25519.Point
+	return hashToCurveEdwards(&p, uniformBytes)
+}
+
+// Edwards25519_ELL2_NU_FromUniform implements the edwards25519 nonuniform
+// suite, using the provided uniform bytes in place of the output of
+// `expand_message`.
+func Edwards25519_ELL2_NU_FromUniform(uniformBytes *[EncodeToCurveUniformSize]byte) *edwards25519.Point {
+	var p edwards25519.Point
+	return encodeToCurveEdwards(&p, uniformBytes)
+}
+
+// Curve25519_ELL2_RO_FromUniform implements the curve25519 random oracle
+// suite, using the provided uniform bytes in place of the output of
+// `expand_message`.
+func Curve25519_ELL2_RO_FromUniform(uniformBytes *[HashToCurveUniformSize]byte) *MontgomeryPoint {
+	var p MontgomeryPoint
+	return hashToCurveMontgomery(&p, uniformBytes)
+}
+
+// Curve25519_ELL2_NU_FromUniform implements the curve25519 nonuniform
+// suite, using the provided uniform bytes in place of the output of
+// `expand_message`.
+func Curve25519_ELL2_NU_FromUniform(uniformBytes *[EncodeToCurveUniformSize]byte) *MontgomeryPoint {
+	var p MontgomeryPoint
+	return encodeToCurveMontgomery(&p, uniformBytes)
+}
+
+// RandomPoint returns a uniformly distributed edwards25519 point in the
+// prime order subgroup, by running the random oracle map on
+// HashToCurveUniformSize bytes read from rand.  If rand is nil,
+// crypto/rand.Reader will be used.
+func RandomPoint(rand io.Reader) (*edwards25519.Point, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+
+	var uniformBytes [hashToCurveSize]byte
+	defer wipeBytes(uniformBytes[:])
+
+	if _, err := io.ReadFull(rand, uniformBytes[:]); err != nil {
+		return nil, fmt.Errorf("h2c: failed to read random bytes: %w", err)
+	}
+	return hashToCurveEdwards(new(edwards25519.Point), &uniformBytes), nil
+}
+
+func hashToCurveEdwards(p *edwards25519.Point, uniformBytes *[hashToCurveSize]byte) *edwards25519.Point {
+	var Q0, Q1 edwards25519.Point
+	mapToCurveEdwards(&Q0, uniformBytes[:ell])
+	mapToCurveEdwards(&Q1, uniformBytes[ell:])
+
+	p.Add(&Q0, &Q1)
+	clearCofactor(p, p)
+
+	Q0.Set(identityPoint)
+	Q1.Set(identityPoint)
+
+	return p
 }
 
-func hashToCurveEdwards(uniformBytes *[hashToCurveSize]byte) *edwards25519.Point {
-	fe0 := uniformToField25519(uniformBytes[:ell])
-	fe1 := uniformToField25519(uniformBytes[ell:])
+func encodeToCurveEdwards(p *edwards25519.Point, uniformBytes *[encodeToCurveSize]byte) *edwards25519.Point {
+	var Q edwards25519.Point
+	mapToCurveEdwards(&Q, uniformBytes[:])
+	clearCofactor(p, &Q)
 
-	Q0 := elligator2.EdwardsFlavor(fe0)
-	Q1 := elligator2.EdwardsFlavor(fe1)
+	Q.Set(identityPoint)
 
-	p := new(edwards25519.Point).Add(Q0, Q1)
-	return p.MultByCofactor(p)
+	return p
 }
 
-func encodeToCurveEdwards(uniformBytes *[encodeToCurveSize]byte) *edwards25519.Point {
-	fe := uniformToField25519(uniformBytes[:])
+func checkNonIdentity(p *edwards25519.Point) (*edwards25519.Point, error) {
+	if p.Equal(identityPoint) == 1 {
+		return nil, ErrIdentityPoint
+	}
+	return p, nil
+}
 
-	Q := elligator2.EdwardsFlavor(fe)
+// The curve25519 suites are implemented in terms of the edwards25519
+// suites.  The Elligator2 map produces Montgomery coordinates which are
+// converted to projective Edwards coordinates without any inversions,
+// the group operations are done with the complete Edwards formulas, and
+// the final conversion back to Montgomery coordinates costs a single
+// inversion.
+
+func hashToCurveMontgomery(mp *MontgomeryPoint, uniformBytes *[hashToCurveSize]byte) *MontgomeryPoint {
+	var p edwards25519.Point
+	hashToCurveEdwards(&p, uniformBytes)
+	montgomery.SetFromEdwardsPoint(&mp.u, &mp.v, &p)
+	p.Set(identityPoint)
+	return mp
+}
 
-	return new(edwards25519.Point).MultByCofactor(Q)
+func encodeToCurveMontgomery(mp *MontgomeryPoint, uniformBytes *[encodeToCurveSize]byte) *MontgomeryPoint {
+	var p edwards25519.Point
+	encodeToCurveEdwards(&p, uniformBytes)
+	montgomery.SetFromEdwardsPoint(&mp.u, &mp.v, &p)
+	p.Set(identityPoint)
+	return mp
 }
 
-func hashToCurveMontgomery(uniformBytes *[hashToCurveSize]byte) (*field.Element, *field.Element) {
-	p := hashToCurveEdwards(uniformBytes)
-	return montgomery.FromEdwardsPoint(p)
+// The u-only variants skip the v-coordinate entirely, saving the
+// multiplications required to recover it from the Edwards point.
+
+func hashToCurveMontgomeryU(u *field.Element, uniformBytes *[hashToCurveSize]byte) *field.Element {
+	var p edwards25519.Point
+	hashToCurveEdwards(&p, uniformBytes)
+	montgomery.SetUFromEdwardsPoint(u, &p)
+	p.Set(identityPoint)
+	return u
 }
 
-func encodeToCurveMontgomery(uniformBytes *[encodeToCurveSize]byte) (*field.Element, *field.Element) {
-	p := encodeToCurveEdwards(uniformBytes)
-	return montgomery.FromEdwardsPoint(p)
+func encodeToCurveMontgomeryU(u *field.Element, uniformBytes *[encodeToCurveSize]byte) *field.Element {
+	var p edwards25519.Point
+	encodeToCurveEdwards(&p, uniformBytes)
+	montgomery.SetUFromEdwardsPoint(u, &p)
+	p.Set(identityPoint)
+	return u
 }
 
 func uniformToField25519(b []byte) *field.Element {
+	return uniformToField25519Into(new(field.Element), b)
+}
+
+// uniformToField25519Into sets fe to `OS2IP(b) mod p`, without
+// allocating, and returns fe.
+func uniformToField25519Into(fe *field.Element, b []byte) *field.Element {
 	if len(b) != ell {
 		panic("h2c: invalid uniform bytes length")
 	}
 
 	// Unlike curve25519-voi, edwards25519 implements a 512-bit reduction
-	// so zero-extend the big-endian input.
-	bExtended := make([]byte, 64-ell, 64)
-	bExtended = append(bExtended, b...)
-
-	// The wide-reduction routine wants little-endian, so do the byte-swap.
-	bLE := reversedByteSlice(bExtended)
+	// so zero-extend the big-endian input, and do the byte-swap to
+	// little-endian that the wide-reduction routine wants at the same
+	// time.
+	var bLE [64]byte
+	for i, v := range b {
+		bLE[ell-1-i] = v
+	}
 
-	fe, err := new(field.Element).SetWideBytes(bLE)
-	if err != nil {
+	if _, err := fe.SetWideBytes(bLE[:]); err != nil {
 		panic("h2c: failed to decode wide field element: " + err.Error())
 	}
 
+	wipeBytes(bLE[:])
+
 	return fe
 }
 
+// HashToField implements `hash_to_field` for GF(2^255-19), with count
+// set to len(out), overwriting out with the field elements derived from
+// the domain separation tag and message with the provided Expander.
+//
+// The field elements are written directly into out, so the only
+// allocations are those done by the Expander (and the buffer for the
+// uniform bytes if more than 2 elements are requested).
+func HashToField(out []field.Element, expander Expander, domainSeparator, message []byte) error {
+	var (
+		buf          [hashToCurveSize]byte
+		uniformBytes []byte
+	)
+	if lenInBytes := len(out) * ell; lenInBytes <= len(buf) {
+		uniformBytes = buf[:lenInBytes]
+	} else {
+		uniformBytes = make([]byte, lenInBytes)
+	}
+	defer wipeBytes(uniformBytes)
+
+	if err := expander.ExpandMessage(uniformBytes, domainSeparator, message); err != nil {
+		return fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+
+	HashToFieldFromUniform(out, uniformBytes)
+
+	return nil
+}
+
+// HashToFieldFromUniform implements the portion of `hash_to_field` for
+// GF(2^255-19) that follows `expand_message`, overwriting out with the
+// field elements derived from uniformBytes, which must be exactly
+// `len(out) * L` bytes long.  This does not allocate.
+func HashToFieldFromUniform(out []field.Element, uniformBytes []byte) {
+	if len(uniformBytes) != len(out)*ell {
+		panic("h2c: invalid uniform bytes length")
+	}
+
+	for i := range out {
+		uniformToField25519Into(&out[i], uniformBytes[i*ell:(i+1)*ell])
+	}
+}
+
 func reversedByteSlice(b []byte) []byte {
 	bLen := len(b)
 	if bLen == 0 {
@@ -223,3 +504,9 @@ func reversedByteSlice(b []byte) []byte {
 
 	return out
 }
+
+func wipeBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
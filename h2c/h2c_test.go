@@ -0,0 +1,324 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	_ "crypto/sha1"
+	"errors"
+	"strings"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+func TestNonIdentity(t *testing.T) {
+	// An all-zero field element maps to (u, v) = (0, 0), which is
+	// sent to the identity by the birational map.
+	var uniformBytes [encodeToCurveSize]byte
+	p := encodeToCurveEdwards(new(edwards25519.Point), &uniformBytes)
+	if _, err := checkNonIdentity(p); !errors.Is(err, ErrIdentityPoint) {
+		t.Fatalf("checkNonIdentity(identity): %v", err)
+	}
+
+	p, err := Edwards25519_XMD_SHA512_ELL2_NU_NonIdentity([]byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_NU_"), []byte("abc"))
+	if err != nil {
+		t.Fatalf("Edwards25519_XMD_SHA512_ELL2_NU_NonIdentity: %v", err)
+	}
+	expected, err := Edwards25519_XMD_SHA512_ELL2_NU([]byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_NU_"), []byte("abc"))
+	if err != nil {
+		t.Fatalf("Edwards25519_XMD_SHA512_ELL2_NU: %v", err)
+	}
+	if p.Equal(expected) != 1 {
+		t.Fatalf("NonIdentity output mismatch")
+	}
+}
+
+type zeroExpander struct{}
+
+func (zeroExpander) ID() string {
+	return "ZERO"
+}
+
+func (zeroExpander) ExpandMessage(out, domainSeparator, message []byte) error {
+	for i := range out {
+		out[i] = 0
+	}
+	return nil
+}
+
+func TestHashToCurveNonIdentity(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_NU_")
+	msg := []byte("abc")
+
+	for _, curve := range []Curve{Edwards25519, Curve25519} {
+		suite, err := NewSuite(curve, zeroExpander{}, false)
+		if err != nil {
+			t.Fatalf("NewSuite(%s): %v", curve.ID(), err)
+		}
+		if _, err = HashToCurveNonIdentity(suite, dst, msg); !errors.Is(err, ErrIdentityPoint) {
+			t.Fatalf("HashToCurveNonIdentity(%s, identity): %v", curve.ID(), err)
+		}
+
+		suite, err = NewSuite(curve, NewExpanderXMD(crypto.SHA512), false)
+		if err != nil {
+			t.Fatalf("NewSuite(%s): %v", curve.ID(), err)
+		}
+		p, err := HashToCurveNonIdentity(suite, dst, msg)
+		if err != nil {
+			t.Fatalf("HashToCurveNonIdentity(%s): %v", curve.ID(), err)
+		}
+		if curve.IsIdentity(p) {
+			t.Fatalf("HashToCurveNonIdentity(%s): returned identity", curve.ID())
+		}
+	}
+}
+
+func TestFromUniform(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_")
+	msg := []byte("abc")
+
+	var roBytes [HashToCurveUniformSize]byte
+	if err := ExpandMessageXMD(roBytes[:], crypto.SHA512, dst, msg); err != nil {
+		t.Fatalf("ExpandMessageXMD: %v", err)
+	}
+	expected, _ := Edwards25519_XMD_SHA512_ELL2_RO(dst, msg)
+	if p := Edwards25519_ELL2_RO_FromUniform(&roBytes); p.Equal(expected) != 1 {
+		t.Fatalf("Edwards25519_ELL2_RO_FromUniform: point mismatch")
+	}
+	expectedMp, _ := Curve25519_XMD_SHA512_ELL2_RO_Point(dst, msg)
+	if mp := Curve25519_ELL2_RO_FromUniform(&roBytes); mp.Equal(expectedMp) != 1 {
+		t.Fatalf("Curve25519_ELL2_RO_FromUniform: point mismatch")
+	}
+
+	var nuBytes [EncodeToCurveUniformSize]byte
+	if err := ExpandMessageXMD(nuBytes[:], crypto.SHA512, dst, msg); err != nil {
+		t.Fatalf("ExpandMessageXMD: %v", err)
+	}
+	expected, _ = Edwards25519_XMD_SHA512_ELL2_NU(dst, msg)
+	if p := Edwards25519_ELL2_NU_FromUniform(&nuBytes); p.Equal(expected) != 1 {
+		t.Fatalf("Edwards25519_ELL2_NU_FromUniform: point mismatch")
+	}
+	expectedMp, _ = Curve25519_XMD_SHA512_ELL2_NU_Point(dst, msg)
+	if mp := Curve25519_ELL2_NU_FromUniform(&nuBytes); mp.Equal(expectedMp) != 1 {
+		t.Fatalf("Curve25519_ELL2_NU_FromUniform: point mismatch")
+	}
+}
+
+func TestRandomPoint(t *testing.T) {
+	var b [HashToCurveUniformSize]byte
+	for i := range b {
+		b[i] = byte(i)
+	}
+
+	p, err := RandomPoint(bytes.NewReader(b[:]))
+	if err != nil {
+		t.Fatalf("RandomPoint: %v", err)
+	}
+	if expected := Edwards25519_ELL2_RO_FromUniform(&b); p.Equal(expected) != 1 {
+		t.Fatalf("RandomPoint: point mismatch")
+	}
+
+	if _, err = RandomPoint(bytes.NewReader(b[:HashToCurveUniformSize-1])); err == nil {
+		t.Fatalf("RandomPoint: accepted short read")
+	}
+
+	p, err = RandomPoint(nil)
+	if err != nil {
+		t.Fatalf("RandomPoint(nil): %v", err)
+	}
+	if p.Equal(identityPoint) == 1 {
+		t.Fatalf("RandomPoint(nil): returned identity")
+	}
+}
+
+func TestFieldHasher(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_")
+	msg := []byte("abc")
+
+	fh := NewFieldHasher(NewExpanderXMD(crypto.SHA512), dst, msg)
+
+	var prev []*field.Element
+	for i := uint32(0); i < 4; i++ {
+		fe, err := fh.Next()
+		if err != nil {
+			t.Fatalf("fh.Next: %v", err)
+		}
+
+		var uniformBytes [ell]byte
+		msgPrime := append(append([]byte{}, msg...), 0, 0, 0, byte(i))
+		if err = ExpandMessageXMD(uniformBytes[:], crypto.SHA512, dst, msgPrime); err != nil {
+			t.Fatalf("ExpandMessageXMD: %v", err)
+		}
+		if fe.Equal(uniformToField25519(uniformBytes[:])) != 1 {
+			t.Fatalf("fh.Next[%d]: element mismatch", i)
+		}
+
+		for j, prevFe := range prev {
+			if fe.Equal(prevFe) == 1 {
+				t.Fatalf("fh.Next[%d]: element equals element %d", i, j)
+			}
+		}
+		prev = append(prev, fe)
+	}
+
+	fe, err := fh.Element(2)
+	if err != nil {
+		t.Fatalf("fh.Element: %v", err)
+	}
+	if fe.Equal(prev[2]) != 1 {
+		t.Fatalf("fh.Element(2): element mismatch")
+	}
+
+	fh.Reset()
+	if fe, _ = fh.Next(); fe.Equal(prev[0]) != 1 {
+		t.Fatalf("fh.Next: Reset did not restart the sequence")
+	}
+
+	fh = NewFieldHasher(NewExpanderXMD(crypto.SHA1), dst, msg)
+	if _, err = fh.Next(); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("fh.Next: unexpected error: %v", err)
+	}
+}
+
+func TestHashToFieldFromUniformAllocs(t *testing.T) {
+	var (
+		uniformBytes [HashToCurveUniformSize]byte
+		out          [2]field.Element
+	)
+	for i := range uniformBytes {
+		uniformBytes[i] = byte(i)
+	}
+
+	if n := testing.AllocsPerRun(100, func() {
+		HashToFieldFromUniform(out[:], uniformBytes[:])
+	}); n != 0 {
+		t.Fatalf("HashToFieldFromUniform: %v allocations", n)
+	}
+}
+
+func TestSelfTest(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Fatalf("SelfTest: %v", err)
+	}
+
+	// Ensure that failures are actually detected.
+	orig := selfTestVectors[1].expected
+	defer func() {
+		selfTestVectors[1].expected = orig
+	}()
+	selfTestVectors[1].expected = strings.Repeat("00", 32)
+
+	if err := SelfTest(); !errors.Is(err, ErrSelfTest) {
+		t.Fatalf("SelfTest: unexpected error: %v", err)
+	}
+}
+
+func TestEdwards25519_XMD_SHA512_ELL2Allocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool is nondeterministic with the race detector")
+	}
+
+	dst := []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_NU_")
+	msg := []byte("abc")
+
+	if n := testing.AllocsPerRun(100, func() {
+		_, _ = Edwards25519_XMD_SHA512_ELL2_NU(dst, msg)
+	}); n != 0 {
+		t.Fatalf("Edwards25519_XMD_SHA512_ELL2_NU: %v allocations", n)
+	}
+
+	// Oversized DSTs are reduced into a fixed size buffer.
+	longDST := bytes.Repeat([]byte("a"), 256)
+	if n := testing.AllocsPerRun(100, func() {
+		_, _ = Edwards25519_XMD_SHA512_ELL2_NU(longDST, msg)
+	}); n != 0 {
+		t.Fatalf("Edwards25519_XMD_SHA512_ELL2_NU(longDST): %v allocations", n)
+	}
+}
+
+func BenchmarkEdwards25519_XMD_SHA512_ELL2(b *testing.B) {
+	msg := []byte("a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+
+	b.Run("EncodeToCurve", func(b *testing.B) {
+		dst := []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_NU_")
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			p, err := Edwards25519_XMD_SHA512_ELL2_NU(dst, msg)
+			if err != nil || p == nil {
+				b.Fatalf("Edwards25519_XMD_SHA512_ELL2_NU: %v", err)
+			}
+		}
+	})
+	b.Run("HashToCurve", func(b *testing.B) {
+		dst := []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_")
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			p, err := Edwards25519_XMD_SHA512_ELL2_RO(dst, msg)
+			if err != nil || p == nil {
+				b.Fatalf("Edwards25519_XMD_SHA512_ELL2_RO: %v", err)
+			}
+		}
+	})
+}
+
+func TestHashToCurveBlinded(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_")
+	msg := []byte("abc")
+
+	var b [64]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		t.Fatalf("rand.Read: %v", err)
+	}
+	blind, _ := edwards25519.NewScalar().SetUniformBytes(b[:])
+
+	p, err := HashToCurveBlinded(dst, msg, blind)
+	if err != nil {
+		t.Fatalf("HashToCurveBlinded: %v", err)
+	}
+
+	expected, err := Edwards25519_XMD_SHA512_ELL2_RO(dst, msg)
+	if err != nil {
+		t.Fatalf("Edwards25519_XMD_SHA512_ELL2_RO: %v", err)
+	}
+	expected.ScalarMult(blind, expected)
+	if p.Equal(expected) != 1 {
+		t.Fatalf("HashToCurveBlinded: point mismatch")
+	}
+
+	if _, err = HashToCurveBlinded(dst, msg, edwards25519.NewScalar()); !errors.Is(err, ErrZeroBlind) {
+		t.Fatalf("HashToCurveBlinded(0): unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+//go:build !h2c_noxof
+// +build !h2c_noxof
+
+// Copyright (c) 2021 Oasis Labs Inc. All rights reserved.
+// Copyright (c) 2021 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+	"golang.org/x/crypto/sha3"
+)
+
+// Edwards25519_XOF_ELL2_RO implements a generic edwards25519 random oracle suite
+// using `expand_message_xof`.
+func Edwards25519_XOF_ELL2_RO(xofFunc sha3.ShakeHash, domainSeparator, message []byte) (*edwards25519.Point, error) {
+	return Edwards25519_XOFFunc_ELL2_RO(cloneXOFFunc(xofFunc), domainSeparator, message)
+}
+
+// Edwards25519_XOF_ELL2_NU implements a generic edwards25519 nonuniform suite
+// using `expand_messsage_xof`.
+func Edwards25519_XOF_ELL2_NU(xofFunc sha3.ShakeHash, domainSeparator, message []byte) (*edwards25519.Point, error) {
+	return Edwards25519_XOFFunc_ELL2_NU(cloneXOFFunc(xofFunc), domainSeparator, message)
+}
+
+// Edwards25519_XOF_ELL2_NU_NonIdentity implements a generic edwards25519
+// nonuniform suite using `expand_message_xof`, returning ErrIdentityPoint
+// if the output is the identity element.
+func Edwards25519_XOF_ELL2_NU_NonIdentity(xofFunc sha3.ShakeHash, domainSeparator, message []byte) (*edwards25519.Point, error) {
+	p, err := Edwards25519_XOF_ELL2_NU(xofFunc, domainSeparator, message)
+	if err != nil {
+		return nil, err
+	}
+	return checkNonIdentity(p)
+}
+
+// Curve25519_XOF_ELL2_RO implements a generic curve25519 random oracle suite
+// using `expand_message_xof`, returning the u and v-coordinates.
+//
+// Deprecated: Use Curve25519_XOF_ELL2_RO_Point.
+func Curve25519_XOF_ELL2_RO(xofFunc sha3.ShakeHash, domainSeparator, message []byte) (*field.Element, *field.Element, error) {
+	p, err := Curve25519_XOF_ELL2_RO_Point(xofFunc, domainSeparator, message)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.U(), p.V(), nil
+}
+
+// Curve25519_XOF_ELL2_RO_Point implements a generic curve25519 random oracle
+// suite using `expand_message_xof`.
+func Curve25519_XOF_ELL2_RO_Point(xofFunc sha3.ShakeHash, domainSeparator, message []byte) (*MontgomeryPoint, error) {
+	return Curve25519_XOFFunc_ELL2_RO_Point(cloneXOFFunc(xofFunc), domainSeparator, message)
+}
+
+// Curve25519_XOF_ELL2_NU implements a generic curve25519 nonuniform suite
+// using `expand_message_xof`, returning the u and v-coordinates.
+//
+// Deprecated: Use Curve25519_XOF_ELL2_NU_Point.
+func Curve25519_XOF_ELL2_NU(xofFunc sha3.ShakeHash, domainSeparator, message []byte) (*field.Element, *field.Element, error) {
+	p, err := Curve25519_XOF_ELL2_NU_Point(xofFunc, domainSeparator, message)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.U(), p.V(), nil
+}
+
+// Curve25519_XOF_ELL2_NU_Point implements a generic curve25519 nonuniform
+// suite using `expand_message_xof`.
+func Curve25519_XOF_ELL2_NU_Point(xofFunc sha3.ShakeHash, domainSeparator, message []byte) (*MontgomeryPoint, error) {
+	return Curve25519_XOFFunc_ELL2_NU_Point(cloneXOFFunc(xofFunc), domainSeparator, message)
+}
+
+// Edwards25519_XOFFunc_ELL2_RO implements a generic edwards25519 random
+// oracle suite using `expand_message_xof`, with the provided XOF
+// constructor (eg: `sha3.NewShake256`).
+func Edwards25519_XOFFunc_ELL2_RO(newXOF func() sha3.ShakeHash, domainSeparator, message []byte) (*edwards25519.Point, error) {
+	var uniformBytes [hashToCurveSize]byte
+	if err := ExpandMessageXOFFunc(uniformBytes[:], newXOF, domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+	return hashToCurveEdwards(new(edwards25519.Point), &uniformBytes), nil
+}
+
+// Edwards25519_XOFFunc_ELL2_NU implements a generic edwards25519
+// nonuniform suite using `expand_message_xof`, with the provided XOF
+// constructor (eg: `sha3.NewShake256`).
+func Edwards25519_XOFFunc_ELL2_NU(newXOF func() sha3.ShakeHash, domainSeparator, message []byte) (*edwards25519.Point, error) {
+	var uniformBytes [encodeToCurveSize]byte
+	if err := ExpandMessageXOFFunc(uniformBytes[:], newXOF, domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+	return encodeToCurveEdwards(new(edwards25519.Point), &uniformBytes), nil
+}
+
+// Curve25519_XOFFunc_ELL2_RO_Point implements a generic curve25519 random
+// oracle suite using `expand_message_xof`, with the provided XOF
+// constructor (eg: `sha3.NewShake256`).
+func Curve25519_XOFFunc_ELL2_RO_Point(newXOF func() sha3.ShakeHash, domainSeparator, message []byte) (*MontgomeryPoint, error) {
+	var uniformBytes [hashToCurveSize]byte
+	if err := ExpandMessageXOFFunc(uniformBytes[:], newXOF, domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+	return hashToCurveMontgomery(new(MontgomeryPoint), &uniformBytes), nil
+}
+
+// Curve25519_XOFFunc_ELL2_NU_Point implements a generic curve25519
+// nonuniform suite using `expand_message_xof`, with the provided XOF
+// constructor (eg: `sha3.NewShake256`).
+func Curve25519_XOFFunc_ELL2_NU_Point(newXOF func() sha3.ShakeHash, domainSeparator, message []byte) (*MontgomeryPoint, error) {
+	var uniformBytes [encodeToCurveSize]byte
+	if err := ExpandMessageXOFFunc(uniformBytes[:], newXOF, domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+	return encodeToCurveMontgomery(new(MontgomeryPoint), &uniformBytes), nil
+}
@@ -0,0 +1,129 @@
+//go:build !h2c_noxof
+// +build !h2c_noxof
+
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"sync"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestXOFFunc(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-edwards25519_XOF:SHAKE256_ELL2_RO_")
+	msg := []byte("abc")
+
+	expected, err := Edwards25519_XOF_ELL2_RO(sha3.NewShake256(), dst, msg)
+	if err != nil {
+		t.Fatalf("Edwards25519_XOF_ELL2_RO: %v", err)
+	}
+	expectedMp, err := Curve25519_XOF_ELL2_NU_Point(sha3.NewShake256(), dst, msg)
+	if err != nil {
+		t.Fatalf("Curve25519_XOF_ELL2_NU_Point: %v", err)
+	}
+
+	// The constructor based API should be safe to call concurrently.
+	var wg sync.WaitGroup
+	errCh := make(chan error, 16)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p, err := Edwards25519_XOFFunc_ELL2_RO(sha3.NewShake256, dst, msg)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			if p.Equal(expected) != 1 {
+				t.Errorf("Edwards25519_XOFFunc_ELL2_RO: point mismatch")
+			}
+			mp, err := Curve25519_XOFFunc_ELL2_NU_Point(sha3.NewShake256, dst, msg)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			if mp.Equal(expectedMp) != 1 {
+				t.Errorf("Curve25519_XOFFunc_ELL2_NU_Point: point mismatch")
+			}
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+	for err := range errCh {
+		t.Fatalf("XOFFunc: %v", err)
+	}
+
+	suite, err := NewSuite(Edwards25519, NewExpanderXOF("SHAKE256", sha3.NewShake256), true)
+	if err != nil {
+		t.Fatalf("NewSuite: %v", err)
+	}
+	if id := suite.ID(); id != "edwards25519_XOF:SHAKE256_ELL2_RO_" {
+		t.Fatalf("suite.ID: unexpected ID: '%s'", id)
+	}
+	p, err := suite.Hash(dst, msg)
+	if err != nil {
+		t.Fatalf("suite.Hash: %v", err)
+	}
+	if p.(*edwards25519.Point).Equal(expected) != 1 {
+		t.Fatalf("suite.Hash: point mismatch")
+	}
+}
+
+func TestHasherXOF(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-edwards25519_XOF:SHAKE256_ELL2_RO_")
+	msg := []byte("abcdef0123456789")
+
+	suite, err := NewSuite(Edwards25519, NewExpanderXOF("SHAKE256", sha3.NewShake256), true)
+	if err != nil {
+		t.Fatalf("NewSuite: %v", err)
+	}
+	expected, err := suite.Hash(dst, msg)
+	if err != nil {
+		t.Fatalf("suite.Hash: %v", err)
+	}
+
+	h := suite.NewHasher(dst)
+	for i := 0; i < 2; i++ {
+		_, _ = h.Write(msg[:3])
+		_, _ = h.Write(msg[3:])
+		p, err := h.SumPoint()
+		if err != nil {
+			t.Fatalf("h.SumPoint: %v", err)
+		}
+		if p.(*edwards25519.Point).Equal(expected.(*edwards25519.Point)) != 1 {
+			t.Fatalf("h.SumPoint: point mismatch")
+		}
+		h.Reset()
+	}
+}
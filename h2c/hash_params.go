@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var hashParamsRegistry struct {
+	sync.RWMutex
+	m map[crypto.Hash]hashParams
+}
+
+type hashParams struct {
+	blockSize  int // s_in_bytes
+	outputSize int // b_in_bytes
+}
+
+// RegisterHashParams registers the input block size (`s_in_bytes`) and
+// output size (`b_in_bytes`) to be used by `expand_message_xmd` with the
+// hash function hFunc, which must already be linked into the binary.
+//
+// By default the parameters are taken from the `hash.Hash` returned by
+// `hFunc.New()`, which is correct for all of the standard library hash
+// functions.  This is only required for hash implementations that do
+// not report a block size, and as the parameters apply process-wide,
+// hash functions that report a block size can not be overridden, and
+// each hash function may only be registered once.
+func RegisterHashParams(hFunc crypto.Hash, blockSize, outputSize int) error {
+	if !hFunc.Available() {
+		return fmt.Errorf("%w: hash function unavailable", ErrInvalidHash)
+	}
+	if hFunc.New().BlockSize() > 0 {
+		return fmt.Errorf("%w: hash block size already known", ErrInvalidHash)
+	}
+	if blockSize <= 0 {
+		return fmt.Errorf("h2c: invalid hash block size: %d", blockSize)
+	}
+	if outputSize != hFunc.Size() {
+		return fmt.Errorf("h2c: hash output size mismatch: %d (expected %d)", outputSize, hFunc.Size())
+	}
+
+	hashParamsRegistry.Lock()
+	defer hashParamsRegistry.Unlock()
+
+	if hashParamsRegistry.m == nil {
+		hashParamsRegistry.m = make(map[crypto.Hash]hashParams)
+	}
+	if _, ok := hashParamsRegistry.m[hFunc]; ok {
+		return fmt.Errorf("%w: hash params already registered", ErrInvalidHash)
+	}
+	hashParamsRegistry.m[hFunc] = hashParams{
+		blockSize:  blockSize,
+		outputSize: outputSize,
+	}
+
+	return nil
+}
+
+// lookupHashParams returns `s_in_bytes` and `b_in_bytes` for the hash
+// function hFunc.  As RegisterHashParams only accepts hash functions that
+// do not report a block size, the registry is only consulted for those.
+func lookupHashParams(hFunc crypto.Hash) (int, int, error) {
+	if !hFunc.Available() {
+		return 0, 0, fmt.Errorf("%w: hash function unavailable", ErrInvalidHash)
+	}
+
+	h := hFunc.New()
+	if h.BlockSize() > 0 {
+		return h.BlockSize(), h.Size(), nil
+	}
+
+	hashParamsRegistry.RLock()
+	params, ok := hashParamsRegistry.m[hFunc]
+	hashParamsRegistry.RUnlock()
+	if !ok {
+		return 0, 0, errors.New("h2c: hash block size unknown")
+	}
+	return params.blockSize, params.outputSize, nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrHasherFinalized is the error returned when a Hasher is used after
+// SumPoint has been called, without an intervening Reset.
+var ErrHasherFinalized = errors.New("h2c: hasher already finalized")
+
+// Hasher is a streaming interface to a Suite, that hashes all of the
+// data written to it to a point.
+//
+// Unlike `hash.Hash`, SumPoint finalizes the Hasher, and Reset must be
+// called before the Hasher can be reused.  Hasher instances are not
+// safe for concurrent use.
+type Hasher struct {
+	suite    *Suite
+	expander preparedExpander
+	prepErr  error
+
+	stream messageStream
+	err    error
+	done   bool
+}
+
+// NewHasher returns a new Hasher for the suite, with the provided domain
+// separation tag.
+func (s *Suite) NewHasher(domainSeparator []byte) *Hasher {
+	pe, err := prepareExpander(s.expander, domainSeparator)
+	return newHasher(s, pe, err)
+}
+
+// NewHasher returns a new Hasher for the prepared suite.
+func (ps *PreparedSuite) NewHasher() *Hasher {
+	return newHasher(ps.suite, ps.expander, nil)
+}
+
+func newHasher(s *Suite, pe preparedExpander, err error) *Hasher {
+	if err != nil {
+		err = fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+
+	h := &Hasher{
+		suite:    s,
+		expander: pe,
+		prepErr:  err,
+	}
+	h.Reset()
+
+	return h
+}
+
+// Write adds more data to the message being hashed.  It returns an error
+// iff the Hasher is finalized, or the suite's expander rejected the
+// domain separation tag or output length.
+func (h *Hasher) Write(p []byte) (int, error) {
+	switch {
+	case h.done:
+		return 0, ErrHasherFinalized
+	case h.err != nil:
+		return 0, h.err
+	default:
+		return h.stream.Write(p)
+	}
+}
+
+// SumPoint finalizes the Hasher and returns the point corresponding to
+// all of the data written to it.  This is equivalent to calling the
+// suite's Hash method with the concatenation of the data.
+func (h *Hasher) SumPoint() (Point, error) {
+	if h.done {
+		return nil, ErrHasherFinalized
+	}
+	h.done = true
+	if h.err != nil {
+		return nil, h.err
+	}
+
+	uniformBytes := make([]byte, h.suite.uniformSize())
+	defer wipeBytes(uniformBytes)
+
+	err := h.stream.finish(uniformBytes)
+	h.stream = nil
+	if err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+
+	return h.suite.mapUniform(uniformBytes), nil
+}
+
+// Reset resets the Hasher to its initial state.
+func (h *Hasher) Reset() {
+	h.stream, h.err, h.done = nil, h.prepErr, false
+	if h.err != nil {
+		return
+	}
+
+	h.stream, h.err = h.expander.newMessageStream(h.suite.uniformSize())
+	if h.err != nil {
+		h.err = fmt.Errorf("h2c: failed to expand message: %w", h.err)
+	}
+}
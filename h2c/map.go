@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"gitlab.com/yawning/edwards25519-extra/elligator2"
+)
+
+// MapToCurveEdwards25519 implements `map_to_curve` for edwards25519
+// (`map_to_curve_elligator2_edwards25519`), mapping the field element u
+// to a point.
+//
+// Note: The cofactor is NOT cleared, and the output may be of small or
+// mixed order.  See ClearCofactor.
+func MapToCurveEdwards25519(u *field.Element) *edwards25519.Point {
+	return elligator2.EdwardsFlavor(u)
+}
+
+// MapToCurveCurve25519 implements `map_to_curve` for curve25519
+// (`map_to_curve_elligator2_curve25519`), mapping the field element u
+// to a point.
+//
+// Note: The cofactor is NOT cleared, and the output may be of small or
+// mixed order.  See ClearCofactorCurve25519.
+func MapToCurveCurve25519(u *field.Element) *MontgomeryPoint {
+	mu, mv := elligator2.MontgomeryFlavor(u)
+	p := newMontgomeryPoint(mu, mv)
+	mu.Zero()
+	mv.Zero()
+	return p
+}
+
+// ClearCofactor implements `clear_cofactor` for edwards25519, returning
+// `h_eff * p`.  This is the exact operation used by the edwards25519
+// suites.
+func ClearCofactor(p *edwards25519.Point) *edwards25519.Point {
+	return clearCofactor(new(edwards25519.Point), p)
+}
+
+func clearCofactor(dst, p *edwards25519.Point) *edwards25519.Point {
+	return dst.MultByCofactor(p)
+}
+
+// ClearCofactorCurve25519 implements `clear_cofactor` for curve25519,
+// returning `h_eff * p`.  This is the exact operation used by the
+// curve25519 suites.
+func ClearCofactorCurve25519(p *MontgomeryPoint) *MontgomeryPoint {
+	pEd := montgomeryToEdwards(p)
+	q := montgomeryFromEdwards(ClearCofactor(pEd))
+	pEd.Set(identityPoint)
+	return q
+}
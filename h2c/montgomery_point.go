@@ -0,0 +1,218 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+// MontgomeryUVSize is the size of the affine (u, v) encoding of a
+// MontgomeryPoint.
+const MontgomeryUVSize = 64
+
+// ErrInvalidUVEncoding is the error returned by UnmarshalUV when the
+// encoding is malformed or does not represent a point on curve25519.
+var ErrInvalidUVEncoding = errors.New("h2c: invalid (u, v) encoding")
+
+// MontgomeryPoint is an affine point on curve25519, represented by
+// the u and v-coordinates.
+type MontgomeryPoint struct {
+	u field.Element
+	v field.Element
+}
+
+// U returns a copy of the u-coordinate of the point.
+func (p *MontgomeryPoint) U() *field.Element {
+	return new(field.Element).Set(&p.u)
+}
+
+// V returns a copy of the v-coordinate of the point.
+func (p *MontgomeryPoint) V() *field.Element {
+	return new(field.Element).Set(&p.v)
+}
+
+// Bytes returns the canonical 32-byte little-endian encoding of the
+// u-coordinate of the point, as used by X25519.
+func (p *MontgomeryPoint) Bytes() []byte {
+	return p.u.Bytes()
+}
+
+// MarshalUV returns the MontgomeryUVSize-byte affine encoding of the
+// point, consisting of the canonical little-endian encodings of the u
+// and v-coordinates (`u || v`).
+func (p *MontgomeryPoint) MarshalUV() []byte {
+	var b [MontgomeryUVSize]byte
+	copy(b[:32], p.u.Bytes())
+	copy(b[32:], p.v.Bytes())
+	return b[:]
+}
+
+// UnmarshalUV sets p to the point represented by the affine encoding
+// produced by MarshalUV.  Both coordinates must be canonically encoded
+// and satisfy the curve equation, otherwise ErrInvalidUVEncoding is
+// returned and p is left unchanged.
+//
+// Note: The point of order 2 and the identity element share the (0, 0)
+// encoding, which is accepted.  This does not check that the point is
+// in the prime order subgroup.
+func (p *MontgomeryPoint) UnmarshalUV(b []byte) error {
+	if len(b) != MontgomeryUVSize {
+		return fmt.Errorf("%w: invalid length: %d", ErrInvalidUVEncoding, len(b))
+	}
+
+	var u, v field.Element
+	if err := setCanonicalBytes(&u, b[:32]); err != nil {
+		return fmt.Errorf("%w: u-coordinate: %v", ErrInvalidUVEncoding, err)
+	}
+	if err := setCanonicalBytes(&v, b[32:]); err != nil {
+		return fmt.Errorf("%w: v-coordinate: %v", ErrInvalidUVEncoding, err)
+	}
+
+	// v^2 = u^3 + A*u^2 + u = ((u + A) * u + 1) * u
+	var lhs, rhs field.Element
+	lhs.Square(&v)
+	rhs.Add(&u, montgomery.A)
+	rhs.Multiply(&rhs, &u)
+	rhs.Add(&rhs, montgomery.ONE)
+	rhs.Multiply(&rhs, &u)
+	if lhs.Equal(&rhs) != 1 {
+		return fmt.Errorf("%w: point not on curve", ErrInvalidUVEncoding)
+	}
+
+	p.u.Set(&u)
+	p.v.Set(&v)
+
+	return nil
+}
+
+func setCanonicalBytes(fe *field.Element, b []byte) error {
+	if _, err := fe.SetBytes(b); err != nil {
+		return err
+	}
+	// field.Element.SetBytes ignores the most significant bit, and
+	// accepts values >= p, so round-trip to reject both.
+	if !bytes.Equal(fe.Bytes(), b) {
+		return errors.New("non-canonical encoding")
+	}
+	return nil
+}
+
+// EdwardsXSign is the sign convention used when converting between
+// curve25519 and edwards25519 points.
+//
+// The birational map `(x, y) = (sqrt(-486664) * u / v, (u - 1) / (u + 1))`
+// depends on the choice of the square root of -486664, with the two
+// choices producing x-coordinates that differ in sign.
+type EdwardsXSign int
+
+const (
+	// EdwardsXSignRFC9380 uses the non-negative square root of -486664,
+	// as specified in RFC 9380 (and used internally by the suites).
+	EdwardsXSignRFC9380 EdwardsXSign = iota
+
+	// EdwardsXSignNegated uses the negative square root of -486664,
+	// for interoperability with implementations that pick the other
+	// root.
+	EdwardsXSignNegated
+)
+
+// EdwardsPoint returns the edwards25519 point corresponding to p, with
+// the specified sign convention.
+func (p *MontgomeryPoint) EdwardsPoint(sign EdwardsXSign) *edwards25519.Point {
+	q := montgomery.ToEdwardsPoint(&p.u, &p.v)
+	switch sign {
+	case EdwardsXSignRFC9380:
+	case EdwardsXSignNegated:
+		q.Negate(q)
+	default:
+		panic("h2c: invalid EdwardsXSign")
+	}
+	return q
+}
+
+// SetEdwardsPoint sets p to the curve25519 point corresponding to the
+// edwards25519 point q, with the specified sign convention, and
+// returns p.
+func (p *MontgomeryPoint) SetEdwardsPoint(q *edwards25519.Point, sign EdwardsXSign) *MontgomeryPoint {
+	var tmp edwards25519.Point
+	switch sign {
+	case EdwardsXSignRFC9380:
+		tmp.Set(q)
+	case EdwardsXSignNegated:
+		tmp.Negate(q)
+	default:
+		panic("h2c: invalid EdwardsXSign")
+	}
+	montgomery.SetFromEdwardsPoint(&p.u, &p.v, &tmp)
+	tmp.Set(identityPoint)
+	return p
+}
+
+// Equal returns 1 if p is equivalent to q, and 0 otherwise.
+func (p *MontgomeryPoint) Equal(q *MontgomeryPoint) int {
+	return p.u.Equal(&q.u) & p.v.Equal(&q.v)
+}
+
+// isIdentity returns 1 iff p is the identity element, which is
+// represented as (0, 0) by the Edwards to Montgomery conversion.
+//
+// Note: This is also the encoding of the point of order 2, which can
+// not be the output of a suite, as the cofactor is cleared.
+func (p *MontgomeryPoint) isIdentity() int {
+	var zero field.Element
+	return p.u.Equal(&zero) & p.v.Equal(&zero)
+}
+
+func newMontgomeryPoint(u, v *field.Element) *MontgomeryPoint {
+	var p MontgomeryPoint
+	p.u.Set(u)
+	p.v.Set(v)
+	return &p
+}
+
+func (p *MontgomeryPoint) zero() {
+	p.u.Zero()
+	p.v.Zero()
+}
+
+func montgomeryFromEdwards(p *edwards25519.Point) *MontgomeryPoint {
+	u, v := montgomery.FromEdwardsPoint(p)
+	mp := newMontgomeryPoint(u, v)
+	u.Zero()
+	v.Zero()
+	return mp
+}
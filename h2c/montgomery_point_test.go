@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+func TestMontgomeryPointUV(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-curve25519_XMD:SHA-512_ELL2_RO_")
+
+	p, err := Curve25519_XMD_SHA512_ELL2_RO_Point(dst, []byte("abc"))
+	if err != nil {
+		t.Fatalf("Curve25519_XMD_SHA512_ELL2_RO_Point: %v", err)
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		b := p.MarshalUV()
+		if len(b) != MontgomeryUVSize {
+			t.Fatalf("MarshalUV: unexpected length: %d", len(b))
+		}
+		if !bytes.Equal(b[:32], p.Bytes()) {
+			t.Fatalf("MarshalUV: u-coordinate mismatch")
+		}
+
+		var q MontgomeryPoint
+		if err := q.UnmarshalUV(b); err != nil {
+			t.Fatalf("UnmarshalUV: %v", err)
+		}
+		if q.Equal(p) != 1 {
+			t.Fatalf("UnmarshalUV: point mismatch")
+		}
+	})
+
+	t.Run("Identity", func(t *testing.T) {
+		var q MontgomeryPoint
+		if err := q.UnmarshalUV(make([]byte, MontgomeryUVSize)); err != nil {
+			t.Fatalf("UnmarshalUV(0, 0): %v", err)
+		}
+		if q.isIdentity() != 1 {
+			t.Fatalf("UnmarshalUV(0, 0): not identity")
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		good := p.MarshalUV()
+
+		// p = 2^255 - 19, which is a non-canonical encoding of 0.
+		nonCanonical := make([]byte, 32)
+		nonCanonical[0] = 0xed
+		for i := 1; i < 31; i++ {
+			nonCanonical[i] = 0xff
+		}
+		nonCanonical[31] = 0x7f
+
+		var negV field.Element
+		negV.Negate(p.V())
+
+		for _, tc := range []struct {
+			n string
+			b []byte
+		}{
+			{"Short", good[:MontgomeryUVSize-1]},
+			{"Long", append(append([]byte{}, good...), 0)},
+			{"NonCanonicalU", append(append([]byte{}, nonCanonical...), make([]byte, 32)...)},
+			{"NonCanonicalV", append(make([]byte, 32), nonCanonical...)},
+			{"HighBitV", func() []byte {
+				b := append([]byte{}, good...)
+				b[MontgomeryUVSize-1] |= 0x80
+				return b
+			}()},
+			{"NotOnCurve", func() []byte {
+				b := append([]byte{}, good...)
+				b[32] ^= 0x01
+				return b
+			}()},
+		} {
+			t.Run(tc.n, func(t *testing.T) {
+				q := newMontgomeryPoint(p.U(), &negV)
+				if err := q.UnmarshalUV(tc.b); !errors.Is(err, ErrInvalidUVEncoding) {
+					t.Fatalf("UnmarshalUV: unexpected error: %v", err)
+				}
+				if q.v.Equal(&negV) != 1 {
+					t.Fatalf("UnmarshalUV: point modified on failure")
+				}
+			})
+		}
+	})
+}
+
+func TestMontgomeryPointEdwards(t *testing.T) {
+	dstMp := []byte("QUUX-V01-CS02-with-curve25519_XMD:SHA-512_ELL2_RO_")
+	msg := []byte("abc")
+
+	p, err := Curve25519_XMD_SHA512_ELL2_RO_Point(dstMp, msg)
+	if err != nil {
+		t.Fatalf("Curve25519_XMD_SHA512_ELL2_RO_Point: %v", err)
+	}
+
+	// The curve25519 and edwards25519 suites share everything but the
+	// DST, so hash with the same DST to get equivalent points.
+	pEd, err := Edwards25519_XMD_SHA512_ELL2_RO(dstMp, msg)
+	if err != nil {
+		t.Fatalf("Edwards25519_XMD_SHA512_ELL2_RO: %v", err)
+	}
+
+	if q := p.EdwardsPoint(EdwardsXSignRFC9380); q.Equal(pEd) != 1 {
+		t.Fatalf("EdwardsPoint(RFC9380): point mismatch")
+	}
+	negPEd := new(edwards25519.Point).Negate(pEd)
+	if q := p.EdwardsPoint(EdwardsXSignNegated); q.Equal(negPEd) != 1 {
+		t.Fatalf("EdwardsPoint(Negated): point mismatch")
+	}
+
+	for _, sign := range []EdwardsXSign{EdwardsXSignRFC9380, EdwardsXSignNegated} {
+		var q MontgomeryPoint
+		if q.SetEdwardsPoint(p.EdwardsPoint(sign), sign).Equal(p) != 1 {
+			t.Fatalf("SetEdwardsPoint(%d): round trip failed", sign)
+		}
+	}
+
+	// The u-coordinate is independent of the sign convention.
+	var q MontgomeryPoint
+	q.SetEdwardsPoint(pEd, EdwardsXSignNegated)
+	if !bytes.Equal(q.Bytes(), p.Bytes()) {
+		t.Fatalf("SetEdwardsPoint(Negated): u-coordinate mismatch")
+	}
+	var negV field.Element
+	negV.Negate(p.V())
+	if q.v.Equal(&negV) != 1 {
+		t.Fatalf("SetEdwardsPoint(Negated): v-coordinate not negated")
+	}
+}
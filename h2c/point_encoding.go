@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+)
+
+// ErrNonCanonicalPoint is the error returned by DecodeCanonicalPoint
+// when the encoding is valid, but not canonical.
+var ErrNonCanonicalPoint = errors.New("h2c: non-canonical point encoding")
+
+// DecodeCanonicalPoint decodes the 32-byte compressed encoding of an
+// edwards25519 point, as per RFC 8032 Section 5.1.3.
+//
+// Unlike edwards25519.Point.SetBytes, this rejects non-canonical
+// encodings (y-coordinates >= p, and the negative zero x-coordinate),
+// by requiring that the encoding round-trips.  This does not check
+// that the point is in the prime order subgroup.
+func DecodeCanonicalPoint(b []byte) (*edwards25519.Point, error) {
+	p, err := edwards25519.NewIdentityPoint().SetBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("h2c: failed to decompress point: %w", err)
+	}
+	if subtle.ConstantTimeCompare(p.Bytes(), b) != 1 {
+		return nil, ErrNonCanonicalPoint
+	}
+	return p, nil
+}
+
+// IsCanonicalPointEncoding returns true iff b is the canonical 32-byte
+// compressed encoding of an edwards25519 point.
+func IsCanonicalPointEncoding(b []byte) bool {
+	_, err := DecodeCanonicalPoint(b)
+	return err == nil
+}
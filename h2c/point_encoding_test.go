@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"errors"
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+func TestDecodeCanonicalPoint(t *testing.T) {
+	t.Run("Canonical", func(t *testing.T) {
+		for _, p := range []*edwards25519.Point{
+			edwards25519.NewGeneratorPoint(),
+			edwards25519.NewIdentityPoint(),
+		} {
+			b := p.Bytes()
+			if !IsCanonicalPointEncoding(b) {
+				t.Fatalf("IsCanonicalPointEncoding(%x): false", b)
+			}
+			q, err := DecodeCanonicalPoint(b)
+			if err != nil {
+				t.Fatalf("DecodeCanonicalPoint(%x): %v", b, err)
+			}
+			if q.Equal(p) != 1 {
+				t.Fatalf("DecodeCanonicalPoint(%x): point mismatch", b)
+			}
+		}
+	})
+
+	t.Run("NonCanonical", func(t *testing.T) {
+		// y = p + 1 (the identity, with a non-canonical y-coordinate).
+		yNonCanonical := make([]byte, 32)
+		yNonCanonical[0] = 0xee
+		for i := 1; i < 31; i++ {
+			yNonCanonical[i] = 0xff
+		}
+		yNonCanonical[31] = 0x7f
+
+		// y = 1, x = -0 (the identity, with the sign bit set).
+		negativeZero := make([]byte, 32)
+		negativeZero[0] = 0x01
+		negativeZero[31] = 0x80
+
+		for _, b := range [][]byte{yNonCanonical, negativeZero} {
+			if IsCanonicalPointEncoding(b) {
+				t.Fatalf("IsCanonicalPointEncoding(%x): true", b)
+			}
+			if _, err := DecodeCanonicalPoint(b); !errors.Is(err, ErrNonCanonicalPoint) {
+				t.Fatalf("DecodeCanonicalPoint(%x): unexpected error: %v", b, err)
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		// y = 2 is not on the curve.
+		notOnCurve := make([]byte, 32)
+		notOnCurve[0] = 0x02
+
+		for _, b := range [][]byte{nil, make([]byte, 31), notOnCurve} {
+			if IsCanonicalPointEncoding(b) {
+				t.Fatalf("IsCanonicalPointEncoding(%x): true", b)
+			}
+			if _, err := DecodeCanonicalPoint(b); err == nil || errors.Is(err, ErrNonCanonicalPoint) {
+				t.Fatalf("DecodeCanonicalPoint(%x): unexpected error: %v", b, err)
+			}
+		}
+	})
+}
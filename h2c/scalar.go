@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"fmt"
+
+	"filippo.io/edwards25519"
+)
+
+// HashToScalarUniformSize is the number of uniform bytes used by
+// HashToScalar to derive each scalar.
+const HashToScalarUniformSize = 64
+
+// HashToScalar hashes the message to a scalar modulo the order of the
+// edwards25519 prime order subgroup, with the domain separation tag
+// and the provided Expander.  The output of `expand_message` is
+// HashToScalarUniformSize bytes, interpreted as a little-endian integer
+// and reduced modulo l, as done by the ristretto255 suites of RFC 9497
+// (which share the scalar field).
+func HashToScalar(expander Expander, domainSeparator, message []byte) (*edwards25519.Scalar, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var s edwards25519.Scalar
+	return hashToScalar(&s, expander, domainSeparator, message)
+}
+
+func hashToScalar(s *edwards25519.Scalar, expander Expander, domainSeparator, message []byte) (*edwards25519.Scalar, error) {
+	var uniformBytes [HashToScalarUniformSize]byte
+	defer wipeBytes(uniformBytes[:])
+
+	if err := expander.ExpandMessage(uniformBytes[:], domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+	if _, err := s.SetUniformBytes(uniformBytes[:]); err != nil {
+		panic("h2c: failed to deserialize uniform scalar: " + err.Error())
+	}
+
+	return s, nil
+}
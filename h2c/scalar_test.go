@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/hex"
+	"testing"
+)
+
+func TestHashToScalar(t *testing.T) {
+	// RFC 9497 derives keys with HashToScalar, and the ristretto255
+	// suites share the scalar field and the derivation with edwards25519,
+	// so the DeriveKeyPair test vectors (Appendix A.1.1) apply.
+	//
+	//   deriveInput = seed || I2OSP(len(info), 2) || info
+	//   skS = HashToScalar(deriveInput || I2OSP(counter, 1),
+	//                      DST = "DeriveKeyPair" || contextString)
+	seed := bytes.Repeat([]byte{0xa3}, 32)
+	info := []byte("test key")
+	msg := append([]byte{}, seed...)
+	msg = append(msg, 0x00, byte(len(info)))
+	msg = append(msg, info...)
+	msg = append(msg, 0x00) // counter
+
+	expander := NewExpanderXMD(crypto.SHA512)
+	for _, v := range []struct {
+		mode     byte
+		expected string
+	}{
+		{0x00, "5ebcea5ee37023ccb9fc2d2019f9d7737be85591ae8652ffa9ef0f4d37063b0e"},
+		{0x01, "e6f73f344b79b379f1a0dd37e07ff62e38d9f71345ce62ae3a9bc60b04ccd909"},
+		{0x02, "145c79c108538421ac164ecbe131942136d5570b16d8bf41a24d4337da981e07"},
+	} {
+		dst := append([]byte("DeriveKeyPairOPRFV1-"), v.mode)
+		dst = append(dst, []byte("-ristretto255-SHA512")...)
+
+		s, err := HashToScalar(expander, dst, msg)
+		if err != nil {
+			t.Fatalf("HashToScalar: %v", err)
+		}
+		if got := hex.EncodeToString(s.Bytes()); got != v.expected {
+			t.Fatalf("HashToScalar(mode %d): got %s, expected %s", v.mode, got, v.expected)
+		}
+	}
+}
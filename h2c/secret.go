@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"crypto"
+	"fmt"
+
+	"filippo.io/edwards25519"
+)
+
+// The `Secret` variants of the suites are intended for use when the
+// message is secret (eg: password-to-point in PAKEs), and wipe the
+// output of `expand_message` before returning, in addition to the
+// intermediate field elements and points that are always wiped.
+//
+// Note: Wiping is best-effort, as there is no way to guarantee that
+// the Go runtime has not made copies of intermediary values, and the
+// internal state of the hash function is not cleared.
+
+// Edwards25519_XMD_ELL2_RO_Secret implements a generic edwards25519 random
+// oracle suite using `expand_message_xmd`, wiping intermediaries.
+func Edwards25519_XMD_ELL2_RO_Secret(hFunc crypto.Hash, domainSeparator, message []byte) (*edwards25519.Point, error) {
+	var uniformBytes [hashToCurveSize]byte
+	defer wipeBytes(uniformBytes[:])
+
+	if err := ExpandMessageXMD(uniformBytes[:], hFunc, domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+	return hashToCurveEdwards(new(edwards25519.Point), &uniformBytes), nil
+}
+
+// Edwards25519_XMD_ELL2_NU_Secret implements a generic edwards25519
+// nonuniform suite using `expand_message_xmd`, wiping intermediaries.
+func Edwards25519_XMD_ELL2_NU_Secret(hFunc crypto.Hash, domainSeparator, message []byte) (*edwards25519.Point, error) {
+	var uniformBytes [encodeToCurveSize]byte
+	defer wipeBytes(uniformBytes[:])
+
+	if err := ExpandMessageXMD(uniformBytes[:], hFunc, domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+	return encodeToCurveEdwards(new(edwards25519.Point), &uniformBytes), nil
+}
+
+// Curve25519_XMD_ELL2_RO_Secret implements a generic curve25519 random
+// oracle suite using `expand_message_xmd`, wiping intermediaries.
+func Curve25519_XMD_ELL2_RO_Secret(hFunc crypto.Hash, domainSeparator, message []byte) (*MontgomeryPoint, error) {
+	var uniformBytes [hashToCurveSize]byte
+	defer wipeBytes(uniformBytes[:])
+
+	if err := ExpandMessageXMD(uniformBytes[:], hFunc, domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+	return hashToCurveMontgomery(new(MontgomeryPoint), &uniformBytes), nil
+}
+
+// Curve25519_XMD_ELL2_NU_Secret implements a generic curve25519
+// nonuniform suite using `expand_message_xmd`, wiping intermediaries.
+func Curve25519_XMD_ELL2_NU_Secret(hFunc crypto.Hash, domainSeparator, message []byte) (*MontgomeryPoint, error) {
+	var uniformBytes [encodeToCurveSize]byte
+	defer wipeBytes(uniformBytes[:])
+
+	if err := ExpandMessageXMD(uniformBytes[:], hFunc, domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+	return encodeToCurveMontgomery(new(MontgomeryPoint), &uniformBytes), nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// ErrSelfTest is the error returned by SelfTest when a known-answer
+// test fails.
+var ErrSelfTest = errors.New("h2c: self-test failed")
+
+// The known-answer tests are taken from RFC 9380 Appendix J and K,
+// with the points encoded as per the suite's `_Bytes` variants
+// (edwards25519), or MontgomeryPoint.MarshalUV (curve25519).
+var selfTestVectors = []struct {
+	name     string
+	dst      string
+	msg      string
+	expected string
+	fn       func(dst, msg []byte) ([]byte, error)
+}{
+	{
+		name:     "expand_message_xmd:SHA-512",
+		dst:      "QUUX-V01-CS02-with-expander-SHA512-256",
+		msg:      "abc",
+		expected: "0da749f12fbe5483eb066a5f595055679b976e93abe9be6f0f6318bce7aca8dc",
+		fn: func(dst, msg []byte) ([]byte, error) {
+			out := make([]byte, 32)
+			if err := ExpandMessageXMD(out, crypto.SHA512, dst, msg); err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+	},
+	{
+		name:     SuiteEdwards25519XMDSHA512ELL2RO,
+		dst:      "QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_",
+		msg:      "abc",
+		expected: "31558a26887f23fb8218f143e69d5f0af2e7831130bd5b432ef23883b895839a",
+		fn:       Edwards25519_XMD_SHA512_ELL2_RO_Bytes,
+	},
+	{
+		name:     SuiteEdwards25519XMDSHA512ELL2NU,
+		dst:      "QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_NU_",
+		msg:      "abc",
+		expected: "42fa27c8f5a1ae0aa38bb59d5938e5145622ba5dedd11d11736fa2f9502d7367",
+		fn:       Edwards25519_XMD_SHA512_ELL2_NU_Bytes,
+	},
+	{
+		name:     SuiteCurve25519XMDSHA512ELL2RO,
+		dst:      "QUUX-V01-CS02-with-curve25519_XMD:SHA-512_ELL2_RO_",
+		msg:      "abc",
+		expected: "6d52bc6a6b822e43de0bd75d91600a7bcc72ca0a2b69de72588fd4f2f119442bdd072d3f70f9ef6011da95d44393142dd2b37ee96387faa6f068a255f235821b",
+		fn: func(dst, msg []byte) ([]byte, error) {
+			p, err := Curve25519_XMD_SHA512_ELL2_RO_Point(dst, msg)
+			if err != nil {
+				return nil, err
+			}
+			return p.MarshalUV(), nil
+		},
+	},
+	{
+		name:     SuiteCurve25519XMDSHA512ELL2NU,
+		dst:      "QUUX-V01-CS02-with-curve25519_XMD:SHA-512_ELL2_NU_",
+		msg:      "abc",
+		expected: "26a0f950b4c925464b893bf48d571a447aa4aefc62423366a80f907d0b95227c41057f09089e96e3a6a55f59cbbdd886b3885276b66cbcdc8596c0e400bc4755",
+		fn: func(dst, msg []byte) ([]byte, error) {
+			p, err := Curve25519_XMD_SHA512_ELL2_NU_Point(dst, msg)
+			if err != nil {
+				return nil, err
+			}
+			return p.MarshalUV(), nil
+		},
+	},
+}
+
+// SelfTest runs a set of RFC 9380 known-answer tests for each of the
+// suites (and `expand_message` variants) compiled into the package,
+// returning an error wrapping ErrSelfTest on failure.  This is intended
+// to be used as a power-on self-test.
+func SelfTest() error {
+	for _, v := range selfTestVectors {
+		if err := selfTestOne(v.name, v.dst, v.msg, v.expected, v.fn); err != nil {
+			return err
+		}
+	}
+	return selfTestXOF()
+}
+
+func selfTestOne(name, dst, msg, expected string, fn func(dst, msg []byte) ([]byte, error)) error {
+	expectedBytes, err := hex.DecodeString(expected)
+	if err != nil {
+		panic("h2c: failed to decode self-test vector: " + err.Error())
+	}
+
+	b, err := fn([]byte(dst), []byte(msg))
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrSelfTest, name, err)
+	}
+	if !bytes.Equal(b, expectedBytes) {
+		return fmt.Errorf("%w: %s: output mismatch", ErrSelfTest, name)
+	}
+
+	return nil
+}
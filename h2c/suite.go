@@ -0,0 +1,405 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+// Point is a point on a Curve.  The concrete type is determined by
+// the Curve implementation.
+type Point interface{}
+
+// Curve is the curve-specific portion of a hash-to-curve suite.
+//
+// Implementations are expected to be stateless and safe for concurrent
+// use.
+type Curve interface {
+	// ID returns the curve portion of a suite ID (eg: `edwards25519`).
+	ID() string
+
+	// MapID returns the mapping portion of a suite ID (eg: `ELL2`).
+	MapID() string
+
+	// FieldElementSize returns L, the number of uniform bytes consumed
+	// by `hash_to_field` per field element.
+	FieldElementSize() int
+
+	// SecurityLevel returns k, the target security level in bits.
+	SecurityLevel() int
+
+	// MapToCurve returns `map_to_curve(hash_to_field(uniformBytes))`
+	// for a single field element, where uniformBytes is exactly
+	// FieldElementSize() bytes long.
+	MapToCurve(uniformBytes []byte) Point
+
+	// Add returns p + q.
+	Add(p, q Point) Point
+
+	// ClearCofactor returns `clear_cofactor(p)`.
+	ClearCofactor(p Point) Point
+
+	// IsIdentity returns true iff p is the identity element.
+	IsIdentity(p Point) bool
+}
+
+// Suite is a hash-to-curve suite, composed of a Curve and an Expander.
+// Suite instances are immutable, and are safe for concurrent use if
+// the Curve and Expander are.  All of the Curve and Expander
+// implementations provided by this package are safe for concurrent use.
+type Suite struct {
+	curve    Curve
+	expander Expander
+	isRO     bool
+	id       string
+}
+
+// ID returns the suite ID.
+func (s *Suite) ID() string {
+	return s.id
+}
+
+// Curve returns the suite's Curve (the target group).
+func (s *Suite) Curve() Curve {
+	return s.curve
+}
+
+// Expander returns the suite's Expander.
+func (s *Suite) Expander() Expander {
+	return s.expander
+}
+
+// IsRandomOracle returns true iff the suite is a random oracle (`_RO_`)
+// suite, as opposed to a nonuniform (`_NU_`) suite.
+func (s *Suite) IsRandomOracle() bool {
+	return s.isRO
+}
+
+// FieldElementSize returns L, the number of uniform bytes consumed by
+// `hash_to_field` per field element.
+func (s *Suite) FieldElementSize() int {
+	return s.curve.FieldElementSize()
+}
+
+// SecurityLevel returns k, the target security level in bits.
+func (s *Suite) SecurityLevel() int {
+	return s.curve.SecurityLevel()
+}
+
+// UniformSize returns the number of bytes of `expand_message` output
+// consumed by each invocation of the suite.
+func (s *Suite) UniformSize() int {
+	return s.uniformSize()
+}
+
+// Hash hashes the message to a point on the suite's curve, with the
+// provided domain separation tag.  This is `hash_to_curve` for random
+// oracle suites, and `encode_to_curve` for nonuniform suites.
+func (s *Suite) Hash(domainSeparator, message []byte) (Point, error) {
+	uniformBytes := make([]byte, s.uniformSize())
+	if err := s.expander.ExpandMessage(uniformBytes, domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+	return s.mapUniform(uniformBytes), nil
+}
+
+// HashToCurveN hashes the message to n independent points on the suite's
+// curve, with the provided domain separation tag.
+//
+// This is done with a single `hash_to_field(msg, n * m)` invocation
+// (where m is 2 for random oracle suites, and 1 for nonuniform suites),
+// with the i-th point derived from field elements `i * m` to
+// `(i + 1) * m - 1`, exactly as Hash derives a single point.  As such
+// `HashToCurveN(dst, msg, 1)` is equivalent to `Hash(dst, msg)`.
+//
+// The maximum value of n is limited by the maximum output length of the
+// suite's Expander.
+func (s *Suite) HashToCurveN(domainSeparator, message []byte, n int) ([]Point, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("h2c: invalid number of points: %d", n)
+	}
+
+	uniformSize := s.uniformSize()
+	if n > math.MaxUint16/uniformSize {
+		return nil, fmt.Errorf("%w: %d points", ErrOutputTooLong, n)
+	}
+
+	uniformBytes := make([]byte, n*uniformSize)
+	defer wipeBytes(uniformBytes)
+
+	if err := s.expander.ExpandMessage(uniformBytes, domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+
+	points := make([]Point, 0, n)
+	for i := 0; i < n; i++ {
+		points = append(points, s.mapUniform(uniformBytes[i*uniformSize:(i+1)*uniformSize]))
+	}
+
+	return points, nil
+}
+
+// HashToCurveNonIdentity hashes the message to a point on the suite's
+// curve like Suite.Hash, returning ErrIdentityPoint if the output is
+// the identity element.
+//
+// For random oracle suites this happens with negligible probability,
+// however for nonuniform suites an adversary that controls the message
+// can find inputs that map to the identity (or to small-order points
+// that become the identity after the cofactor is cleared).
+func HashToCurveNonIdentity(suite *Suite, domainSeparator, message []byte) (Point, error) {
+	p, err := suite.Hash(domainSeparator, message)
+	if err != nil {
+		return nil, err
+	}
+	if suite.curve.IsIdentity(p) {
+		return nil, ErrIdentityPoint
+	}
+	return p, nil
+}
+
+func (s *Suite) uniformSize() int {
+	if s.isRO {
+		return 2 * s.curve.FieldElementSize()
+	}
+	return s.curve.FieldElementSize()
+}
+
+func (s *Suite) mapUniform(uniformBytes []byte) Point {
+	return s.mapUniformTrace(uniformBytes, nil)
+}
+
+func (s *Suite) mapUniformTrace(uniformBytes []byte, trace *Trace) Point {
+	l := s.curve.FieldElementSize()
+
+	trace.expandMessage(uniformBytes)
+
+	Q := s.mapToCurveTrace(0, uniformBytes[:l], trace)
+	if s.isRO {
+		Q1 := s.mapToCurveTrace(1, uniformBytes[l:], trace)
+		Q = s.curve.Add(Q, Q1)
+	}
+
+	trace.clearCofactor(Q)
+
+	return s.curve.ClearCofactor(Q)
+}
+
+func (s *Suite) mapToCurveTrace(i int, uniformBytes []byte, trace *Trace) Point {
+	trace.hashToField(s.curve, i, uniformBytes)
+
+	Q := s.curve.MapToCurve(uniformBytes)
+
+	trace.mapToCurve(i, Q)
+
+	return Q
+}
+
+// PreparedSuite is a Suite bound to a domain separation tag, with the
+// message independent portion of `expand_message` (eg: `DST_prime`, and
+// the hash function parameters) precomputed.
+//
+// PreparedSuite instances are immutable, and are safe for concurrent
+// use.
+type PreparedSuite struct {
+	suite           *Suite
+	domainSeparator []byte
+	expander        preparedExpander
+}
+
+// Prepare returns a PreparedSuite for the suite and domain separation
+// tag.
+func (s *Suite) Prepare(domainSeparator []byte) (*PreparedSuite, error) {
+	pe, err := prepareExpander(s.expander, domainSeparator)
+	if err != nil {
+		return nil, fmt.Errorf("h2c: failed to prepare expander: %w", err)
+	}
+
+	return &PreparedSuite{
+		suite:           s,
+		domainSeparator: append([]byte{}, domainSeparator...),
+		expander:        pe,
+	}, nil
+}
+
+// Suite returns the underlying Suite.
+func (ps *PreparedSuite) Suite() *Suite {
+	return ps.suite
+}
+
+// DomainSeparator returns a copy of the domain separation tag.
+func (ps *PreparedSuite) DomainSeparator() []byte {
+	return append([]byte{}, ps.domainSeparator...)
+}
+
+// Hash hashes the message to a point on the suite's curve.  This is
+// equivalent to `ps.Suite().Hash(ps.DomainSeparator(), message)`.
+func (ps *PreparedSuite) Hash(message []byte) (Point, error) {
+	uniformBytes := make([]byte, ps.suite.uniformSize())
+	defer wipeBytes(uniformBytes)
+
+	if err := expandPrepared(uniformBytes, ps.expander, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+	return ps.suite.mapUniform(uniformBytes), nil
+}
+
+// NewSuite creates a new hash-to-curve suite from the provided Curve
+// and Expander.  If isRandomOracle is set, the suite will be a random
+// oracle (`_RO_`) suite, otherwise it will be a nonuniform (`_NU_`)
+// suite.
+func NewSuite(curve Curve, expander Expander, isRandomOracle bool) (*Suite, error) {
+	if curve == nil || expander == nil {
+		return nil, errors.New("h2c: nil curve or expander")
+	}
+	if curve.FieldElementSize() <= 0 {
+		return nil, fmt.Errorf("h2c: invalid field element size: %d", curve.FieldElementSize())
+	}
+
+	encVar := "NU_"
+	if isRandomOracle {
+		encVar = "RO_"
+	}
+
+	return &Suite{
+		curve:    curve,
+		expander: expander,
+		isRO:     isRandomOracle,
+		id:       curve.ID() + "_" + expander.ID() + "_" + curve.MapID() + "_" + encVar,
+	}, nil
+}
+
+// Edwards25519 is the edwards25519 Curve, with points represented as
+// `*edwards25519.Point`.
+var Edwards25519 Curve = curveEdwards25519{}
+
+type curveEdwards25519 struct{}
+
+func (curveEdwards25519) ID() string {
+	return "edwards25519"
+}
+
+func (curveEdwards25519) MapID() string {
+	return "ELL2"
+}
+
+func (curveEdwards25519) FieldElementSize() int {
+	return ell
+}
+
+func (curveEdwards25519) SecurityLevel() int {
+	return kay
+}
+
+func (curveEdwards25519) MapToCurve(uniformBytes []byte) Point {
+	return mapToCurveEdwards(new(edwards25519.Point), uniformBytes)
+}
+
+func (curveEdwards25519) hashToField(uniformBytes []byte) *field.Element {
+	return uniformToField25519(uniformBytes)
+}
+
+func (curveEdwards25519) Add(p, q Point) Point {
+	return new(edwards25519.Point).Add(p.(*edwards25519.Point), q.(*edwards25519.Point))
+}
+
+func (curveEdwards25519) ClearCofactor(p Point) Point {
+	return ClearCofactor(p.(*edwards25519.Point))
+}
+
+func (curveEdwards25519) IsIdentity(p Point) bool {
+	return p.(*edwards25519.Point).Equal(identityPoint) == 1
+}
+
+func mapToCurveEdwards(p *edwards25519.Point, uniformBytes []byte) *edwards25519.Point {
+	var fe field.Element
+	uniformToField25519Into(&fe, uniformBytes)
+	p.Set(MapToCurveEdwards25519(&fe))
+	fe.Zero()
+	return p
+}
+
+// Curve25519 is the curve25519 Curve, with points represented as
+// `*MontgomeryPoint`.
+var Curve25519 Curve = curveCurve25519{}
+
+type curveCurve25519 struct{}
+
+func (curveCurve25519) ID() string {
+	return "curve25519"
+}
+
+func (curveCurve25519) MapID() string {
+	return "ELL2"
+}
+
+func (curveCurve25519) FieldElementSize() int {
+	return ell
+}
+
+func (curveCurve25519) SecurityLevel() int {
+	return kay
+}
+
+func (curveCurve25519) MapToCurve(uniformBytes []byte) Point {
+	fe := uniformToField25519(uniformBytes)
+	p := MapToCurveCurve25519(fe)
+	fe.Zero()
+	return p
+}
+
+func (curveCurve25519) hashToField(uniformBytes []byte) *field.Element {
+	return uniformToField25519(uniformBytes)
+}
+
+func (curveCurve25519) Add(p, q Point) Point {
+	pEd := montgomeryToEdwards(p.(*MontgomeryPoint))
+	qEd := montgomeryToEdwards(q.(*MontgomeryPoint))
+	return montgomeryFromEdwards(pEd.Add(pEd, qEd))
+}
+
+func (curveCurve25519) ClearCofactor(p Point) Point {
+	return ClearCofactorCurve25519(p.(*MontgomeryPoint))
+}
+
+func (curveCurve25519) IsIdentity(p Point) bool {
+	return p.(*MontgomeryPoint).isIdentity() == 1
+}
+
+func montgomeryToEdwards(p *MontgomeryPoint) *edwards25519.Point {
+	return montgomery.ToEdwardsPoint(&p.u, &p.v)
+}
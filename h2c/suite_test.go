@@ -0,0 +1,424 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"crypto"
+	_ "crypto/sha1"
+	"errors"
+	"sync"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+func TestSuiteMetadata(t *testing.T) {
+	for _, v := range []struct {
+		id    string
+		curve Curve
+		isRO  bool
+	}{
+		{SuiteEdwards25519XMDSHA512ELL2RO, Edwards25519, true},
+		{SuiteEdwards25519XMDSHA512ELL2NU, Edwards25519, false},
+		{SuiteCurve25519XMDSHA512ELL2RO, Curve25519, true},
+		{SuiteCurve25519XMDSHA512ELL2NU, Curve25519, false},
+	} {
+		suite, err := NewSuite(v.curve, NewExpanderXMD(crypto.SHA512), v.isRO)
+		if err != nil {
+			t.Fatalf("NewSuite: %v", err)
+		}
+
+		if id := suite.ID(); id != v.id {
+			t.Fatalf("suite.ID: got '%s', expected '%s'", id, v.id)
+		}
+		if suite.Curve() != v.curve {
+			t.Fatalf("%s: suite.Curve mismatch", v.id)
+		}
+		if id := suite.Expander().ID(); id != "XMD:SHA-512" {
+			t.Fatalf("%s: suite.Expander().ID(): '%s'", v.id, id)
+		}
+		if suite.IsRandomOracle() != v.isRO {
+			t.Fatalf("%s: suite.IsRandomOracle mismatch", v.id)
+		}
+		if l := suite.FieldElementSize(); l != 48 {
+			t.Fatalf("%s: suite.FieldElementSize: %d", v.id, l)
+		}
+		if k := suite.SecurityLevel(); k != 128 {
+			t.Fatalf("%s: suite.SecurityLevel: %d", v.id, k)
+		}
+
+		expectedUniformSize := EncodeToCurveUniformSize
+		if v.isRO {
+			expectedUniformSize = HashToCurveUniformSize
+		}
+		if sz := suite.UniformSize(); sz != expectedUniformSize {
+			t.Fatalf("%s: suite.UniformSize: %d", v.id, sz)
+		}
+	}
+}
+
+// bufferedExpander hides the streaming support of the wrapped Expander.
+type bufferedExpander struct {
+	Expander
+}
+
+func testHasherEqual(t *testing.T, curve Curve, p, q Point) {
+	switch curve {
+	case Edwards25519:
+		if p.(*edwards25519.Point).Equal(q.(*edwards25519.Point)) != 1 {
+			t.Errorf("%s: point mismatch", curve.ID())
+		}
+	case Curve25519:
+		if p.(*MontgomeryPoint).Equal(q.(*MontgomeryPoint)) != 1 {
+			t.Errorf("%s: point mismatch", curve.ID())
+		}
+	}
+}
+
+func TestHasher(t *testing.T) {
+	msg := []byte("a512_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+
+	for _, dst := range [][]byte{
+		[]byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_"),
+		bytes.Repeat([]byte{'D'}, 300),
+	} {
+		for _, curve := range []Curve{Edwards25519, Curve25519} {
+			for _, isRO := range []bool{true, false} {
+				for _, expander := range []Expander{
+					NewExpanderXMD(crypto.SHA512),
+					bufferedExpander{NewExpanderXMD(crypto.SHA512)},
+				} {
+					suite, err := NewSuite(curve, expander, isRO)
+					if err != nil {
+						t.Fatalf("NewSuite: %v", err)
+					}
+
+					expected, err := suite.Hash(dst, msg)
+					if err != nil {
+						t.Fatalf("suite.Hash: %v", err)
+					}
+
+					h := suite.NewHasher(dst)
+					for i := 0; i < 2; i++ {
+						_, _ = h.Write(msg[:7])
+						_, _ = h.Write(msg[7:])
+						p, err := h.SumPoint()
+						if err != nil {
+							t.Fatalf("h.SumPoint: %v", err)
+						}
+						testHasherEqual(t, curve, p, expected)
+
+						if _, err = h.Write(msg); !errors.Is(err, ErrHasherFinalized) {
+							t.Fatalf("h.Write: unexpected error: %v", err)
+						}
+						if _, err = h.SumPoint(); !errors.Is(err, ErrHasherFinalized) {
+							t.Fatalf("h.SumPoint: unexpected error: %v", err)
+						}
+
+						h.Reset()
+					}
+				}
+			}
+		}
+	}
+
+	suite, _ := NewSuite(Edwards25519, NewExpanderXMD(crypto.SHA1), true)
+	h := suite.NewHasher([]byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-1_ELL2_RO_"))
+	if _, err := h.Write(msg); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("h.Write: unexpected error: %v", err)
+	}
+	if _, err := h.SumPoint(); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("h.SumPoint: unexpected error: %v", err)
+	}
+}
+
+func TestPreparedSuite(t *testing.T) {
+	msgs := [][]byte{
+		[]byte(""),
+		[]byte("abc"),
+		[]byte("abcdef0123456789"),
+	}
+
+	for _, dst := range [][]byte{
+		[]byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_"),
+		bytes.Repeat([]byte{'D'}, 300),
+	} {
+		for _, curve := range []Curve{Edwards25519, Curve25519} {
+			for _, expander := range []Expander{
+				NewExpanderXMD(crypto.SHA512),
+				bufferedExpander{NewExpanderXMD(crypto.SHA512)},
+			} {
+				suite, err := NewSuite(curve, expander, true)
+				if err != nil {
+					t.Fatalf("NewSuite: %v", err)
+				}
+
+				expected := make([]Point, 0, len(msgs))
+				for _, msg := range msgs {
+					p, err := suite.Hash(dst, msg)
+					if err != nil {
+						t.Fatalf("suite.Hash: %v", err)
+					}
+					expected = append(expected, p)
+				}
+
+				ps, err := suite.Prepare(dst)
+				if err != nil {
+					t.Fatalf("suite.Prepare: %v", err)
+				}
+				if ps.Suite() != suite || !bytes.Equal(ps.DomainSeparator(), dst) {
+					t.Fatalf("ps: metadata mismatch")
+				}
+
+				var wg sync.WaitGroup
+				for i := 0; i < 4; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						for j, msg := range msgs {
+							p, err := ps.Hash(msg)
+							if err != nil {
+								t.Errorf("ps.Hash: %v", err)
+								return
+							}
+							testHasherEqual(t, curve, p, expected[j])
+						}
+					}()
+				}
+				wg.Wait()
+
+				h := ps.NewHasher()
+				_, _ = h.Write(msgs[1])
+				p, err := h.SumPoint()
+				if err != nil {
+					t.Fatalf("h.SumPoint: %v", err)
+				}
+				testHasherEqual(t, curve, p, expected[1])
+			}
+		}
+	}
+
+	suite, _ := NewSuite(Edwards25519, NewExpanderXMD(crypto.SHA1), true)
+	if _, err := suite.Prepare([]byte("DST")); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("suite.Prepare: unexpected error: %v", err)
+	}
+}
+
+func TestHashToCurveN(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_")
+	msg := []byte("abc")
+
+	for _, isRO := range []bool{true, false} {
+		suite, err := NewSuite(Edwards25519, NewExpanderXMD(crypto.SHA512), isRO)
+		if err != nil {
+			t.Fatalf("NewSuite: %v", err)
+		}
+
+		t.Run(suite.ID(), func(t *testing.T) {
+			p, err := suite.Hash(dst, msg)
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+
+			points, err := suite.HashToCurveN(dst, msg, 1)
+			if err != nil {
+				t.Fatalf("HashToCurveN(1): %v", err)
+			}
+			if len(points) != 1 || points[0].(*edwards25519.Point).Equal(p.(*edwards25519.Point)) != 1 {
+				t.Fatalf("HashToCurveN(1) != Hash")
+			}
+
+			const n = 8
+			points, err = suite.HashToCurveN(dst, msg, n)
+			if err != nil {
+				t.Fatalf("HashToCurveN(%d): %v", n, err)
+			}
+			if len(points) != n {
+				t.Fatalf("HashToCurveN(%d): unexpected number of points: %d", n, len(points))
+			}
+
+			uniformBytes := make([]byte, n*suite.UniformSize())
+			if err = suite.Expander().ExpandMessage(uniformBytes, dst, msg); err != nil {
+				t.Fatalf("ExpandMessage: %v", err)
+			}
+			seen := make(map[string]bool)
+			for i, pt := range points {
+				p := pt.(*edwards25519.Point)
+				expected := suite.mapUniform(uniformBytes[i*suite.UniformSize() : (i+1)*suite.UniformSize()])
+				if p.Equal(expected.(*edwards25519.Point)) != 1 {
+					t.Fatalf("HashToCurveN: point %d mismatch", i)
+				}
+
+				k := string(p.Bytes())
+				if seen[k] {
+					t.Fatalf("HashToCurveN: point %d is a duplicate", i)
+				}
+				seen[k] = true
+			}
+
+			for _, n := range []int{0, -1, 1 << 20} {
+				if _, err = suite.HashToCurveN(dst, msg, n); err == nil {
+					t.Fatalf("HashToCurveN(%d): expected failure", n)
+				}
+			}
+		})
+	}
+}
+
+func TestHashWithTrace(t *testing.T) {
+	for _, v := range []struct {
+		file string
+		isRO bool
+	}{
+		{"testdata/edwards25519_XMD_SHA-512_ELL2_RO_.json.gz", true},
+		{"testdata/edwards25519_XMD_SHA-512_ELL2_NU_.json.gz", false},
+	} {
+		suite, err := NewSuite(Edwards25519, NewExpanderXMD(crypto.SHA512), v.isRO)
+		if err != nil {
+			t.Fatalf("NewSuite: %v", err)
+		}
+
+		testVectors := loadSuiteTestVectors(t, v.file)
+		for i, vec := range testVectors.Vectors {
+			var (
+				uniformBytes []byte
+				us           []*field.Element
+				qs           []*edwards25519.Point
+				r            *edwards25519.Point
+			)
+			trace := &Trace{
+				ExpandMessage: func(b []byte) {
+					uniformBytes = append([]byte{}, b...)
+				},
+				HashToField: func(j int, u *field.Element) {
+					if j != len(us) {
+						t.Fatalf("%s[%d]: HashToField: unexpected index: %d", v.file, i, j)
+					}
+					us = append(us, new(field.Element).Set(u))
+				},
+				MapToCurve: func(j int, Q Point) {
+					if j != len(qs) {
+						t.Fatalf("%s[%d]: MapToCurve: unexpected index: %d", v.file, i, j)
+					}
+					qs = append(qs, new(edwards25519.Point).Set(Q.(*edwards25519.Point)))
+				},
+				ClearCofactor: func(R Point) {
+					r = new(edwards25519.Point).Set(R.(*edwards25519.Point))
+				},
+			}
+
+			p, err := suite.HashWithTrace([]byte(testVectors.DST), []byte(vec.Msg), trace)
+			if err != nil {
+				t.Fatalf("%s[%d]: HashWithTrace: %v", v.file, i, err)
+			}
+			expectedP, err := vec.P.ToEdwardsPoint(t)
+			if err != nil {
+				t.Fatalf("%s[%d]: failed to deserialize P: %v", v.file, i, err)
+			}
+			if p.(*edwards25519.Point).Equal(expectedP) != 1 {
+				t.Fatalf("%s[%d]: P mismatch", v.file, i)
+			}
+
+			if len(uniformBytes) != suite.UniformSize() {
+				t.Fatalf("%s[%d]: ExpandMessage: unexpected length: %d", v.file, i, len(uniformBytes))
+			}
+
+			mapOutputs := vec.MapOutputs()
+			if len(us) != len(vec.U) || len(qs) != len(mapOutputs) {
+				t.Fatalf("%s[%d]: unexpected number of trace calls", v.file, i)
+			}
+			sum := edwards25519.NewIdentityPoint()
+			for j := range vec.U {
+				expectedU, err := FeFromBEBytes(mustUnhex(t, trimOhEcks(vec.U[j])))
+				if err != nil {
+					t.Fatalf("%s[%d]: failed to deserialize u[%d]: %v", v.file, i, j, err)
+				}
+				if us[j].Equal(expectedU) != 1 {
+					t.Fatalf("%s[%d]: u[%d] mismatch", v.file, i, j)
+				}
+
+				expectedQ, err := mapOutputs[j].ToEdwardsPoint(t)
+				if err != nil {
+					t.Fatalf("%s[%d]: failed to deserialize Q%d: %v", v.file, i, j, err)
+				}
+				if qs[j].Equal(expectedQ) != 1 {
+					t.Fatalf("%s[%d]: Q%d mismatch", v.file, i, j)
+				}
+				sum.Add(sum, qs[j])
+			}
+			if r == nil || r.Equal(sum) != 1 {
+				t.Fatalf("%s[%d]: clear_cofactor input mismatch", v.file, i)
+			}
+		}
+	}
+}
+
+func TestHashDualDST(t *testing.T) {
+	var (
+		dst0 = []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_0")
+		dst1 = []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_1")
+		msg  = []byte("abc")
+	)
+
+	suite, err := NewSuite(Edwards25519, NewExpanderXMD(crypto.SHA512), true)
+	if err != nil {
+		t.Fatalf("NewSuite: %v", err)
+	}
+
+	p, err := suite.HashDualDST(dst0, dst1, msg)
+	if err != nil {
+		t.Fatalf("HashDualDST: %v", err)
+	}
+
+	var uniformBytes [HashToCurveUniformSize]byte
+	if err = ExpandMessageXMD(uniformBytes[:ell], crypto.SHA512, dst0, msg); err != nil {
+		t.Fatalf("ExpandMessageXMD(dst0): %v", err)
+	}
+	if err = ExpandMessageXMD(uniformBytes[ell:], crypto.SHA512, dst1, msg); err != nil {
+		t.Fatalf("ExpandMessageXMD(dst1): %v", err)
+	}
+	if expected := Edwards25519_ELL2_RO_FromUniform(&uniformBytes); expected.Equal(p.(*edwards25519.Point)) != 1 {
+		t.Fatalf("HashDualDST: point mismatch")
+	}
+
+	if _, err = suite.HashDualDST(dst0, dst0, msg); err == nil {
+		t.Fatalf("HashDualDST(dst0, dst0): expected failure")
+	}
+
+	nuSuite, err := NewSuite(Edwards25519, NewExpanderXMD(crypto.SHA512), false)
+	if err != nil {
+		t.Fatalf("NewSuite: %v", err)
+	}
+	if _, err = nuSuite.HashDualDST(dst0, dst1, msg); err == nil {
+		t.Fatalf("HashDualDST(NU): expected failure")
+	}
+}
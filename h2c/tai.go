@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+
+	"filippo.io/edwards25519"
+)
+
+// ErrTAIExhausted is the error returned when the try-and-increment
+// mapping fails to find a valid point after all counter values.
+var ErrTAIExhausted = errors.New("h2c: try-and-increment counter exhausted")
+
+// Edwards25519_TAI_VarTime implements the legacy try-and-increment
+// mapping to edwards25519, as used by ECVRF-EDWARDS25519-SHA512-TAI
+// (RFC 9381 Section 5.4.1.1).  This is NOT a RFC 9380 suite.
+//
+// For each counter value ctr in [0, 255], the candidate encoding is the
+// first 32 bytes of `H(prefix || message || ctr || suffix)`, and the
+// first candidate that decodes to a point that is not the identity after
+// clearing the cofactor is returned.
+//
+// WARNING: The execution time of this function depends on the message,
+// and the number of iterations leaks information about the message
+// via timing side-channels.  It MUST NOT be used with secret messages.
+func Edwards25519_TAI_VarTime(hFunc crypto.Hash, prefix, message, suffix []byte) (*edwards25519.Point, error) {
+	if hFunc.Size() < 32 {
+		return nil, ErrInvalidHash
+	}
+
+	h := hFunc.New()
+	p := new(edwards25519.Point)
+	for ctr := 0; ctr < 256; ctr++ {
+		h.Reset()
+		_, _ = h.Write(prefix)
+		_, _ = h.Write(message)
+		_, _ = h.Write([]byte{byte(ctr)})
+		_, _ = h.Write(suffix)
+		digest := h.Sum(nil)
+
+		// string_to_point uses the RFC 8032 decoding semantics,
+		// which rejects non-canonical encodings.
+		if _, err := p.SetBytes(digest[:32]); err != nil {
+			continue
+		}
+		if !bytes.Equal(p.Bytes(), digest[:32]) {
+			continue
+		}
+		p.MultByCofactor(p)
+		if p.Equal(identityPoint) == 1 {
+			continue
+		}
+		return p, nil
+	}
+
+	return nil, ErrTAIExhausted
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"crypto"
+	"encoding/hex"
+	"testing"
+)
+
+func TestTAI(t *testing.T) {
+	// RFC 9381 Appendix B.1, Example 16 (ECVRF-EDWARDS25519-SHA512-TAI).
+	//
+	// hash_string = Hash(suite_string || one_string || PK_string ||
+	//                    alpha_string || ctr_string || zero_string)
+	pk := mustUnhex(t, "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a")
+	prefix := append([]byte{0x03, 0x01}, pk...)
+
+	p, err := Edwards25519_TAI_VarTime(crypto.SHA512, prefix, nil, []byte{0x00})
+	if err != nil {
+		t.Fatalf("Edwards25519_TAI_VarTime: %v", err)
+	}
+
+	expected := "91bbed02a99461df1ad4c6564a5f5d829d0b90cfc7903e7a5797bd658abf3318"
+	if h := hex.EncodeToString(p.Bytes()); h != expected {
+		t.Fatalf("Edwards25519_TAI_VarTime: got '%s'", h)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"fmt"
+
+	"filippo.io/edwards25519/field"
+)
+
+// Trace is a set of hooks that are called with the intermediate values
+// produced while hashing to the curve, for debugging protocols and
+// capturing test vectors.  Any of the hooks may be nil.
+//
+// The values passed to the hooks are owned by the suite, and MUST NOT
+// be modified or retained after the hook returns.  As the intermediate
+// values are secret if the message is, care should be taken when
+// tracing in production.
+type Trace struct {
+	// ExpandMessage is called with the output of `expand_message`.
+	ExpandMessage func(uniformBytes []byte)
+
+	// HashToField is called with each field element `u[i]` output by
+	// `hash_to_field`.  As the Curve interface does not expose field
+	// elements, this is only called for the curves provided by this
+	// package.
+	HashToField func(i int, u *field.Element)
+
+	// MapToCurve is called with each `Q[i] = map_to_curve(u[i])`.
+	MapToCurve func(i int, Q Point)
+
+	// ClearCofactor is called with the input to `clear_cofactor` (ie:
+	// `Q0 + Q1` for random oracle suites, and `Q` for nonuniform suites).
+	ClearCofactor func(R Point)
+}
+
+// HashWithTrace hashes the message to a point on the suite's curve like
+// Suite.Hash, calling the hooks in trace with the intermediate values.
+// If trace is nil, this is identical to Suite.Hash.
+func (s *Suite) HashWithTrace(domainSeparator, message []byte, trace *Trace) (Point, error) {
+	uniformBytes := make([]byte, s.uniformSize())
+	defer wipeBytes(uniformBytes)
+
+	if err := s.expander.ExpandMessage(uniformBytes, domainSeparator, message); err != nil {
+		return nil, fmt.Errorf("h2c: failed to expand message: %w", err)
+	}
+	return s.mapUniformTrace(uniformBytes, trace), nil
+}
+
+type fieldCurve interface {
+	hashToField(uniformBytes []byte) *field.Element
+}
+
+func (trace *Trace) expandMessage(uniformBytes []byte) {
+	if trace == nil || trace.ExpandMessage == nil {
+		return
+	}
+	trace.ExpandMessage(uniformBytes)
+}
+
+func (trace *Trace) hashToField(curve Curve, i int, uniformBytes []byte) {
+	if trace == nil || trace.HashToField == nil {
+		return
+	}
+	fc, ok := curve.(fieldCurve)
+	if !ok {
+		return
+	}
+
+	u := fc.hashToField(uniformBytes)
+	trace.HashToField(i, u)
+	u.Zero()
+}
+
+func (trace *Trace) mapToCurve(i int, Q Point) {
+	if trace == nil || trace.MapToCurve == nil {
+		return
+	}
+	trace.MapToCurve(i, Q)
+}
+
+func (trace *Trace) clearCofactor(R Point) {
+	if trace == nil || trace.ClearCofactor == nil {
+		return
+	}
+	trace.ClearCofactor(R)
+}
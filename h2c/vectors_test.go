@@ -43,8 +43,6 @@ import (
 	"strings"
 	"testing"
 
-	"golang.org/x/crypto/sha3"
-
 	"filippo.io/edwards25519"
 	"filippo.io/edwards25519/field"
 
@@ -56,13 +54,15 @@ type suiteTestDef struct {
 	file string
 	fn   func([]byte, []byte) (*edwards25519.Point, error)
 	fn2  func([]byte, []byte) (*field.Element, *field.Element, error)
+	fn3  func([]byte, []byte) (*MontgomeryPoint, error)
+	fnU  func([]byte, []byte) (*field.Element, error)
 }
 
 type expandTestDef struct {
 	n    string
 	file string
 	h    crypto.Hash
-	xof  sha3.ShakeHash
+	fn   func([]byte, []byte, []byte) error
 }
 
 func TestVectors(t *testing.T) {
@@ -82,21 +82,6 @@ func TestVectors(t *testing.T) {
 			file: "testdata/expand_message_xmd_SHA512_38.json.gz",
 			h:    crypto.SHA512,
 		},
-		{
-			n:    "SHAKE128",
-			file: "testdata/expand_message_xof_SHAKE128_36.json.gz",
-			xof:  sha3.NewShake128(),
-		},
-		{
-			n:    "SHAKE128-LongDST",
-			file: "testdata/expand_message_xof_SHAKE128_256.json.gz",
-			xof:  sha3.NewShake128(),
-		},
-		{
-			n:    "SHAKE256",
-			file: "testdata/expand_message_xof_SHAKE256_36.json.gz",
-			xof:  sha3.NewShake256(),
-		},
 	} {
 		t.Run(expandTest.n, func(t *testing.T) {
 			testExpand(t, &expandTest)
@@ -124,6 +109,74 @@ func TestVectors(t *testing.T) {
 			file: "testdata/curve25519_XMD_SHA-512_ELL2_NU_.json.gz",
 			fn2:  Curve25519_XMD_SHA512_ELL2_NU,
 		},
+		{
+			n:    "edwards25519_XMD:SHA-512_ELL2_RO_/Secret",
+			file: "testdata/edwards25519_XMD_SHA-512_ELL2_RO_.json.gz",
+			fn: func(dst, msg []byte) (*edwards25519.Point, error) {
+				return Edwards25519_XMD_ELL2_RO_Secret(crypto.SHA512, dst, msg)
+			},
+		},
+		{
+			n:    "edwards25519_XMD:SHA-512_ELL2_NU_/Secret",
+			file: "testdata/edwards25519_XMD_SHA-512_ELL2_NU_.json.gz",
+			fn: func(dst, msg []byte) (*edwards25519.Point, error) {
+				return Edwards25519_XMD_ELL2_NU_Secret(crypto.SHA512, dst, msg)
+			},
+		},
+		{
+			n:    "curve25519_XMD:SHA-512_ELL2_RO_/Point",
+			file: "testdata/curve25519_XMD_SHA-512_ELL2_RO_.json.gz",
+			fn3:  Curve25519_XMD_SHA512_ELL2_RO_Point,
+		},
+		{
+			n:    "curve25519_XMD:SHA-512_ELL2_NU_/Point",
+			file: "testdata/curve25519_XMD_SHA-512_ELL2_NU_.json.gz",
+			fn3:  Curve25519_XMD_SHA512_ELL2_NU_Point,
+		},
+		{
+			n:    "curve25519_XMD:SHA-512_ELL2_RO_/U",
+			file: "testdata/curve25519_XMD_SHA-512_ELL2_RO_.json.gz",
+			fnU:  Curve25519_XMD_SHA512_ELL2_RO_U,
+		},
+		{
+			n:    "curve25519_XMD:SHA-512_ELL2_NU_/U",
+			file: "testdata/curve25519_XMD_SHA-512_ELL2_NU_.json.gz",
+			fnU:  Curve25519_XMD_SHA512_ELL2_NU_U,
+		},
+		{
+			n:    "curve25519_XMD:SHA-512_ELL2_RO_/Suite",
+			file: "testdata/curve25519_XMD_SHA-512_ELL2_RO_.json.gz",
+			fn3:  suiteCurve25519Fn(t, true),
+		},
+		{
+			n:    "curve25519_XMD:SHA-512_ELL2_NU_/Suite",
+			file: "testdata/curve25519_XMD_SHA-512_ELL2_NU_.json.gz",
+			fn3:  suiteCurve25519Fn(t, false),
+		},
+		{
+			n:    "edwards25519_XMD:SHA-512_ELL2_RO_/Suite",
+			file: "testdata/edwards25519_XMD_SHA-512_ELL2_RO_.json.gz",
+			fn:   suiteEdwardsFn(t, true),
+		},
+		{
+			n:    "edwards25519_XMD:SHA-512_ELL2_NU_/Suite",
+			file: "testdata/edwards25519_XMD_SHA-512_ELL2_NU_.json.gz",
+			fn:   suiteEdwardsFn(t, false),
+		},
+		{
+			n:    "curve25519_XMD:SHA-512_ELL2_RO_/Secret",
+			file: "testdata/curve25519_XMD_SHA-512_ELL2_RO_.json.gz",
+			fn3: func(dst, msg []byte) (*MontgomeryPoint, error) {
+				return Curve25519_XMD_ELL2_RO_Secret(crypto.SHA512, dst, msg)
+			},
+		},
+		{
+			n:    "curve25519_XMD:SHA-512_ELL2_NU_/Secret",
+			file: "testdata/curve25519_XMD_SHA-512_ELL2_NU_.json.gz",
+			fn3: func(dst, msg []byte) (*MontgomeryPoint, error) {
+				return Curve25519_XMD_ELL2_NU_Secret(crypto.SHA512, dst, msg)
+			},
+		},
 	} {
 		t.Run(suiteTest.n, func(t *testing.T) {
 			testSuite(t, &suiteTest)
@@ -131,6 +184,52 @@ func TestVectors(t *testing.T) {
 	}
 }
 
+func suiteEdwardsFn(t *testing.T, isRO bool) func([]byte, []byte) (*edwards25519.Point, error) {
+	suite, err := NewSuite(Edwards25519, NewExpanderXMD(crypto.SHA512), isRO)
+	if err != nil {
+		t.Fatalf("NewSuite: %v", err)
+	}
+
+	expectedID := SuiteEdwards25519XMDSHA512ELL2NU
+	if isRO {
+		expectedID = SuiteEdwards25519XMDSHA512ELL2RO
+	}
+	if id := suite.ID(); id != expectedID {
+		t.Fatalf("suite.ID: unexpected ID: '%s'", id)
+	}
+
+	return func(dst, msg []byte) (*edwards25519.Point, error) {
+		p, err := suite.Hash(dst, msg)
+		if err != nil {
+			return nil, err
+		}
+		return p.(*edwards25519.Point), nil
+	}
+}
+
+func suiteCurve25519Fn(t *testing.T, isRO bool) func([]byte, []byte) (*MontgomeryPoint, error) {
+	suite, err := NewSuite(Curve25519, NewExpanderXMD(crypto.SHA512), isRO)
+	if err != nil {
+		t.Fatalf("NewSuite: %v", err)
+	}
+
+	expectedID := SuiteCurve25519XMDSHA512ELL2NU
+	if isRO {
+		expectedID = SuiteCurve25519XMDSHA512ELL2RO
+	}
+	if id := suite.ID(); id != expectedID {
+		t.Fatalf("suite.ID: unexpected ID: '%s'", id)
+	}
+
+	return func(dst, msg []byte) (*MontgomeryPoint, error) {
+		p, err := suite.Hash(dst, msg)
+		if err != nil {
+			return nil, err
+		}
+		return p.(*MontgomeryPoint), nil
+	}
+}
+
 type suiteTestVectors struct {
 	DST     string            `json:"DST"`
 	Vectors []suiteTestVector `json:"vectors"`
@@ -138,7 +237,20 @@ type suiteTestVectors struct {
 
 type suiteTestVector struct {
 	P   suiteTestPoint
-	Msg string `json:"msg"`
+	Q   *suiteTestPoint `json:"Q"`
+	Q0  *suiteTestPoint `json:"Q0"`
+	Q1  *suiteTestPoint `json:"Q1"`
+	U   []string        `json:"u"`
+	Msg string          `json:"msg"`
+}
+
+// MapOutputs returns the expected `map_to_curve` outputs (Q or Q0, Q1),
+// in the same order as U.
+func (vec *suiteTestVector) MapOutputs() []*suiteTestPoint {
+	if vec.Q != nil {
+		return []*suiteTestPoint{vec.Q}
+	}
+	return []*suiteTestPoint{vec.Q0, vec.Q1}
 }
 
 type suiteTestPoint struct {
@@ -147,23 +259,17 @@ type suiteTestPoint struct {
 }
 
 func (pt *suiteTestPoint) ToCoordinates(t *testing.T) (*field.Element, *field.Element, error) {
-	x := mustUnhex(t, trimOhEcks(pt.X))
-	y := mustUnhex(t, trimOhEcks(pt.Y))
-
 	// The IETF test vectors provide all coordinates in big-endian byte order.
-	x = reversedByteSlice(x)
-	y = reversedByteSlice(y)
-
-	// Generate a point from the test vector x and y-coordinates.
-	var feX, feY field.Element
-	if _, err := feX.SetBytes(x); err != nil {
+	feX, err := FeFromBEBytes(mustUnhex(t, trimOhEcks(pt.X)))
+	if err != nil {
 		return nil, nil, fmt.Errorf("h2c: failed to deserialize x: %w", err)
 	}
-	if _, err := feY.SetBytes(y); err != nil {
+	feY, err := FeFromBEBytes(mustUnhex(t, trimOhEcks(pt.Y)))
+	if err != nil {
 		return nil, nil, fmt.Errorf("h2c: failed to deserialize y: %w", err)
 	}
 
-	return &feX, &feY, nil
+	return feX, feY, nil
 }
 
 func (pt *suiteTestPoint) ToEdwardsPoint(t *testing.T) (*edwards25519.Point, error) {
@@ -179,8 +285,8 @@ func (pt *suiteTestPoint) ToMontgomeryPoint(t *testing.T) (*field.Element, *fiel
 	return feU, feV, err
 }
 
-func testSuite(t *testing.T, def *suiteTestDef) {
-	f, err := os.Open(def.file)
+func loadSuiteTestVectors(t *testing.T, file string) *suiteTestVectors {
+	f, err := os.Open(file)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -198,6 +304,12 @@ func testSuite(t *testing.T, def *suiteTestDef) {
 		t.Fatal(err)
 	}
 
+	return &testVectors
+}
+
+func testSuite(t *testing.T, def *suiteTestDef) {
+	testVectors := loadSuiteTestVectors(t, def.file)
+
 	for i, vec := range testVectors.Vectors {
 		t.Run(fmt.Sprintf("TestCase/%d", i), func(t *testing.T) {
 			switch {
@@ -232,6 +344,40 @@ func testSuite(t *testing.T, def *suiteTestDef) {
 				if expectedV.Equal(v) != 1 {
 					t.Fatalf("h2c: point v-cooredinate mismatch (Got: '%x')", v.Bytes())
 				}
+			case def.fn3 != nil:
+				expectedU, expectedV, err := vec.P.ToMontgomeryPoint(t)
+				if err != nil {
+					t.Fatalf("failed to deserialized result: %v", err)
+				}
+
+				p, err := def.fn3([]byte(testVectors.DST), []byte(vec.Msg))
+				if err != nil {
+					t.Fatalf("hash to curve failed: %v", err)
+				}
+
+				if expected := newMontgomeryPoint(expectedU, expectedV); expected.Equal(p) != 1 {
+					t.Fatalf("h2c: point mismatch (Got: '%x')", p.Bytes())
+				}
+				if !bytes.Equal(p.Bytes(), expectedU.Bytes()) {
+					t.Fatalf("h2c: point encoding mismatch (Got: '%x')", p.Bytes())
+				}
+				if p.U().Equal(expectedU) != 1 || p.V().Equal(expectedV) != 1 {
+					t.Fatalf("h2c: point coordinate mismatch")
+				}
+			case def.fnU != nil:
+				expectedU, _, err := vec.P.ToMontgomeryPoint(t)
+				if err != nil {
+					t.Fatalf("failed to deserialized result: %v", err)
+				}
+
+				u, err := def.fnU([]byte(testVectors.DST), []byte(vec.Msg))
+				if err != nil {
+					t.Fatalf("hash to curve failed: %v", err)
+				}
+
+				if expectedU.Equal(u) != 1 {
+					t.Fatalf("h2c: point u-cooredinate mismatch (Got: '%x')", u.Bytes())
+				}
 			default:
 				t.Fatalf("h2c: no suite function defined")
 			}
@@ -277,8 +423,8 @@ func testExpand(t *testing.T, def *expandTestDef) {
 			switch {
 			case def.h != 0:
 				err = ExpandMessageXMD(out, def.h, []byte(testVectors.DST), []byte(vec.Msg))
-			case def.xof != nil:
-				err = ExpandMessageXOF(out, def.xof, []byte(testVectors.DST), []byte(vec.Msg))
+			case def.fn != nil:
+				err = def.fn(out, []byte(testVectors.DST), []byte(vec.Msg))
 			default:
 				t.Fatalf("malformed test vector, unknown hash/XOF")
 			}
@@ -293,6 +439,83 @@ func testExpand(t *testing.T, def *expandTestDef) {
 	}
 }
 
+func TestHashToFieldMapToCurveClearCofactorVectors(t *testing.T) {
+	for _, file := range []string{
+		"testdata/edwards25519_XMD_SHA-512_ELL2_RO_.json.gz",
+		"testdata/edwards25519_XMD_SHA-512_ELL2_NU_.json.gz",
+		"testdata/curve25519_XMD_SHA-512_ELL2_RO_.json.gz",
+		"testdata/curve25519_XMD_SHA-512_ELL2_NU_.json.gz",
+	} {
+		testVectors := loadSuiteTestVectors(t, file)
+		isEdwards := strings.HasPrefix(file, "testdata/edwards25519")
+
+		for i, vec := range testVectors.Vectors {
+			qs := vec.MapOutputs()
+			if len(qs) != len(vec.U) {
+				t.Fatalf("%s[%d]: malformed test vector", file, i)
+			}
+
+			us := make([]field.Element, len(vec.U))
+			if err := HashToField(us, NewExpanderXMD(crypto.SHA512), []byte(testVectors.DST), []byte(vec.Msg)); err != nil {
+				t.Fatalf("%s[%d]: HashToField: %v", file, i, err)
+			}
+
+			var (
+				sumEd = edwards25519.NewIdentityPoint()
+				sumMp *MontgomeryPoint
+			)
+			for j, u := range vec.U {
+				fe, err := FeFromBEBytes(mustUnhex(t, trimOhEcks(u)))
+				if err != nil {
+					t.Fatalf("%s[%d]: failed to deserialize u[%d]: %v", file, i, j, err)
+				}
+				if us[j].Equal(fe) != 1 {
+					t.Fatalf("%s[%d]: u[%d] mismatch (Got: '%x')", file, i, j, us[j].Bytes())
+				}
+
+				expectedX, expectedY, err := qs[j].ToCoordinates(t)
+				if err != nil {
+					t.Fatalf("%s[%d]: failed to deserialize Q%d: %v", file, i, j, err)
+				}
+
+				if isEdwards {
+					q := MapToCurveEdwards25519(fe)
+					if q.Equal(montgomery.NewEdwardsFromXY(expectedX, expectedY)) != 1 {
+						t.Fatalf("%s[%d]: Q%d mismatch (Got: '%x')", file, i, j, q.Bytes())
+					}
+					sumEd.Add(sumEd, q)
+				} else {
+					q := MapToCurveCurve25519(fe)
+					if q.Equal(newMontgomeryPoint(expectedX, expectedY)) != 1 {
+						t.Fatalf("%s[%d]: Q%d mismatch (Got: '%x')", file, i, j, q.Bytes())
+					}
+					if sumMp == nil {
+						sumMp = q
+					} else {
+						sumMp = Curve25519.Add(sumMp, q).(*MontgomeryPoint)
+					}
+				}
+			}
+
+			expectedX, expectedY, err := vec.P.ToCoordinates(t)
+			if err != nil {
+				t.Fatalf("%s[%d]: failed to deserialize P: %v", file, i, err)
+			}
+			if isEdwards {
+				p := ClearCofactor(sumEd)
+				if p.Equal(montgomery.NewEdwardsFromXY(expectedX, expectedY)) != 1 {
+					t.Fatalf("%s[%d]: P mismatch (Got: '%x')", file, i, p.Bytes())
+				}
+			} else {
+				p := ClearCofactorCurve25519(sumMp)
+				if p.Equal(newMontgomeryPoint(expectedX, expectedY)) != 1 {
+					t.Fatalf("%s[%d]: P mismatch (Got: '%x')", file, i, p.Bytes())
+				}
+			}
+		}
+	}
+}
+
 func trimOhEcks(s string) string {
 	return strings.TrimPrefix(s, "0x")
 }
@@ -0,0 +1,91 @@
+//go:build !h2c_noxof
+// +build !h2c_noxof
+
+// Copyright (c) 2021 Oasis Labs Inc. All rights reserved.
+// Copyright (c) 2021 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package h2c
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+)
+
+func TestVectorsXOF(t *testing.T) {
+	for _, expandTest := range []expandTestDef{
+		{
+			n:    "SHAKE128",
+			file: "testdata/expand_message_xof_SHAKE128_36.json.gz",
+			fn:   xofExpandFn(sha3.NewShake128()),
+		},
+		{
+			n:    "SHAKE128-LongDST",
+			file: "testdata/expand_message_xof_SHAKE128_256.json.gz",
+			fn:   xofExpandFn(sha3.NewShake128()),
+		},
+		{
+			n:    "SHAKE256",
+			file: "testdata/expand_message_xof_SHAKE256_36.json.gz",
+			fn:   xofExpandFn(sha3.NewShake256()),
+		},
+		{
+			n:    "SHAKE128/Func",
+			file: "testdata/expand_message_xof_SHAKE128_36.json.gz",
+			fn:   xofFuncExpandFn(sha3.NewShake128),
+		},
+		{
+			n:    "SHAKE128-LongDST/Func",
+			file: "testdata/expand_message_xof_SHAKE128_256.json.gz",
+			fn:   xofFuncExpandFn(sha3.NewShake128),
+		},
+		{
+			n:    "SHAKE256/Func",
+			file: "testdata/expand_message_xof_SHAKE256_36.json.gz",
+			fn:   xofFuncExpandFn(sha3.NewShake256),
+		},
+	} {
+		t.Run(expandTest.n, func(t *testing.T) {
+			testExpand(t, &expandTest)
+		})
+	}
+}
+
+func xofExpandFn(xofFunc sha3.ShakeHash) func([]byte, []byte, []byte) error {
+	return func(out, domainSeparator, message []byte) error {
+		return ExpandMessageXOF(out, xofFunc, domainSeparator, message)
+	}
+}
+
+func xofFuncExpandFn(newXOF func() sha3.ShakeHash) func([]byte, []byte, []byte) error {
+	return func(out, domainSeparator, message []byte) error {
+		return ExpandMessageXOFFunc(out, newXOF, domainSeparator, message)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+// BatchInvert replaces each element of fes with its inverse (or 0 if it
+// is 0), with a single field inversion (Montgomery's trick).
+func BatchInvert(fes []field.Element) {
+	n := len(fes)
+	if n == 0 {
+		return
+	}
+
+	// Substitute 1 for 0 so that the running product stays invertible,
+	// and fix up the result afterwards.
+	isZero := make([]int, n)
+	for i := range fes {
+		isZero[i] = feIsZero(&fes[i])
+		fes[i].Select(ONE, &fes[i], isZero[i])
+	}
+
+	// acc[i] = fes[0] * ... * fes[i]
+	acc := make([]field.Element, n)
+	acc[0].Set(&fes[0])
+	for i := 1; i < n; i++ {
+		acc[i].Multiply(&acc[i-1], &fes[i])
+	}
+
+	var inv, tmp field.Element
+	inv.Invert(&acc[n-1])
+	for i := n - 1; i > 0; i-- {
+		// fes[i]^-1 = (fes[0] * ... * fes[i])^-1 * (fes[0] * ... * fes[i-1])
+		tmp.Multiply(&inv, &acc[i-1])
+		inv.Multiply(&inv, &fes[i])
+		fes[i].Set(&tmp)
+	}
+	fes[0].Set(&inv)
+
+	for i := range fes {
+		fes[i].Select(ZERO, &fes[i], isZero[i])
+		acc[i].Zero()
+	}
+	inv.Zero()
+	tmp.Zero()
+}
+
+// SetFromEdwardsPoints sets (us[i], vs[i]) to the Montgomery form of
+// points[i] like SetFromEdwardsPoint, with the inversions shared across
+// all of the points.  us and vs MUST be the same length as points.
+func SetFromEdwardsPoints(us, vs []field.Element, points []*edwards25519.Point) {
+	n := len(points)
+	if len(us) != n || len(vs) != n {
+		panic("montgomery: invalid output lengths")
+	}
+
+	// See SetFromEdwardsPoint, the shared denominator is (Z-Y)*X, with
+	// the exceptional cases handled by BatchInvert mapping 0 to 0.
+	invs := make([]field.Element, n)
+	for i, p := range points {
+		X, Y, Z, _ := p.ExtendedCoordinates()
+
+		us[i].Add(Z, Y)               // Z+Y
+		vs[i].Multiply(&us[i], Z)     // (Z+Y)*Z
+		us[i].Multiply(&us[i], X)     // (Z+Y)*X
+		invs[i].Subtract(Z, Y)        // Z-Y
+		invs[i].Multiply(&invs[i], X) // (Z-Y)*X
+
+		for _, fe := range []*field.Element{X, Y, Z} {
+			fe.Zero()
+		}
+	}
+
+	BatchInvert(invs)
+
+	for i := range points {
+		us[i].Multiply(&us[i], &invs[i])
+		vs[i].Multiply(&vs[i], SQRT_NEG_A_PLUS_TWO)
+		vs[i].Multiply(&vs[i], &invs[i])
+		invs[i].Zero()
+	}
+}
@@ -92,56 +92,116 @@ func feIsZero(fe *field.Element) int {
 }
 
 func FromEdwardsPoint(p *edwards25519.Point) (*field.Element, *field.Element) {
-	xExt, yExt, zExt, _ := p.ExtendedCoordinates()
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var u, v field.Element
+	SetFromEdwardsPoint(&u, &v, p)
+	return &u, &v
+}
 
-	// Convert from extended (x, y, z, t) coordiantes to x, y.
-	zInv := new(field.Element).Invert(zExt)
-	x := new(field.Element).Multiply(xExt, zInv)
-	y := new(field.Element).Multiply(yExt, zInv)
+// SetFromEdwardsPoint sets (u, v) to the Montgomery form of p, without
+// allocating.
+func SetFromEdwardsPoint(u, v *field.Element, p *edwards25519.Point) {
+	X, Y, Z, _ := p.ExtendedCoordinates()
 
 	// Per RFC 7748: (u, v) = ((1+y)/(1-y), sqrt(-486664)*u/x)
+	//
+	// With x = X/Z, y = Y/Z, this is:
+	//   u = (Z+Y)/(Z-Y)
+	//   v = sqrt(-486664) * (Z+Y)*Z / ((Z-Y)*X)
+	//
+	// Both share the denominator (Z-Y)*X, so only one inversion is
+	// required.
+
+	zPlusY := new(field.Element).Add(Z, Y)
+	zMinusY := new(field.Element).Subtract(Z, Y)
+
+	inv := new(field.Element).Multiply(zMinusY, X)
+	inv.Invert(inv)
 
-	onePlusY := new(field.Element).Add(ONE, y)
-	oneMinusY := new(field.Element).Subtract(ONE, y)
-	u := new(field.Element).Invert(oneMinusY)
-	u.Multiply(onePlusY, u)
+	u.Multiply(zPlusY, X)
+	u.Multiply(u, inv)
 
-	v := new(field.Element).Invert(x)
+	v.Multiply(zPlusY, Z)
 	v.Multiply(v, SQRT_NEG_A_PLUS_TWO)
-	v.Multiply(v, u)
+	v.Multiply(v, inv)
 
-	// If y == 1, 1/(1-y) = 0, (u, v) = (0, 0) (No adjustment needed)
-	// If x == 0, sqrt(-486664)*u/x = 0, (u, v) = (u, 0)
-	u.Select(ZERO, u, feIsZero(x))
+	// If y == 1, (Z-Y) = 0, (u, v) = (0, 0) (No adjustment needed)
+	// If x == 0, X = 0, (u, v) = (0, 0) (No adjustment needed)
 
-	return u, v
+	for _, fe := range []*field.Element{X, Y, Z, zPlusY, zMinusY, inv} {
+		fe.Zero()
+	}
+}
+
+// SetUFromEdwardsPoint sets u to the u-coordinate of the Montgomery form
+// of p, without computing the v-coordinate.
+func SetUFromEdwardsPoint(u *field.Element, p *edwards25519.Point) {
+	X, Y, Z, _ := p.ExtendedCoordinates()
+
+	// u = (1+y)/(1-y) = (Z+Y)/(Z-Y)
+	//
+	// If y == 1, (Z-Y) = 0, u = 0 (No adjustment needed)
+
+	zPlusY := new(field.Element).Add(Z, Y)
+	inv := new(field.Element).Subtract(Z, Y)
+	inv.Invert(inv)
+
+	u.Multiply(zPlusY, inv)
+
+	for _, fe := range []*field.Element{X, Y, Z, zPlusY, inv} {
+		fe.Zero()
+	}
 }
 
 func ToEdwardsPoint(u, v *field.Element) *edwards25519.Point {
-	// Per RFC 7748: (x, y) = (sqrt(-486664)*u/v, (u-1)/(u+1))
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p edwards25519.Point
+	SetEdwardsPoint(&p, u, v)
+	return &p
+}
 
-	x := new(field.Element).Invert(v)
-	x.Multiply(x, u)
-	x.Multiply(x, SQRT_NEG_A_PLUS_TWO)
+// SetEdwardsPoint sets p to the Edwards form of (u, v), without
+// allocating.
+func SetEdwardsPoint(p *edwards25519.Point, u, v *field.Element) {
+	// Per RFC 7748: (x, y) = (sqrt(-486664)*u/v, (u-1)/(u+1))
+	//
+	// This can be done without any inversions, by using the common
+	// denominator v*(u+1) as Z:
+	//   X = sqrt(-486664)*u*(u+1)
+	//   Y = (u-1)*v
+	//   Z = v*(u+1)
+	//   T = sqrt(-486664)*u*(u-1)
 
 	uMinusOne := new(field.Element).Subtract(u, ONE)
 	uPlusOne := new(field.Element).Add(u, ONE)
-	uPlusOneIsZero := feIsZero(uPlusOne)
 
-	uPlusOne.Invert(uPlusOne)
-	y := new(field.Element).Multiply(uMinusOne, uPlusOne)
+	cU := new(field.Element).Multiply(u, SQRT_NEG_A_PLUS_TWO)
+
+	X := new(field.Element).Multiply(cU, uPlusOne)
+	Y := new(field.Element).Multiply(uMinusOne, v)
+	Z := new(field.Element).Multiply(v, uPlusOne)
+	T := new(field.Element).Multiply(cU, uMinusOne)
 
 	// This mapping is undefined when t == 0 or s == -1, i.e., when the
 	// denominator of either of the above rational functions is zero.
 	// Implementations MUST detect exceptional cases and return the value
 	// (v, w) = (0, 1), which is the identity point on all twisted Edwards
 	// curves.
-	resultUndefined := feIsZero(v) | uPlusOneIsZero
-	x.Select(ZERO, x, resultUndefined)
-	y.Select(ONE, y, resultUndefined)
+	resultUndefined := feIsZero(v) | feIsZero(uPlusOne)
+	X.Select(ZERO, X, resultUndefined)
+	Y.Select(ONE, Y, resultUndefined)
+	Z.Select(ONE, Z, resultUndefined)
+	T.Select(ZERO, T, resultUndefined)
+
+	if _, err := p.SetExtendedCoordinates(X, Y, Z, T); err != nil {
+		panic("h2c: failed to create edwards point from u, v: " + err.Error())
+	}
 
-	// Convert from Edwards (x, y) to extended (x, y, z, t) coordinates.
-	return NewEdwardsFromXY(x, y)
+	for _, fe := range []*field.Element{uMinusOne, uPlusOne, cU, X, Y, Z, T} {
+		fe.Zero()
+	}
 }
 
 func NewEdwardsFromXY(x, y *field.Element) *edwards25519.Point {
@@ -153,5 +213,6 @@ func NewEdwardsFromXY(x, y *field.Element) *edwards25519.Point {
 	if err != nil {
 		panic("h2c: failed to create edwards point from x, y: " + err.Error())
 	}
+	T.Zero()
 	return p
 }
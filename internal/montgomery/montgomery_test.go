@@ -31,13 +31,93 @@
 package montgomery
 
 import (
+	"crypto/rand"
 	"testing"
 
+	"filippo.io/edwards25519"
 	"filippo.io/edwards25519/field"
 )
 
 func TestMontgomery(t *testing.T) {
 	t.Run("Elligator2Constants", testElligator2Constants)
+	t.Run("EdwardsConversion", testEdwardsConversion)
+}
+
+func testEdwardsConversion(t *testing.T) {
+	t.Run("Basepoint", func(t *testing.T) {
+		u, v := FromEdwardsPoint(edwards25519.NewGeneratorPoint())
+		if u.Equal(mustFeFromUint64(9)) != 1 {
+			t.Fatalf("invalid basepoint u-coordinate: %x", u.Bytes())
+		}
+
+		p := ToEdwardsPoint(u, v)
+		if p.Equal(edwards25519.NewGeneratorPoint()) != 1 {
+			t.Fatalf("basepoint round trip failed")
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		var b [64]byte
+		for i := 0; i < 64; i++ {
+			if _, err := rand.Read(b[:]); err != nil {
+				t.Fatalf("rand.Read: %v", err)
+			}
+			s, _ := edwards25519.NewScalar().SetUniformBytes(b[:])
+			p := new(edwards25519.Point).ScalarBaseMult(s)
+
+			u, v := FromEdwardsPoint(p)
+
+			// v^2 = u^3 + A*u^2 + u
+			lhs := new(field.Element).Square(v)
+			rhs := new(field.Element).Add(u, A)
+			rhs.Multiply(rhs, u)
+			rhs.Add(rhs, ONE)
+			rhs.Multiply(rhs, u)
+			if lhs.Equal(rhs) != 1 {
+				t.Fatalf("FromEdwardsPoint: point not on curve")
+			}
+
+			if ToEdwardsPoint(u, v).Equal(p) != 1 {
+				t.Fatalf("round trip failed")
+			}
+
+			var uOnly field.Element
+			SetUFromEdwardsPoint(&uOnly, p)
+			if uOnly.Equal(u) != 1 {
+				t.Fatalf("SetUFromEdwardsPoint: u-coordinate mismatch")
+			}
+		}
+	})
+
+	t.Run("Exceptional", func(t *testing.T) {
+		identity := edwards25519.NewIdentityPoint()
+
+		u, v := FromEdwardsPoint(identity)
+		if feIsZero(u)&feIsZero(v) != 1 {
+			t.Fatalf("FromEdwardsPoint(identity): (%x, %x)", u.Bytes(), v.Bytes())
+		}
+
+		// (0, -1) is the point of order 2.
+		negOne := new(field.Element).Negate(ONE)
+		p := NewEdwardsFromXY(ZERO, negOne)
+		u, v = FromEdwardsPoint(p)
+		if feIsZero(u)&feIsZero(v) != 1 {
+			t.Fatalf("FromEdwardsPoint((0, -1)): (%x, %x)", u.Bytes(), v.Bytes())
+		}
+
+		var uOnly field.Element
+		SetUFromEdwardsPoint(&uOnly, identity)
+		if feIsZero(&uOnly) != 1 {
+			t.Fatalf("SetUFromEdwardsPoint(identity): %x", uOnly.Bytes())
+		}
+
+		if ToEdwardsPoint(ZERO, ZERO).Equal(identity) != 1 {
+			t.Fatalf("ToEdwardsPoint(0, 0) != identity")
+		}
+		if ToEdwardsPoint(negOne, ZERO).Equal(identity) != 1 {
+			t.Fatalf("ToEdwardsPoint(-1, 0) != identity")
+		}
+	})
 }
 
 func testElligator2Constants(t *testing.T) {
@@ -77,3 +157,26 @@ func testElligator2Constants(t *testing.T) {
 		}
 	})
 }
+
+func TestBatchInvert(t *testing.T) {
+	fes := []field.Element{
+		*mustFeFromUint64(2),
+		*mustFeFromUint64(0),
+		*mustFeFromUint64(9),
+		*mustFeFromUint64(486662),
+		*mustFeFromUint64(0),
+	}
+	expected := make([]field.Element, len(fes))
+	for i := range fes {
+		expected[i].Invert(&fes[i])
+	}
+
+	BatchInvert(fes)
+	for i := range fes {
+		if fes[i].Equal(&expected[i]) != 1 {
+			t.Fatalf("BatchInvert[%d]: got %x, expected %x", i, fes[i].Bytes(), expected[i].Bytes())
+		}
+	}
+
+	BatchInvert(nil)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package scalar provides helper routines for dealing with edwards25519
+// scalars that are missing from the edwards25519 package.
+package scalar
+
+import (
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+)
+
+// One returns a new scalar set to 1.
+func One() *edwards25519.Scalar {
+	var b [32]byte
+	b[0] = 1
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(b[:])
+	if err != nil {
+		panic("scalar: failed to deserialize one: " + err.Error())
+	}
+	return s
+}
+
+// IsZero returns 1 iff s is 0, and 0 otherwise.
+func IsZero(s *edwards25519.Scalar) int {
+	return s.Equal(edwards25519.NewScalar())
+}
+
+// Random returns a uniformly random non-zero scalar, using entropy from
+// rand.
+func Random(rand io.Reader) (*edwards25519.Scalar, error) {
+	var (
+		b [64]byte
+		s edwards25519.Scalar
+	)
+	defer func() {
+		for i := range b {
+			b[i] = 0
+		}
+	}()
+
+	for {
+		if _, err := io.ReadFull(rand, b[:]); err != nil {
+			return nil, fmt.Errorf("scalar: failed to read entropy: %w", err)
+		}
+		if _, err := s.SetUniformBytes(b[:]); err != nil {
+			panic("scalar: failed to deserialize uniform scalar: " + err.Error())
+		}
+		if IsZero(&s) == 0 {
+			return &s, nil
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package scalar
+
+import (
+	"bytes"
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+func TestScalar(t *testing.T) {
+	t.Run("IsZero", func(t *testing.T) {
+		if IsZero(edwards25519.NewScalar()) != 1 {
+			t.Fatalf("IsZero(0) != 1")
+		}
+		if IsZero(One()) != 0 {
+			t.Fatalf("IsZero(1) != 0")
+		}
+	})
+	t.Run("Random", func(t *testing.T) {
+		// An all zero entropy source produces 0, which is rejected,
+		// and the subsequent non-zero entropy is used.
+		entropy := make([]byte, 128)
+		entropy[64] = 1
+		s, err := Random(bytes.NewReader(entropy))
+		if err != nil {
+			t.Fatalf("Random: %v", err)
+		}
+		if s.Equal(One()) != 1 {
+			t.Fatalf("Random: unexpected scalar")
+		}
+
+		if _, err = Random(bytes.NewReader(make([]byte, 64))); err == nil {
+			t.Fatalf("Random(zeros): succeeded")
+		}
+	})
+}
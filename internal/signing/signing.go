@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package signing provides Ed25519 signing with an arbitrary expanded
+// secret key, for schemes that derive keys (eg: by blinding) in ways
+// that can not be represented as an RFC 8032 private key seed.
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+
+	"filippo.io/edwards25519"
+)
+
+// PrefixSize is the size of the nonce prefix of an expanded secret key
+// in bytes.
+const PrefixSize = 32
+
+// Sign signs the message with the expanded secret key (s, prefix), and
+// the corresponding public key `s * B`, as per RFC 8032 Section 5.1.6.
+func Sign(s *edwards25519.Scalar, prefix, publicKey, message []byte) []byte {
+	if len(prefix) != PrefixSize {
+		panic("signing: bad prefix length")
+	}
+	if len(publicKey) != ed25519.PublicKeySize {
+		panic("signing: bad public key length")
+	}
+
+	var digest [64]byte
+	h := sha512.New()
+	_, _ = h.Write(prefix)
+	_, _ = h.Write(message)
+	h.Sum(digest[:0])
+	r, err := edwards25519.NewScalar().SetUniformBytes(digest[:])
+	if err != nil {
+		panic("signing: failed to deserialize r scalar: " + err.Error())
+	}
+	R := edwards25519.NewIdentityPoint().ScalarBaseMult(r).Bytes()
+
+	h.Reset()
+	_, _ = h.Write(R)
+	_, _ = h.Write(publicKey)
+	_, _ = h.Write(message)
+	h.Sum(digest[:0])
+	k, err := edwards25519.NewScalar().SetUniformBytes(digest[:])
+	if err != nil {
+		panic("signing: failed to deserialize k scalar: " + err.Error())
+	}
+
+	S := k.MultiplyAdd(k, s, r)
+
+	sig := make([]byte, 0, ed25519.SignatureSize)
+	sig = append(sig, R...)
+	sig = append(sig, S.Bytes()...)
+
+	return sig
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package keyblinding
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+func TestKeyBlinding(t *testing.T) {
+	pk, sk, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey: %v", err)
+	}
+	bk, err := GenerateBlindKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateBlindKey: %v", err)
+	}
+	ctx := []byte("keyblinding test context")
+	msg := []byte("test message")
+
+	blindedPk, err := BlindPublicKey(pk, bk, ctx)
+	if err != nil {
+		t.Fatalf("BlindPublicKey: %v", err)
+	}
+	if bytes.Equal(blindedPk, pk) {
+		t.Fatalf("BlindPublicKey: blinded key == public key")
+	}
+
+	t.Run("SignVerify", func(t *testing.T) {
+		sig, err := BlindKeySign(sk, bk, ctx, msg)
+		if err != nil {
+			t.Fatalf("BlindKeySign: %v", err)
+		}
+		if !Verify(blindedPk, msg, sig) {
+			t.Fatalf("Verify: failed")
+		}
+		if Verify(pk, msg, sig) {
+			t.Fatalf("Verify: succeeded with unblinded public key")
+		}
+		if Verify(blindedPk, []byte("other message"), sig) {
+			t.Fatalf("Verify: succeeded with wrong message")
+		}
+
+		// Signatures are deterministic, and differ from those made
+		// with the unblinded key.
+		sig2, err := BlindKeySign(sk, bk, ctx, msg)
+		if err != nil {
+			t.Fatalf("BlindKeySign: %v", err)
+		}
+		if !bytes.Equal(sig, sig2) {
+			t.Fatalf("BlindKeySign: non-deterministic signature")
+		}
+		if bytes.Equal(sig[:32], ed25519.Sign(sk, msg)[:32]) {
+			t.Fatalf("BlindKeySign: nonce reused from unblinded key")
+		}
+	})
+	t.Run("Unblind", func(t *testing.T) {
+		unblindedPk, err := UnblindPublicKey(blindedPk, bk, ctx)
+		if err != nil {
+			t.Fatalf("UnblindPublicKey: %v", err)
+		}
+		if !bytes.Equal(unblindedPk, pk) {
+			t.Fatalf("UnblindPublicKey(BlindPublicKey(pk)) != pk")
+		}
+	})
+	t.Run("Context", func(t *testing.T) {
+		otherPk, err := BlindPublicKey(pk, bk, []byte("other context"))
+		if err != nil {
+			t.Fatalf("BlindPublicKey: %v", err)
+		}
+		if bytes.Equal(otherPk, blindedPk) {
+			t.Fatalf("BlindPublicKey: context does not affect the blinded key")
+		}
+
+		sig, err := BlindKeySign(sk, bk, []byte("other context"), msg)
+		if err != nil {
+			t.Fatalf("BlindKeySign: %v", err)
+		}
+		if Verify(blindedPk, msg, sig) {
+			t.Fatalf("Verify: succeeded with wrong context")
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := BlindPublicKey(pk, bk[1:], ctx); !errors.Is(err, ErrInvalidBlindKey) {
+			t.Fatalf("BlindPublicKey(short bk): unexpected error: %v", err)
+		}
+		if _, err := BlindKeySign(sk, bk[1:], ctx, msg); !errors.Is(err, ErrInvalidBlindKey) {
+			t.Fatalf("BlindKeySign(short bk): unexpected error: %v", err)
+		}
+
+		lowOrder, _ := hex.DecodeString("26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05")
+		A, err := edwards25519.NewIdentityPoint().SetBytes(pk)
+		if err != nil {
+			t.Fatalf("SetBytes(pk): %v", err)
+		}
+		T, err := edwards25519.NewIdentityPoint().SetBytes(lowOrder)
+		if err != nil {
+			t.Fatalf("SetBytes(lowOrder): %v", err)
+		}
+
+		for _, v := range []struct {
+			n  string
+			pk []byte
+		}{
+			{"Identity", edwards25519.NewIdentityPoint().Bytes()},
+			{"LowOrder", lowOrder},
+			{"Mixed", A.Add(A, T).Bytes()},
+			{"Short", pk[1:]},
+		} {
+			if _, err := BlindPublicKey(v.pk, bk, ctx); !errors.Is(err, ErrInvalidPublicKey) {
+				t.Fatalf("BlindPublicKey(%s): unexpected error: %v", v.n, err)
+			}
+			if _, err := UnblindPublicKey(v.pk, bk, ctx); !errors.Is(err, ErrInvalidPublicKey) {
+				t.Fatalf("UnblindPublicKey(%s): unexpected error: %v", v.n, err)
+			}
+		}
+	})
+}
+
+func TestDraftVectors(t *testing.T) {
+	// From the Ed25519 test vectors of draft-irtf-cfrg-signature-key-blinding
+	// (empty context).
+	mustUnhex := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatalf("hex.DecodeString: %v", err)
+		}
+		return b
+	}
+
+	var (
+		skS = mustUnhex("875532ab039b0a154161c284e19c74afa28d5bf5454e99284bbcffaa71eebf45")
+		pkS = mustUnhex("3b5983605b277cd44918410eb246bb52d83adfc806ccaa91a60b5b2011bc5973")
+		bkS = mustUnhex("c461e8595f0ac41d374f878613206704978115a226f60470ffd566e9e6ae73bf")
+		pkB = mustUnhex("e52bbb204e72a816854ac82c7e244e13a8fcc3217cfdeb90c8a5a927e741a20f")
+		msg = mustUnhex("68656c6c6f20776f726c64")
+	)
+
+	sk := ed25519.NewKeyFromSeed(skS)
+	if pk := sk.Public().(ed25519.PublicKey); !bytes.Equal(pk, pkS) {
+		t.Fatalf("ed25519.NewKeyFromSeed: got public key %x", pk)
+	}
+
+	blindedPk, err := BlindPublicKey(pkS, bkS, nil)
+	if err != nil {
+		t.Fatalf("BlindPublicKey: %v", err)
+	}
+	if !bytes.Equal(blindedPk, pkB) {
+		t.Fatalf("BlindPublicKey: got %x", blindedPk)
+	}
+
+	unblindedPk, err := UnblindPublicKey(pkB, bkS, nil)
+	if err != nil {
+		t.Fatalf("UnblindPublicKey: %v", err)
+	}
+	if !bytes.Equal(unblindedPk, pkS) {
+		t.Fatalf("UnblindPublicKey: got %x", unblindedPk)
+	}
+
+	sig, err := BlindKeySign(sk, bkS, nil, msg)
+	if err != nil {
+		t.Fatalf("BlindKeySign: %v", err)
+	}
+	if !Verify(pkB, msg, sig) {
+		t.Fatalf("Verify: signature does not verify under the draft blinded key")
+	}
+}
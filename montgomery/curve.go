@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"filippo.io/edwards25519/field"
+
+	imontgomery "gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+// smallOrderUs are the canonical u-coordinates of the points of small
+// order (dividing 8) on curve25519 and its quadratic twist.
+var smallOrderUs = []*field.Element{
+	// 0 (order 2, and the identity under the X25519 convention)
+	imontgomery.ZERO,
+	// 1 (order 4)
+	imontgomery.ONE,
+	// p - 1 (order 4 on the twist)
+	mustFeFromBytes([]byte{
+		0xec, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f,
+	}),
+	// 325606250916557431795983626356110631294008115727848805560023387167927233504 (order 8)
+	mustFeFromBytes([]byte{
+		0xe0, 0xeb, 0x7a, 0x7c, 0x3b, 0x41, 0xb8, 0xae,
+		0x16, 0x56, 0xe3, 0xfa, 0xf1, 0x9f, 0xc4, 0x6a,
+		0xda, 0x09, 0x8d, 0xeb, 0x9c, 0x32, 0xb1, 0xfd,
+		0x86, 0x62, 0x05, 0x16, 0x5f, 0x49, 0xb8, 0x00,
+	}),
+	// 39382357235489614581723060781553021112529911719440698176882885853963445705823 (order 8)
+	mustFeFromBytes([]byte{
+		0x5f, 0x9c, 0x95, 0xbc, 0xa3, 0x50, 0x8c, 0x24,
+		0xb1, 0xd0, 0xb1, 0x55, 0x9c, 0x83, 0xef, 0x5b,
+		0x04, 0x44, 0x5c, 0xc4, 0x58, 0x1c, 0x8e, 0x86,
+		0xd8, 0x22, 0x4e, 0xdd, 0xd0, 0x9f, 0x11, 0x57,
+	}),
+}
+
+// IsSmallOrder returns true iff u is the u-coordinate of a point of
+// small order (dividing 8) on curve25519 or its quadratic twist, in
+// constant time.  X25519 with any (clamped) scalar will output 0 for
+// such points, so protocols that require contributory behavior should
+// reject them.
+func IsSmallOrder(u *field.Element) bool {
+	ret := 0
+	for _, fe := range smallOrderUs {
+		ret |= u.Equal(fe)
+	}
+	return ret == 1
+}
+
+// IsOnCurve returns true iff (u, v) satisfies the curve25519 equation
+// `v^2 = u^3 + A*u^2 + u`, in constant time.
+func IsOnCurve(u, v *field.Element) bool {
+	return isOnCurve(u, v) == 1
+}
+
+func isOnCurve(u, v *field.Element) int {
+	var lhs, rhs field.Element
+	lhs.Square(v)
+	curveEquation(&rhs, u)
+	return lhs.Equal(&rhs)
+}
+
+// IsOnTwist returns true iff u is the u-coordinate of a point on the
+// quadratic twist of curve25519, in constant time.  Every u-coordinate
+// is on exactly one of the curve or the twist, with the exception of
+// u = 0 (the point of order 2), which is on both.
+func IsOnTwist(u *field.Element) bool {
+	var gu, tmp field.Element
+	curveEquation(&gu, u)
+	_, wasSquare := tmp.SqrtRatio(&gu, imontgomery.ONE)
+	return (1^wasSquare)|gu.Equal(imontgomery.ZERO) == 1
+}
+
+// RecoverV returns the v-coordinate of the point on curve25519 with the
+// u-coordinate u, and the sign (0 for non-negative, 1 for negative, per
+// field.Element.IsNegative) sign.
+//
+// ErrNotOnCurve is returned if u is not the u-coordinate of a point on
+// curve25519 (ie: it is on the twist), or if v = 0 and sign is 1.
+func RecoverV(u *field.Element, sign int) (*field.Element, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var v field.Element
+	return recoverV(&v, u, sign)
+}
+
+func recoverV(v, u *field.Element, sign int) (*field.Element, error) {
+	if sign != 0 && sign != 1 {
+		panic("montgomery: invalid sign")
+	}
+
+	var gu field.Element
+	curveEquation(&gu, u)
+
+	// SqrtRatio returns the non-negative square root.
+	_, wasSquare := v.SqrtRatio(&gu, imontgomery.ONE)
+	if wasSquare != 1 {
+		return nil, ErrNotOnCurve
+	}
+	if sign == 1 && v.Equal(imontgomery.ZERO) == 1 {
+		return nil, ErrNotOnCurve
+	}
+	v.Select(new(field.Element).Negate(v), v, sign)
+
+	return v, nil
+}
+
+// curveEquation sets out to `u^3 + A*u^2 + u`, the right hand side of
+// the curve25519 equation, and returns out.
+func curveEquation(out, u *field.Element) *field.Element {
+	// u^3 + A*u^2 + u = ((u + A) * u + 1) * u
+	var tmp field.Element
+	tmp.Add(u, imontgomery.A)
+	tmp.Multiply(&tmp, u)
+	tmp.Add(&tmp, imontgomery.ONE)
+	return out.Multiply(&tmp, u)
+}
+
+func mustFeFromBytes(b []byte) *field.Element {
+	fe, err := new(field.Element).SetBytes(b)
+	if err != nil {
+		panic("montgomery: failed to deserialize constant: " + err.Error())
+	}
+	return fe
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"crypto/subtle"
+
+	"filippo.io/edwards25519/field"
+)
+
+// The golang.org/x/crypto/curve25519 package represents u-coordinates
+// as little-endian `[32]byte` (or `[]byte`) values, and like X25519,
+// accepts non-canonical values (>= p, or with the most significant bit
+// set) by ignoring the most significant bit and reducing modulo p.
+
+// UToArray returns the canonical encoding of the u-coordinate u, as a
+// `[32]byte` suitable for use with golang.org/x/crypto/curve25519.
+func UToArray(u *field.Element) [X25519PublicKeySize]byte {
+	var b [X25519PublicKeySize]byte
+	copy(b[:], u.Bytes())
+	return b
+}
+
+// UFromArray returns the u-coordinate encoded in b, which may be
+// non-canonical, decoded exactly like X25519 does.
+func UFromArray(b *[X25519PublicKeySize]byte) *field.Element {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var u field.Element
+	return uFromArray(&u, b)
+}
+
+func uFromArray(u *field.Element, b *[X25519PublicKeySize]byte) *field.Element {
+	// field.Element.SetBytes ignores the most significant bit and
+	// reduces values >= p, and only fails if the length is incorrect.
+	if _, err := u.SetBytes(b[:]); err != nil {
+		panic("montgomery: failed to decode u-coordinate: " + err.Error())
+	}
+	return u
+}
+
+// CanonicalizeU rewrites the u-coordinate encoded in b to the canonical
+// encoding of the same value (as interpreted by X25519), and returns
+// true iff b already was canonical.
+func CanonicalizeU(b *[X25519PublicKeySize]byte) bool {
+	var u field.Element
+	uFromArray(&u, b)
+
+	canonical := UToArray(&u)
+	wasCanonical := subtle.ConstantTimeCompare(b[:], canonical[:])
+	*b = canonical
+
+	return wasCanonical == 1
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestCurve25519Interop(t *testing.T) {
+	var sk [ScalarSize]byte
+	for i := 0; i < 32; i++ {
+		p, _ := newRandomPoint(t)
+		if _, err := rand.Read(sk[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+
+		b := UToArray(&p.u)
+		if !bytes.Equal(b[:], p.Bytes()) {
+			t.Fatalf("UToArray(u) != u.Bytes()")
+		}
+		if UFromArray(&b).Equal(&p.u) != 1 {
+			t.Fatalf("UFromArray(UToArray(u)) != u")
+		}
+		if !CanonicalizeU(&b) {
+			t.Fatalf("CanonicalizeU(canonical) = false")
+		}
+
+		// Setting the most significant bit is ignored by X25519.
+		nonCanonical := b
+		nonCanonical[31] |= 0x80
+		if UFromArray(&nonCanonical).Equal(&p.u) != 1 {
+			t.Fatalf("UFromArray(u | 2^255) != u")
+		}
+
+		expected, err := curve25519.X25519(sk[:], nonCanonical[:])
+		if err != nil {
+			t.Fatalf("X25519: %v", err)
+		}
+		if CanonicalizeU(&nonCanonical) {
+			t.Fatalf("CanonicalizeU(nonCanonical) = true")
+		}
+		if nonCanonical != b {
+			t.Fatalf("CanonicalizeU(u | 2^255) != u")
+		}
+		out, err := curve25519.X25519(sk[:], nonCanonical[:])
+		if err != nil {
+			t.Fatalf("X25519: %v", err)
+		}
+		if !bytes.Equal(out, expected) {
+			t.Fatalf("X25519(CanonicalizeU(u)) != X25519(u)")
+		}
+	}
+
+	// p + 1 is reduced to 1.
+	pPlusOne := [X25519PublicKeySize]byte{0xee}
+	for i := 1; i < 31; i++ {
+		pPlusOne[i] = 0xff
+	}
+	pPlusOne[31] = 0x7f
+	if CanonicalizeU(&pPlusOne) {
+		t.Fatalf("CanonicalizeU(p + 1) = true")
+	}
+	if pPlusOne != [X25519PublicKeySize]byte{1} {
+		t.Fatalf("CanonicalizeU(p + 1) = %x", pPlusOne)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+	"golang.org/x/crypto/curve25519"
+
+	imontgomery "gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+func TestRecoverV(t *testing.T) {
+	t.Run("RandomPoints", func(t *testing.T) {
+		var b [64]byte
+		for i := 0; i < 64; i++ {
+			if _, err := rand.Read(b[:]); err != nil {
+				t.Fatalf("rand.Read: %v", err)
+			}
+			s, _ := new(edwards25519.Scalar).SetUniformBytes(b[:])
+			p := new(edwards25519.Point).ScalarBaseMult(s)
+			u, v := FromEdwardsPoint(p)
+
+			sign := v.IsNegative()
+			v2, err := RecoverV(u, sign)
+			if err != nil {
+				t.Fatalf("RecoverV: %v", err)
+			}
+			if v2.Equal(v) != 1 {
+				t.Fatalf("RecoverV(u, sign(v)) != v")
+			}
+
+			negV, err := RecoverV(u, sign^1)
+			if err != nil {
+				t.Fatalf("RecoverV(u, !sign): %v", err)
+			}
+			if negV.Equal(new(field.Element).Negate(v)) != 1 {
+				t.Fatalf("RecoverV(u, !sign(v)) != -v")
+			}
+		}
+	})
+	t.Run("OrderTwo", func(t *testing.T) {
+		var u field.Element
+		v, err := RecoverV(&u, 0)
+		if err != nil {
+			t.Fatalf("RecoverV(0, 0): %v", err)
+		}
+		if v.Equal(new(field.Element)) != 1 {
+			t.Fatalf("RecoverV(0, 0) != 0")
+		}
+		if _, err = RecoverV(&u, 1); err != ErrNotOnCurve {
+			t.Fatalf("RecoverV(0, 1): unexpected error: %v", err)
+		}
+	})
+	t.Run("Twist", func(t *testing.T) {
+		// u = 2 is on the twist.
+		var u field.Element
+		mustSetUint64(&u, 2)
+		if _, err := RecoverV(&u, 0); err != ErrNotOnCurve {
+			t.Fatalf("RecoverV(twist): unexpected error: %v", err)
+		}
+	})
+}
+
+func TestIsSmallOrder(t *testing.T) {
+	t.Run("Blacklist", func(t *testing.T) {
+		var sk [ScalarSize]byte
+		for _, u := range smallOrderUs {
+			if !IsSmallOrder(u) {
+				t.Fatalf("IsSmallOrder(%x) = false", u.Bytes())
+			}
+
+			// X25519 should output 0 for every small order point.
+			if _, err := rand.Read(sk[:]); err != nil {
+				t.Fatalf("rand.Read: %v", err)
+			}
+			if _, err := curve25519.X25519(sk[:], u.Bytes()); err == nil {
+				t.Fatalf("X25519(sk, %x) did not fail", u.Bytes())
+			}
+		}
+	})
+	t.Run("Torsion", func(t *testing.T) {
+		torsion := edwards25519.NewIdentityPoint()
+		for i := 0; i < 8; i++ {
+			u, _ := FromEdwardsPoint(torsion)
+			if !IsSmallOrder(u) {
+				t.Fatalf("IsSmallOrder(u(%d * T8)) = false", i)
+			}
+			torsion.Add(torsion, testLowOrderPoint)
+		}
+	})
+	t.Run("RandomPoints", func(t *testing.T) {
+		for i := 0; i < 32; i++ {
+			p, _ := newRandomPoint(t)
+			if IsSmallOrder(&p.u) {
+				t.Fatalf("IsSmallOrder(random) = true")
+			}
+		}
+	})
+}
+
+func TestIsOnCurve(t *testing.T) {
+	for i := 0; i < 32; i++ {
+		p, _ := newRandomPoint(t)
+		if !IsOnCurve(&p.u, &p.v) {
+			t.Fatalf("IsOnCurve(random) = false")
+		}
+		if !IsOnCurve(&p.u, new(field.Element).Negate(&p.v)) {
+			t.Fatalf("IsOnCurve(random, -v) = false")
+		}
+
+		var q Point
+		if _, err := q.SetUV(&p.u, &p.v); err != nil {
+			t.Fatalf("SetUV(random): %v", err)
+		}
+		requirePointsEqual(t, "SetUV", &q, p)
+
+		badV := new(field.Element).Add(&p.v, imontgomery.ONE)
+		if IsOnCurve(&p.u, badV) {
+			t.Fatalf("IsOnCurve(u, v + 1) = true")
+		}
+		if _, err := q.SetUV(&p.u, badV); err != ErrNotOnCurve {
+			t.Fatalf("SetUV(u, v + 1): unexpected error: %v", err)
+		}
+		requirePointsEqual(t, "SetUV(invalid)", &q, p)
+	}
+
+	// (0, 0) is the point of order 2.
+	var zero field.Element
+	if !IsOnCurve(&zero, &zero) {
+		t.Fatalf("IsOnCurve(0, 0) = false")
+	}
+}
+
+func TestIsOnTwist(t *testing.T) {
+	for i := 0; i < 32; i++ {
+		p, _ := newRandomPoint(t)
+		if IsOnTwist(&p.u) {
+			t.Fatalf("IsOnTwist(random curve point) = true")
+		}
+	}
+
+	var u field.Element
+	if !IsOnTwist(&u) {
+		t.Fatalf("IsOnTwist(0) = false")
+	}
+	for _, x := range []uint64{2, 3} {
+		mustSetUint64(&u, x)
+		if !IsOnTwist(&u) {
+			t.Fatalf("IsOnTwist(%d) = false", x)
+		}
+		if _, err := RecoverV(&u, 0); err != ErrNotOnCurve {
+			t.Fatalf("RecoverV(%d): unexpected error: %v", x, err)
+		}
+	}
+
+	// The curve and the twist partition the u-coordinates (except 0).
+	var b [32]byte
+	for i := 0; i < 64; i++ {
+		if _, err := rand.Read(b[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		if _, err := u.SetBytes(b[:]); err != nil {
+			t.Fatalf("SetBytes: %v", err)
+		}
+		_, err := RecoverV(&u, 0)
+		if onCurve := err == nil; onCurve == IsOnTwist(&u) {
+			t.Fatalf("IsOnTwist(%x) = %v, RecoverV err = %v", b, !onCurve, err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+//go:build go1.20
+// +build go1.20
+
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"crypto/ecdh"
+	"errors"
+
+	"filippo.io/edwards25519/field"
+)
+
+var errNotX25519 = errors.New("montgomery: key is not a X25519 key")
+
+// ECDHPublicKey returns the u-coordinate u as a X25519
+// crypto/ecdh.PublicKey.
+func ECDHPublicKey(u *field.Element) (*ecdh.PublicKey, error) {
+	return ecdh.X25519().NewPublicKey(u.Bytes())
+}
+
+// FromECDHPublicKey returns the u-coordinate of the X25519
+// crypto/ecdh.PublicKey pk.
+func FromECDHPublicKey(pk *ecdh.PublicKey) (*field.Element, error) {
+	if pk.Curve() != ecdh.X25519() {
+		return nil, errNotX25519
+	}
+	return new(field.Element).SetBytes(pk.Bytes())
+}
+
+// ScalarMultECDH returns the u-coordinate of `sk * P`, where P is the
+// point with the u-coordinate u, and sk is the scalar of the X25519
+// crypto/ecdh.PrivateKey sk (see ScalarMult).  Unlike
+// ecdh.PrivateKey.ECDH, this does not reject low order points.
+func ScalarMultECDH(sk *ecdh.PrivateKey, u *field.Element) (*field.Element, error) {
+	if sk.Curve() != ecdh.X25519() {
+		return nil, errNotX25519
+	}
+
+	scalar := sk.Bytes()
+	defer func() {
+		for i := range scalar {
+			scalar[i] = 0
+		}
+	}()
+
+	return ScalarMult(scalar, u), nil
+}
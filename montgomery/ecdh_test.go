@@ -0,0 +1,102 @@
+//go:build go1.20
+// +build go1.20
+
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+func TestECDH(t *testing.T) {
+	sk, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	peerSk, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	u, err := FromECDHPublicKey(peerSk.PublicKey())
+	if err != nil {
+		t.Fatalf("FromECDHPublicKey: %v", err)
+	}
+	if !bytes.Equal(u.Bytes(), peerSk.PublicKey().Bytes()) {
+		t.Fatalf("FromECDHPublicKey: encoding mismatch")
+	}
+
+	pk, err := ECDHPublicKey(u)
+	if err != nil {
+		t.Fatalf("ECDHPublicKey: %v", err)
+	}
+	if !pk.Equal(peerSk.PublicKey()) {
+		t.Fatalf("ECDHPublicKey(FromECDHPublicKey(pk)) != pk")
+	}
+
+	shared, err := ScalarMultECDH(sk, u)
+	if err != nil {
+		t.Fatalf("ScalarMultECDH: %v", err)
+	}
+	expected, err := sk.ECDH(pk)
+	if err != nil {
+		t.Fatalf("ECDH: %v", err)
+	}
+	if !bytes.Equal(shared.Bytes(), expected) {
+		t.Fatalf("ScalarMultECDH: shared secret mismatch")
+	}
+
+	// A converted Edwards point can be used directly.
+	u, _ = FromEdwardsPoint(edwards25519.NewGeneratorPoint())
+	pkU, err := ScalarMultECDH(sk, u)
+	if err != nil {
+		t.Fatalf("ScalarMultECDH(basepoint): %v", err)
+	}
+	if !bytes.Equal(pkU.Bytes(), sk.PublicKey().Bytes()) {
+		t.Fatalf("ScalarMultECDH(basepoint) != public key")
+	}
+
+	p256Sk, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(P256): %v", err)
+	}
+	if _, err = FromECDHPublicKey(p256Sk.PublicKey()); err == nil {
+		t.Fatalf("FromECDHPublicKey(P256): expected failure")
+	}
+	if _, err = ScalarMultECDH(p256Sk, u); err == nil {
+		t.Fatalf("ScalarMultECDH(P256): expected failure")
+	}
+}
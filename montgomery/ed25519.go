@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"errors"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	imontgomery "gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+// X25519PublicKeySize is the size of a X25519 public key in bytes.
+const X25519PublicKeySize = 32
+
+var (
+	// ErrInvalidEd25519PublicKey is the error returned when an Ed25519
+	// public key is malformed or is not a canonical point encoding.
+	ErrInvalidEd25519PublicKey = errors.New("montgomery: invalid Ed25519 public key")
+
+	// ErrInvalidEd25519Seed is the error returned when an Ed25519 seed
+	// is malformed.
+	ErrInvalidEd25519Seed = errors.New("montgomery: invalid Ed25519 seed")
+
+	// ErrInvalidX25519PublicKey is the error returned when a X25519
+	// public key is malformed or is not a canonical u-coordinate.
+	ErrInvalidX25519PublicKey = errors.New("montgomery: invalid X25519 public key")
+)
+
+// EdPublicToXPublic converts the Ed25519 public key edPublic to the
+// corresponding X25519 public key, via the birational map
+// `u = (1 + y) / (1 - y)`.  edPublic MUST be a canonical encoding of a
+// point on the curve.
+//
+// Note that this does not reject small order points, and the sign of
+// the Ed25519 x-coordinate is lost in the conversion.
+func EdPublicToXPublic(edPublic ed25519.PublicKey) ([]byte, error) {
+	var p edwards25519.Point
+	if err := decodeEdPublic(&p, edPublic); err != nil {
+		return nil, err
+	}
+
+	var u field.Element
+	imontgomery.SetUFromEdwardsPoint(&u, &p)
+
+	return u.Bytes(), nil
+}
+
+// XPublicToEdPublic converts the X25519 public key xPublic to the
+// corresponding Ed25519 public key, with the sign of the x-coordinate
+// set to signBit (0 for even, 1 for odd).  xPublic MUST be a canonical
+// encoding of a u-coordinate on the curve (not the twist), with the
+// most significant bit clear.
+func XPublicToEdPublic(xPublic []byte, signBit int) (ed25519.PublicKey, error) {
+	var u field.Element
+	if err := decodeXPublic(&u, xPublic); err != nil {
+		return nil, err
+	}
+
+	var p edwards25519.Point
+	if _, err := toEdwards(&p, &u, signBit); err != nil {
+		return nil, ErrInvalidX25519PublicKey
+	}
+
+	return ed25519.PublicKey(p.Bytes()), nil
+}
+
+func decodeEdPublic(p *edwards25519.Point, edPublic []byte) error {
+	if len(edPublic) != ed25519.PublicKeySize {
+		return ErrInvalidEd25519PublicKey
+	}
+	if _, err := p.SetBytes(edPublic); err != nil {
+		return ErrInvalidEd25519PublicKey
+	}
+
+	// Reject non-canonical encodings (y >= p, or x = 0 with the sign
+	// bit set).  This is equivalent to `p.Bytes() == edPublic`, without
+	// the field inversion, which matters for the batch conversion.
+	var y field.Element
+	if err := setCanonicalBytes(&y, clearSignBit(edPublic)); err != nil {
+		return ErrInvalidEd25519PublicKey
+	}
+	x, _, _, _ := p.ExtendedCoordinates()
+	if int(edPublic[31]>>7)&x.Equal(imontgomery.ZERO) == 1 {
+		return ErrInvalidEd25519PublicKey
+	}
+
+	return nil
+}
+
+func clearSignBit(b []byte) []byte {
+	var tmp [32]byte
+	copy(tmp[:], b)
+	tmp[31] &= 0x7f
+	return tmp[:]
+}
+
+func decodeXPublic(u *field.Element, xPublic []byte) error {
+	if len(xPublic) != X25519PublicKeySize {
+		return ErrInvalidX25519PublicKey
+	}
+	if err := setCanonicalBytes(u, xPublic); err != nil {
+		return ErrInvalidX25519PublicKey
+	}
+	return nil
+}
+
+// EdSeedToXPrivate derives the X25519 private key corresponding to the
+// Ed25519 seed (RFC 8032 private key) seed, by hashing it with SHA-512
+// and clamping the lower half of the digest.  The X25519 public key of
+// the returned private key is EdPublicToXPublic of the seed's Ed25519
+// public key.
+//
+// Note that the returned private key is already clamped, and that using
+// the same key for both signing and key exchange is only safe if the
+// protocol(s) in question are designed with this in mind.
+func EdSeedToXPrivate(seed []byte) ([]byte, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, ErrInvalidEd25519Seed
+	}
+
+	h := sha512.Sum512(seed)
+	defer func() {
+		for i := range h {
+			h[i] = 0
+		}
+	}()
+
+	var e [ScalarSize]byte
+	copy(e[:], h[:ScalarSize])
+	e = ClampScalar(e)
+
+	xPrivate := make([]byte, ScalarSize)
+	copy(xPrivate, e[:])
+	for i := range e {
+		e[i] = 0
+	}
+
+	return xPrivate, nil
+}
+
+// EdPrivateToXPrivate derives the X25519 private key corresponding to
+// the Ed25519 private key edPrivate, like EdSeedToXPrivate.
+func EdPrivateToXPrivate(edPrivate ed25519.PrivateKey) ([]byte, error) {
+	if len(edPrivate) != ed25519.PrivateKeySize {
+		return nil, ErrInvalidEd25519Seed
+	}
+	return EdSeedToXPrivate(edPrivate.Seed())
+}
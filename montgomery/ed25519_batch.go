@@ -0,0 +1,224 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	imontgomery "gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+// StreamBatchSize is the number of keys converted at once, with a
+// single shared field inversion, by ConvertEdPublicKeys and
+// ConvertXPublicKeys.
+const StreamBatchSize = 256
+
+// EdPublicsToXPublics converts each of the Ed25519 public keys to the
+// corresponding X25519 public key like EdPublicToXPublic, with a single
+// field inversion shared across all of the keys (Montgomery's trick).
+//
+// If any of the keys are invalid, an error wrapping
+// ErrInvalidEd25519PublicKey that identifies the first offending key
+// is returned.
+func EdPublicsToXPublics(edPublics []ed25519.PublicKey) ([][]byte, error) {
+	n := len(edPublics)
+	if n == 0 {
+		return nil, nil
+	}
+
+	// u = (1 + y) / (1 - y) = (Z + Y) / (Z - Y)
+	nums := make([]field.Element, n)
+	dens := make([]field.Element, n)
+	var p edwards25519.Point
+	for i, edPublic := range edPublics {
+		if err := decodeEdPublic(&p, edPublic); err != nil {
+			return nil, fmt.Errorf("%w: key %d", err, i)
+		}
+		_, y, z, _ := p.ExtendedCoordinates()
+		nums[i].Add(z, y)
+		dens[i].Subtract(z, y)
+	}
+
+	imontgomery.BatchInvert(dens)
+
+	xPublics := make([][]byte, n)
+	buf := make([]byte, n*X25519PublicKeySize)
+	for i := range nums {
+		nums[i].Multiply(&nums[i], &dens[i])
+		xPublics[i] = buf[i*X25519PublicKeySize : (i+1)*X25519PublicKeySize : (i+1)*X25519PublicKeySize]
+		copy(xPublics[i], nums[i].Bytes())
+	}
+
+	return xPublics, nil
+}
+
+// XPublicsToEdPublics converts each of the X25519 public keys to the
+// corresponding Ed25519 public key like XPublicToEdPublic, with the
+// sign of the x-coordinate set to the corresponding entry of signBits,
+// and with a single field inversion shared across all of the keys
+// (Montgomery's trick).
+//
+// If any of the keys are invalid, an error wrapping
+// ErrInvalidX25519PublicKey that identifies the first offending key
+// is returned.
+func XPublicsToEdPublics(xPublics [][]byte, signBits []int) ([]ed25519.PublicKey, error) {
+	n := len(xPublics)
+	if len(signBits) != n {
+		panic("montgomery: mismatched key and sign bit counts")
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	// y = (u - 1) / (u + 1)
+	nums := make([]field.Element, n)
+	dens := make([]field.Element, n)
+	var u field.Element
+	for i, xPublic := range xPublics {
+		if signBits[i] != 0 && signBits[i] != 1 {
+			panic("montgomery: invalid sign bit")
+		}
+		if err := decodeXPublic(&u, xPublic); err != nil {
+			return nil, fmt.Errorf("%w: key %d", err, i)
+		}
+		nums[i].Subtract(&u, imontgomery.ONE)
+		dens[i].Add(&u, imontgomery.ONE)
+		if dens[i].Equal(imontgomery.ZERO) == 1 {
+			return nil, fmt.Errorf("%w: key %d", ErrInvalidX25519PublicKey, i)
+		}
+	}
+
+	imontgomery.BatchInvert(dens)
+
+	edPublics := make([]ed25519.PublicKey, n)
+	buf := make([]byte, n*ed25519.PublicKeySize)
+	var p edwards25519.Point
+	for i := range nums {
+		nums[i].Multiply(&nums[i], &dens[i])
+		if _, err := setEdwardsFromY(&p, &nums[i], signBits[i]); err != nil {
+			return nil, fmt.Errorf("%w: key %d", ErrInvalidX25519PublicKey, i)
+		}
+		edPublics[i] = buf[i*ed25519.PublicKeySize : (i+1)*ed25519.PublicKeySize : (i+1)*ed25519.PublicKeySize]
+		copy(edPublics[i], p.Bytes())
+	}
+
+	return edPublics, nil
+}
+
+// ConvertEdPublicKeys reads concatenated Ed25519 public keys from r
+// until EOF, and writes the concatenated corresponding X25519 public
+// keys to w, converting StreamBatchSize keys at a time like
+// EdPublicsToXPublics.  It returns the number of keys converted.
+func ConvertEdPublicKeys(w io.Writer, r io.Reader) (int, error) {
+	return convertKeyStream(w, r, func(keys [][]byte) ([][]byte, error) {
+		edPublics := make([]ed25519.PublicKey, len(keys))
+		for i := range keys {
+			edPublics[i] = keys[i]
+		}
+		return EdPublicsToXPublics(edPublics)
+	})
+}
+
+// ConvertXPublicKeys reads concatenated X25519 public keys from r until
+// EOF, and writes the concatenated corresponding Ed25519 public keys to
+// w, converting StreamBatchSize keys at a time like XPublicsToEdPublics.
+// It returns the number of keys converted.
+//
+// The sign of each of the Ed25519 x-coordinates is taken from the most
+// significant bit of the corresponding X25519 public key (which is
+// otherwise unused), mirroring the Ed25519 point encoding.
+func ConvertXPublicKeys(w io.Writer, r io.Reader) (int, error) {
+	return convertKeyStream(w, r, func(keys [][]byte) ([][]byte, error) {
+		signBits := make([]int, len(keys))
+		for i := range keys {
+			signBits[i] = int(keys[i][31] >> 7)
+			keys[i][31] &= 0x7f
+		}
+		edPublics, err := XPublicsToEdPublics(keys, signBits)
+		if err != nil {
+			return nil, err
+		}
+		out := make([][]byte, len(edPublics))
+		for i := range edPublics {
+			out[i] = edPublics[i]
+		}
+		return out, nil
+	})
+}
+
+func convertKeyStream(w io.Writer, r io.Reader, convertFn func([][]byte) ([][]byte, error)) (int, error) {
+	const keySize = 32 // ed25519.PublicKeySize == X25519PublicKeySize
+
+	var (
+		buf    [StreamBatchSize * keySize]byte
+		outBuf [StreamBatchSize * keySize]byte
+		keys   [StreamBatchSize][]byte
+		n      int
+	)
+	for {
+		l, err := io.ReadFull(r, buf[:])
+		switch {
+		case err == nil, errors.Is(err, io.ErrUnexpectedEOF):
+		case errors.Is(err, io.EOF):
+			return n, nil
+		default:
+			return n, fmt.Errorf("montgomery: failed to read keys: %w", err)
+		}
+		if l%keySize != 0 {
+			return n, errors.New("montgomery: truncated key")
+		}
+
+		nKeys := l / keySize
+		for i := 0; i < nKeys; i++ {
+			keys[i] = buf[i*keySize : (i+1)*keySize]
+		}
+		converted, cErr := convertFn(keys[:nKeys])
+		if cErr != nil {
+			return n, fmt.Errorf("montgomery: batch starting at key %d: %w", n, cErr)
+		}
+		for i, key := range converted {
+			copy(outBuf[i*keySize:], key)
+		}
+		if _, wErr := w.Write(outBuf[:l]); wErr != nil {
+			return n, fmt.Errorf("montgomery: failed to write keys: %w", wErr)
+		}
+		n += nKeys
+
+		if err != nil {
+			return n, nil
+		}
+	}
+}
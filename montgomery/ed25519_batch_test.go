@@ -0,0 +1,200 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+
+	"filippo.io/edwards25519/field"
+
+	imontgomery "gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+func newTestEdPublicKeys(t testing.TB, n int) []ed25519.PublicKey {
+	edPublics := make([]ed25519.PublicKey, n)
+	for i := range edPublics {
+		edPublic, _, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("ed25519.GenerateKey: %v", err)
+		}
+		edPublics[i] = edPublic
+	}
+	return edPublics
+}
+
+func TestEd25519BatchConversion(t *testing.T) {
+	edPublics := newTestEdPublicKeys(t, 64)
+
+	xPublics, err := EdPublicsToXPublics(edPublics)
+	if err != nil {
+		t.Fatalf("EdPublicsToXPublics: %v", err)
+	}
+	signBits := make([]int, len(edPublics))
+	for i, edPublic := range edPublics {
+		expected, err := EdPublicToXPublic(edPublic)
+		if err != nil {
+			t.Fatalf("EdPublicToXPublic: %v", err)
+		}
+		if !bytes.Equal(xPublics[i], expected) {
+			t.Fatalf("EdPublicsToXPublics[%d] != EdPublicToXPublic", i)
+		}
+		signBits[i] = int(edPublic[31] >> 7)
+	}
+
+	edPublics2, err := XPublicsToEdPublics(xPublics, signBits)
+	if err != nil {
+		t.Fatalf("XPublicsToEdPublics: %v", err)
+	}
+	for i := range edPublics {
+		if !bytes.Equal(edPublics2[i], edPublics[i]) {
+			t.Fatalf("XPublicsToEdPublics[%d] != edPublics[%d]", i, i)
+		}
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		bad := append([]ed25519.PublicKey{}, edPublics...)
+		bad[7] = bytes.Repeat([]byte{0xff}, ed25519.PublicKeySize)
+		_, err := EdPublicsToXPublics(bad)
+		if !errors.Is(err, ErrInvalidEd25519PublicKey) || !strings.Contains(err.Error(), "key 7") {
+			t.Fatalf("EdPublicsToXPublics(bad): unexpected error: %v", err)
+		}
+
+		badX := append([][]byte{}, xPublics...)
+		badX[3] = make([]byte, X25519PublicKeySize)
+		badX[3][0] = 2 // u = 2 is on the twist.
+		_, err = XPublicsToEdPublics(badX, signBits)
+		if !errors.Is(err, ErrInvalidX25519PublicKey) || !strings.Contains(err.Error(), "key 3") {
+			t.Fatalf("XPublicsToEdPublics(twist): unexpected error: %v", err)
+		}
+
+		badX[3] = new(field.Element).Negate(imontgomery.ONE).Bytes() // u = -1
+		_, err = XPublicsToEdPublics(badX, signBits)
+		if !errors.Is(err, ErrInvalidX25519PublicKey) || !strings.Contains(err.Error(), "key 3") {
+			t.Fatalf("XPublicsToEdPublics(-1): unexpected error: %v", err)
+		}
+	})
+}
+
+func TestEd25519StreamConversion(t *testing.T) {
+	// Not a multiple of StreamBatchSize, to exercise the partial batch.
+	const nKeys = 2*StreamBatchSize + 17
+
+	edPublics := newTestEdPublicKeys(t, nKeys)
+	var edBuf bytes.Buffer
+	for _, edPublic := range edPublics {
+		_, _ = edBuf.Write(edPublic)
+	}
+	edStream := edBuf.Bytes()
+
+	var xBuf bytes.Buffer
+	n, err := ConvertEdPublicKeys(&xBuf, bytes.NewReader(edStream))
+	if err != nil {
+		t.Fatalf("ConvertEdPublicKeys: %v", err)
+	}
+	if n != nKeys {
+		t.Fatalf("ConvertEdPublicKeys: converted %d keys, expected %d", n, nKeys)
+	}
+	xStream := xBuf.Bytes()
+	for i, edPublic := range edPublics {
+		expected, _ := EdPublicToXPublic(edPublic)
+		if !bytes.Equal(xStream[i*32:(i+1)*32], expected) {
+			t.Fatalf("ConvertEdPublicKeys: key %d mismatch", i)
+		}
+	}
+
+	// Pack the Ed25519 sign bits into the X25519 keys.
+	for i, edPublic := range edPublics {
+		xStream[i*32+31] |= edPublic[31] & 0x80
+	}
+	var edBuf2 bytes.Buffer
+	n, err = ConvertXPublicKeys(&edBuf2, bytes.NewReader(xStream))
+	if err != nil {
+		t.Fatalf("ConvertXPublicKeys: %v", err)
+	}
+	if n != nKeys {
+		t.Fatalf("ConvertXPublicKeys: converted %d keys, expected %d", n, nKeys)
+	}
+	if !bytes.Equal(edBuf2.Bytes(), edStream) {
+		t.Fatalf("ConvertXPublicKeys(ConvertEdPublicKeys(keys)) != keys")
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		var out bytes.Buffer
+		n, err := ConvertEdPublicKeys(&out, bytes.NewReader(nil))
+		if err != nil || n != 0 || out.Len() != 0 {
+			t.Fatalf("ConvertEdPublicKeys(empty): %d, %v", n, err)
+		}
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		var out bytes.Buffer
+		_, err := ConvertEdPublicKeys(&out, bytes.NewReader(edStream[:len(edStream)-1]))
+		if err == nil {
+			t.Fatalf("ConvertEdPublicKeys(truncated): succeeded")
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		bad := append([]byte{}, edStream...)
+		idx := StreamBatchSize + 5
+		copy(bad[idx*32:], bytes.Repeat([]byte{0xff}, 32))
+
+		var out bytes.Buffer
+		n, err := ConvertEdPublicKeys(&out, bytes.NewReader(bad))
+		if !errors.Is(err, ErrInvalidEd25519PublicKey) {
+			t.Fatalf("ConvertEdPublicKeys(invalid): unexpected error: %v", err)
+		}
+		if n != StreamBatchSize || out.Len() != StreamBatchSize*32 {
+			t.Fatalf("ConvertEdPublicKeys(invalid): converted %d keys", n)
+		}
+	})
+}
+
+func BenchmarkEdPublicsToXPublics(b *testing.B) {
+	edPublics := newTestEdPublicKeys(b, StreamBatchSize)
+
+	b.Run("Single", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, edPublic := range edPublics {
+				_, _ = EdPublicToXPublic(edPublic)
+			}
+		}
+	})
+	b.Run("Batch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = EdPublicsToXPublics(edPublics)
+		}
+	})
+}
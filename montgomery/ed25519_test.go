@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"testing"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestEd25519Conversion(t *testing.T) {
+	for i := 0; i < 32; i++ {
+		edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("ed25519.GenerateKey: %v", err)
+		}
+
+		xPublic, err := EdPublicToXPublic(edPublic)
+		if err != nil {
+			t.Fatalf("EdPublicToXPublic: %v", err)
+		}
+
+		// The X25519 public key must match the one derived from the
+		// Ed25519 private scalar.
+		h := sha512.Sum512(edPrivate.Seed())
+		expected, err := curve25519.X25519(h[:32], curve25519.Basepoint)
+		if err != nil {
+			t.Fatalf("X25519: %v", err)
+		}
+		if !bytes.Equal(xPublic, expected) {
+			t.Fatalf("EdPublicToXPublic: got %x, expected %x", xPublic, expected)
+		}
+
+		signBit := int(edPublic[31] >> 7)
+		edPublic2, err := XPublicToEdPublic(xPublic, signBit)
+		if err != nil {
+			t.Fatalf("XPublicToEdPublic: %v", err)
+		}
+		if !bytes.Equal(edPublic2, edPublic) {
+			t.Fatalf("XPublicToEdPublic(EdPublicToXPublic(pk)) != pk")
+		}
+	}
+
+	// Non-canonical encodings are rejected.
+	nonCanonical := bytes.Repeat([]byte{0xff}, 32)
+	nonCanonical[0] = 0xee // p + 1 (with the sign bit set)
+	if _, err := EdPublicToXPublic(nonCanonical); err != ErrInvalidEd25519PublicKey {
+		t.Fatalf("EdPublicToXPublic(nonCanonical): unexpected error: %v", err)
+	}
+	nonCanonical[31] = 0x7f
+	if _, err := XPublicToEdPublic(nonCanonical, 0); err != ErrInvalidX25519PublicKey {
+		t.Fatalf("XPublicToEdPublic(nonCanonical): unexpected error: %v", err)
+	}
+	highBit := make([]byte, 32)
+	highBit[0], highBit[31] = 9, 0x80
+	if _, err := XPublicToEdPublic(highBit, 0); err != ErrInvalidX25519PublicKey {
+		t.Fatalf("XPublicToEdPublic(highBit): unexpected error: %v", err)
+	}
+	negZero := make([]byte, 32)
+	negZero[0], negZero[31] = 1, 0x80 // -0 (the identity with the sign bit set)
+	if _, err := EdPublicToXPublic(negZero); err != ErrInvalidEd25519PublicKey {
+		t.Fatalf("EdPublicToXPublic(negZero): unexpected error: %v", err)
+	}
+	if _, err := EdPublicToXPublic(nil); err != ErrInvalidEd25519PublicKey {
+		t.Fatalf("EdPublicToXPublic(nil): unexpected error: %v", err)
+	}
+}
+
+func TestEd25519PrivateConversion(t *testing.T) {
+	for i := 0; i < 32; i++ {
+		edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("ed25519.GenerateKey: %v", err)
+		}
+
+		xPrivate, err := EdSeedToXPrivate(edPrivate.Seed())
+		if err != nil {
+			t.Fatalf("EdSeedToXPrivate: %v", err)
+		}
+		if xPrivate[0]&7 != 0 || xPrivate[31]&0xc0 != 0x40 {
+			t.Fatalf("EdSeedToXPrivate: private key not clamped: %x", xPrivate)
+		}
+
+		xPrivate2, err := EdPrivateToXPrivate(edPrivate)
+		if err != nil {
+			t.Fatalf("EdPrivateToXPrivate: %v", err)
+		}
+		if !bytes.Equal(xPrivate, xPrivate2) {
+			t.Fatalf("EdPrivateToXPrivate != EdSeedToXPrivate")
+		}
+
+		xPublic, err := curve25519.X25519(xPrivate, curve25519.Basepoint)
+		if err != nil {
+			t.Fatalf("X25519: %v", err)
+		}
+		xPublic2, err := EdPublicToXPublic(edPublic)
+		if err != nil {
+			t.Fatalf("EdPublicToXPublic: %v", err)
+		}
+		if !bytes.Equal(xPublic, xPublic2) {
+			t.Fatalf("X25519(EdSeedToXPrivate(seed)) != EdPublicToXPublic(pk)")
+		}
+	}
+
+	if _, err := EdSeedToXPrivate(make([]byte, 31)); err != ErrInvalidEd25519Seed {
+		t.Fatalf("EdSeedToXPrivate(short): unexpected error: %v", err)
+	}
+	if _, err := EdPrivateToXPrivate(make([]byte, 32)); err != ErrInvalidEd25519Seed {
+		t.Fatalf("EdPrivateToXPrivate(short): unexpected error: %v", err)
+	}
+}
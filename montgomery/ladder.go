@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"filippo.io/edwards25519/field"
+)
+
+// LadderPoint is a point on curve25519 (or its twist), represented by
+// the projective (U:W) coordinates used by the Montgomery ladder, where
+// the affine u-coordinate is U/W.  The point at infinity is represented
+// by W = 0, and the v-coordinate is not tracked.
+//
+// The zero value is NOT valid, and it may be used only as a receiver.
+type LadderPoint struct {
+	u field.Element
+	w field.Element
+}
+
+// NewLadderPoint returns a new LadderPoint set to (u:1).
+func NewLadderPoint(u *field.Element) *LadderPoint {
+	return new(LadderPoint).SetU(u)
+}
+
+// SetU sets p to (u:1), and returns p.
+func (p *LadderPoint) SetU(u *field.Element) *LadderPoint {
+	p.u.Set(u)
+	p.w.One()
+	return p
+}
+
+// SetInfinity sets p to the point at infinity (1:0), and returns p.
+func (p *LadderPoint) SetInfinity() *LadderPoint {
+	p.u.One()
+	p.w.Zero()
+	return p
+}
+
+// Set sets p = q, and returns p.
+func (p *LadderPoint) Set(q *LadderPoint) *LadderPoint {
+	*p = *q
+	return p
+}
+
+// Swap swaps p and q if cond == 1, and leaves them unchanged if
+// cond == 0, in constant time.
+func (p *LadderPoint) Swap(q *LadderPoint, cond int) {
+	p.u.Swap(&q.u, cond)
+	p.w.Swap(&q.w, cond)
+}
+
+// SetProjective sets p to (U:W), and returns p.
+func (p *LadderPoint) SetProjective(U, W *field.Element) *LadderPoint {
+	p.u.Set(U)
+	p.w.Set(W)
+	return p
+}
+
+// Projective returns copies of the projective (U:W) coordinates of p.
+func (p *LadderPoint) Projective() (U, W *field.Element) {
+	return new(field.Element).Set(&p.u), new(field.Element).Set(&p.w)
+}
+
+// U returns the affine u-coordinate of p, U/W.  The point at infinity
+// is returned as u = 0, like with X25519.
+func (p *LadderPoint) U() *field.Element {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var u field.Element
+	return p.affineU(&u)
+}
+
+func (p *LadderPoint) affineU(u *field.Element) *field.Element {
+	u.Invert(&p.w)
+	return u.Multiply(u, &p.u)
+}
+
+// LadderPointStep performs a combined differential addition and doubling
+// step of the Montgomery ladder, setting p to 2*p, and q to p+q, given
+// the affine u-coordinate of the difference q-p, uDiff.  No inversions
+// are performed.
+//
+// Note: The differential addition is undefined when uDiff is 0 (q-p is
+// the point of order 2 or the point at infinity).
+func LadderPointStep(p, q *LadderPoint, uDiff *field.Element) {
+	if p == q {
+		panic("montgomery: aliased ladder points")
+	}
+
+	var tmp0, tmp1 field.Element
+	ladderStep(&p.u, &p.w, &q.u, &q.w, uDiff, &tmp0, &tmp1)
+}
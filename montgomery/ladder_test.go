@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+func TestLadderPoint(t *testing.T) {
+	t.Run("Step", func(t *testing.T) {
+		for i := 0; i < 16; i++ {
+			_, q := newRandomPoint(t)
+			_, r := newRandomPoint(t)
+
+			uP, _ := FromEdwardsPoint(q)
+			uQ, _ := FromEdwardsPoint(r)
+			uDiff, _ := FromEdwardsPoint(new(edwards25519.Point).Subtract(r, q))
+
+			p2, p3 := NewLadderPoint(uP), NewLadderPoint(uQ)
+			LadderPointStep(p2, p3, uDiff)
+
+			expectedDbl, expectedSum := LadderStep(uP, uQ, uDiff)
+			if p2.U().Equal(expectedDbl) != 1 {
+				t.Fatalf("LadderPointStep: u(2P) mismatch")
+			}
+			if p3.U().Equal(expectedSum) != 1 {
+				t.Fatalf("LadderPointStep: u(P+Q) mismatch")
+			}
+		}
+	})
+	t.Run("Ladder", func(t *testing.T) {
+		var scalar [ScalarSize]byte
+		for i := 0; i < 8; i++ {
+			if _, err := rand.Read(scalar[:]); err != nil {
+				t.Fatalf("rand.Read: %v", err)
+			}
+			p, _ := newRandomPoint(t)
+			u := p.U()
+
+			// A textbook Montgomery ladder, with the clamping done by
+			// ScalarMult.
+			e := ClampScalar(scalar)
+			r0, r1 := new(LadderPoint).SetInfinity(), NewLadderPoint(u)
+			for pos := 254; pos >= 0; pos-- {
+				b := int(e[pos/8]>>uint(pos&7)) & 1
+				r0.Swap(r1, b)
+				LadderPointStep(r0, r1, u)
+				r0.Swap(r1, b)
+			}
+
+			if r0.U().Equal(ScalarMult(scalar[:], u)) != 1 {
+				t.Fatalf("ladder != ScalarMult")
+			}
+
+			U, W := r0.Projective()
+			if new(LadderPoint).SetProjective(U, W).U().Equal(r0.U()) != 1 {
+				t.Fatalf("SetProjective(Projective()) != p")
+			}
+		}
+	})
+	t.Run("Infinity", func(t *testing.T) {
+		if new(LadderPoint).SetInfinity().U().Equal(new(field.Element)) != 1 {
+			t.Fatalf("U(infinity) != 0")
+		}
+	})
+}
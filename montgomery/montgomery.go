@@ -0,0 +1,164 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package montgomery provides routines for working with curve25519
+// points in Montgomery form, and for converting them to and from
+// edwards25519 points.
+package montgomery
+
+import (
+	"errors"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	imontgomery "gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+// ErrNotOnCurve is the error returned when a u-coordinate does not
+// correspond to a point on curve25519.
+var ErrNotOnCurve = errors.New("montgomery: point is not on the curve")
+
+// FromEdwardsPoint returns the (u, v) coordinates of the Montgomery form
+// of the edwards25519 point p.  The identity element and the point of
+// order 2 are both mapped to (0, 0).
+func FromEdwardsPoint(p *edwards25519.Point) (*field.Element, *field.Element) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var u, v field.Element
+	imontgomery.SetFromEdwardsPoint(&u, &v, p)
+	return &u, &v
+}
+
+// FromEdwardsPointInto sets (u, v) to the Montgomery form of the
+// edwards25519 point p like FromEdwardsPoint, without allocating.
+func FromEdwardsPointInto(u, v *field.Element, p *edwards25519.Point) {
+	imontgomery.SetFromEdwardsPoint(u, v, p)
+}
+
+// UFromEdwardsPointInto sets u to the u-coordinate of the Montgomery
+// form of the edwards25519 point p, without computing the v-coordinate
+// or allocating.
+func UFromEdwardsPointInto(u *field.Element, p *edwards25519.Point) {
+	imontgomery.SetUFromEdwardsPoint(u, p)
+}
+
+// FromEdwardsPoints returns the (u, v) coordinates of the Montgomery
+// form of each of the edwards25519 points like FromEdwardsPoint, with
+// a single field inversion shared across all of the points (Montgomery's
+// trick).  This is considerably faster than calling FromEdwardsPoint on
+// each point.
+func FromEdwardsPoints(points []*edwards25519.Point) (us, vs []field.Element) {
+	if len(points) == 0 {
+		return nil, nil
+	}
+
+	us = make([]field.Element, len(points))
+	vs = make([]field.Element, len(points))
+	imontgomery.SetFromEdwardsPoints(us, vs, points)
+
+	return us, vs
+}
+
+// ToEdwardsPoint returns the edwards25519 point corresponding to the
+// Montgomery point (u, v), per the birational map in RFC 7748.  The
+// sign of the Edwards x-coordinate is determined by v.  The point of
+// order 2 (0, 0), where the map is undefined, is mapped to the identity
+// element (see Point.EdwardsPoint).
+//
+// ErrNotOnCurve is returned if (u, v) is not on curve25519.
+func ToEdwardsPoint(u, v *field.Element) (*edwards25519.Point, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p edwards25519.Point
+	return toEdwardsPoint(&p, u, v)
+}
+
+// ToEdwardsPointInto sets p to the edwards25519 point corresponding to
+// the Montgomery point (u, v) like ToEdwardsPoint, without allocating.
+// If (u, v) is not on curve25519, p is left unchanged and ErrNotOnCurve
+// is returned.
+func ToEdwardsPointInto(p *edwards25519.Point, u, v *field.Element) error {
+	_, err := toEdwardsPoint(p, u, v)
+	return err
+}
+
+func toEdwardsPoint(p *edwards25519.Point, u, v *field.Element) (*edwards25519.Point, error) {
+	if isOnCurve(u, v) != 1 {
+		return nil, ErrNotOnCurve
+	}
+	imontgomery.SetEdwardsPoint(p, u, v)
+	return p, nil
+}
+
+// ToEdwards returns the edwards25519 point with the y-coordinate
+// `(u - 1) / (u + 1)` corresponding to the Montgomery u-coordinate u,
+// and the sign of the x-coordinate set to signBit (0 for even, 1 for
+// odd), as in the RFC 8032 point encoding.  This is the conversion used
+// to go from X25519 to Ed25519 public keys, where only u is available.
+//
+// ErrNotOnCurve is returned if u = -1, or if u is not the u-coordinate
+// of a point on curve25519 (ie: it is on the twist).
+func ToEdwards(u *field.Element, signBit int) (*edwards25519.Point, error) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var p edwards25519.Point
+	return toEdwards(&p, u, signBit)
+}
+
+func toEdwards(p *edwards25519.Point, u *field.Element, signBit int) (*edwards25519.Point, error) {
+	if signBit != 0 && signBit != 1 {
+		panic("montgomery: invalid sign bit")
+	}
+
+	var uPlusOne, uMinusOne, y field.Element
+	uPlusOne.Add(u, imontgomery.ONE)
+	if uPlusOne.Equal(imontgomery.ZERO) == 1 {
+		return nil, ErrNotOnCurve
+	}
+	uMinusOne.Subtract(u, imontgomery.ONE)
+
+	y.Invert(&uPlusOne)
+	y.Multiply(&y, &uMinusOne)
+
+	return setEdwardsFromY(p, &y, signBit)
+}
+
+// setEdwardsFromY sets p to the edwards25519 point with the
+// y-coordinate y, and the sign of the x-coordinate signBit, and
+// returns p.
+func setEdwardsFromY(p *edwards25519.Point, y *field.Element, signBit int) (*edwards25519.Point, error) {
+	b := y.Bytes()
+	b[31] |= byte(signBit << 7)
+	if _, err := p.SetBytes(b); err != nil {
+		return nil, ErrNotOnCurve
+	}
+
+	return p, nil
+}
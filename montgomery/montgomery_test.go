@@ -0,0 +1,227 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+func TestFromEdwardsPoints(t *testing.T) {
+	order2, err := new(edwards25519.Point).SetBytes([]byte{
+		0xec, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f,
+	})
+	if err != nil {
+		t.Fatalf("SetBytes(order2): %v", err)
+	}
+
+	points := []*edwards25519.Point{
+		edwards25519.NewGeneratorPoint(),
+		edwards25519.NewIdentityPoint(),
+		order2,
+	}
+	var b [64]byte
+	for i := 0; i < 32; i++ {
+		if _, err = rand.Read(b[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		s, err := edwards25519.NewScalar().SetUniformBytes(b[:])
+		if err != nil {
+			t.Fatalf("SetUniformBytes: %v", err)
+		}
+		points = append(points, new(edwards25519.Point).ScalarBaseMult(s))
+	}
+
+	us, vs := FromEdwardsPoints(points)
+	if len(us) != len(points) || len(vs) != len(points) {
+		t.Fatalf("FromEdwardsPoints: invalid output lengths")
+	}
+	for i, p := range points {
+		u, v := FromEdwardsPoint(p)
+		if us[i].Equal(u) != 1 || vs[i].Equal(v) != 1 {
+			t.Fatalf("FromEdwardsPoints[%d] != FromEdwardsPoint", i)
+		}
+	}
+
+	if us, vs = FromEdwardsPoints(nil); us != nil || vs != nil {
+		t.Fatalf("FromEdwardsPoints(nil): expected nil")
+	}
+}
+
+func BenchmarkFromEdwardsPoints(b *testing.B) {
+	points := make([]*edwards25519.Point, 1024)
+	for i := range points {
+		points[i] = edwards25519.NewGeneratorPoint()
+	}
+
+	b.Run("Single", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, p := range points {
+				_, _ = FromEdwardsPoint(p)
+			}
+		}
+	})
+	b.Run("Batch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = FromEdwardsPoints(points)
+		}
+	})
+}
+
+func TestToEdwards(t *testing.T) {
+	var b [64]byte
+	for i := 0; i < 32; i++ {
+		if _, err := rand.Read(b[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		s, err := edwards25519.NewScalar().SetUniformBytes(b[:])
+		if err != nil {
+			t.Fatalf("SetUniformBytes: %v", err)
+		}
+		p := new(edwards25519.Point).ScalarBaseMult(s)
+		negP := new(edwards25519.Point).Negate(p)
+		u, v := FromEdwardsPoint(p)
+
+		q, err := ToEdwardsPoint(u, v)
+		if err != nil {
+			t.Fatalf("ToEdwardsPoint: %v", err)
+		}
+		if q.Equal(p) != 1 {
+			t.Fatalf("ToEdwardsPoint(FromEdwardsPoint(p)) != p")
+		}
+
+		sign := int(p.Bytes()[31] >> 7)
+		q, err = ToEdwards(u, sign)
+		if err != nil {
+			t.Fatalf("ToEdwards(u, %d): %v", sign, err)
+		}
+		if q.Equal(p) != 1 {
+			t.Fatalf("ToEdwards(u, sign(p)) != p")
+		}
+		q, err = ToEdwards(u, sign^1)
+		if err != nil {
+			t.Fatalf("ToEdwards(u, %d): %v", sign^1, err)
+		}
+		if q.Equal(negP) != 1 {
+			t.Fatalf("ToEdwards(u, !sign(p)) != -p")
+		}
+	}
+
+	negOne := new(field.Element).Negate(new(field.Element).One())
+	if _, err := ToEdwards(negOne, 0); err != ErrNotOnCurve {
+		t.Fatalf("ToEdwards(-1): unexpected error: %v", err)
+	}
+
+	// Find a u-coordinate on the twist.
+	var u, gu, tmp field.Element
+	for i := uint64(2); ; i++ {
+		mustSetUint64(&u, i)
+		gu.Square(&u)
+		tmp.Multiply(&gu, mustSetUint64(new(field.Element), 486662))
+		gu.Multiply(&gu, &u)
+		gu.Add(&gu, &tmp)
+		gu.Add(&gu, &u)
+		if _, isSquare := tmp.SqrtRatio(&gu, new(field.Element).One()); isSquare == 0 {
+			break
+		}
+	}
+	if _, err := ToEdwards(&u, 0); err != ErrNotOnCurve {
+		t.Fatalf("ToEdwards(twist): unexpected error: %v", err)
+	}
+
+	// (2, 1) is not on the curve.
+	two, one := mustSetUint64(new(field.Element), 2), new(field.Element).One()
+	if _, err := ToEdwardsPoint(two, one); err != ErrNotOnCurve {
+		t.Fatalf("ToEdwardsPoint(2, 1): unexpected error: %v", err)
+	}
+	q := edwards25519.NewGeneratorPoint()
+	if err := ToEdwardsPointInto(q, two, one); err != ErrNotOnCurve {
+		t.Fatalf("ToEdwardsPointInto(2, 1): unexpected error: %v", err)
+	}
+	if q.Equal(edwards25519.NewGeneratorPoint()) != 1 {
+		t.Fatalf("ToEdwardsPointInto(2, 1): output modified")
+	}
+}
+
+func mustSetUint64(fe *field.Element, x uint64) *field.Element {
+	var b [32]byte
+	binary.LittleEndian.PutUint64(b[:], x)
+	if _, err := fe.SetBytes(b[:]); err != nil {
+		panic(err)
+	}
+	return fe
+}
+
+func TestInto(t *testing.T) {
+	var (
+		uOut, vOut field.Element
+		q          edwards25519.Point
+	)
+	p := edwards25519.NewGeneratorPoint()
+
+	u, v := FromEdwardsPoint(p)
+	FromEdwardsPointInto(&uOut, &vOut, p)
+	if uOut.Equal(u) != 1 || vOut.Equal(v) != 1 {
+		t.Fatalf("FromEdwardsPointInto != FromEdwardsPoint")
+	}
+	uOut.Zero()
+	UFromEdwardsPointInto(&uOut, p)
+	if uOut.Equal(u) != 1 {
+		t.Fatalf("UFromEdwardsPointInto != FromEdwardsPoint")
+	}
+	if err := ToEdwardsPointInto(&q, u, v); err != nil {
+		t.Fatalf("ToEdwardsPointInto: %v", err)
+	}
+	if q.Equal(p) != 1 {
+		t.Fatalf("ToEdwardsPointInto(FromEdwardsPoint(p)) != p")
+	}
+
+	for _, v := range []struct {
+		n  string
+		fn func()
+	}{
+		{"FromEdwardsPointInto", func() { FromEdwardsPointInto(&uOut, &vOut, p) }},
+		{"UFromEdwardsPointInto", func() { UFromEdwardsPointInto(&uOut, p) }},
+		{"ToEdwardsPointInto", func() { _ = ToEdwardsPointInto(&q, &uOut, &vOut) }},
+	} {
+		if n := testing.AllocsPerRun(100, v.fn); n != 0 {
+			t.Errorf("%s: %v allocations", v.n, n)
+		}
+	}
+}
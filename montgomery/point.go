@@ -0,0 +1,410 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	imontgomery "gitlab.com/yawning/edwards25519-extra/internal/montgomery"
+)
+
+// CompressedSize is the size of the compressed encoding of a Point.
+const CompressedSize = 32
+
+// DecodeFlags are the options for Point.SetBytesWithFlags.
+type DecodeFlags int
+
+const (
+	// DecodeAllowNonCanonical accepts non-canonical u-coordinates (>= p,
+	// or with the most significant bit set), reducing them like X25519.
+	DecodeAllowNonCanonical DecodeFlags = 1 << iota
+
+	// DecodeRejectSmallOrder rejects u-coordinates of points of small
+	// order, per IsSmallOrder.
+	DecodeRejectSmallOrder
+)
+
+var edwardsIdentity = edwards25519.NewIdentityPoint()
+
+// ErrInvalidEncoding is the error returned when a point encoding is
+// malformed or does not represent a point on curve25519.
+var ErrInvalidEncoding = errors.New("montgomery: invalid point encoding")
+
+// identityCompressed is the compressed encoding of the identity element,
+// (u = 0, sign(v) = 1), which is otherwise invalid as the only point
+// with u = 0 is the point of order 2 (0, 0).
+var identityCompressed = func() [CompressedSize]byte {
+	var b [CompressedSize]byte
+	b[31] = 0x80
+	return b
+}()
+
+// Point is a point on curve25519, represented by the affine u and
+// v-coordinates, or the identity element (the point at infinity).
+//
+// The zero value is NOT valid, and it may be used only as a receiver.
+type Point struct {
+	u field.Element
+	v field.Element
+
+	// isIdentity is 1 iff the point is the point at infinity, in
+	// which case u and v are both 0.
+	isIdentity int
+}
+
+// NewIdentityPoint returns a new Point set to the identity element.
+func NewIdentityPoint() *Point {
+	return new(Point).setIdentity()
+}
+
+// Set sets p = q, and returns p.
+func (p *Point) Set(q *Point) *Point {
+	*p = *q
+	return p
+}
+
+// SetUV sets p to the affine point (u, v), and returns p.  If (u, v)
+// is not on curve25519, SetUV returns nil and ErrNotOnCurve, and the
+// receiver is unchanged.
+func (p *Point) SetUV(u, v *field.Element) (*Point, error) {
+	if isOnCurve(u, v) != 1 {
+		return nil, ErrNotOnCurve
+	}
+	p.u.Set(u)
+	p.v.Set(v)
+	p.isIdentity = 0
+	return p, nil
+}
+
+// SetEdwardsPoint sets p to the curve25519 point corresponding to the
+// edwards25519 point q, with the same sign convention as
+// FromEdwardsPoint, and returns p.  Unlike FromEdwardsPoint, the
+// identity element is mapped to the identity element, and not the
+// point of order 2.
+func (p *Point) SetEdwardsPoint(q *edwards25519.Point) *Point {
+	// The identity element is mapped to (0, 0), which is what the
+	// identity element is represented as.
+	imontgomery.SetFromEdwardsPoint(&p.u, &p.v, q)
+	p.isIdentity = q.Equal(edwardsIdentity)
+	return p
+}
+
+// EdwardsPoint returns the edwards25519 point corresponding to p, with
+// the same sign convention as ToEdwardsPoint.  Unlike ToEdwardsPoint,
+// the point of order 2 (0, 0) is mapped to the edwards25519 point of
+// order 2 (0, -1), and not the identity element.
+func (p *Point) EdwardsPoint() *edwards25519.Point {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var q edwards25519.Point
+	return p.edwardsPoint(&q)
+}
+
+func (p *Point) edwardsPoint(q *edwards25519.Point) *edwards25519.Point {
+	// Per RFC 7748: (x, y) = (sqrt(-486664)*u/v, (u-1)/(u+1)), in
+	// extended coordinates with the common denominator v*(u+1) as Z,
+	// like imontgomery.SetEdwardsPoint.
+	var uMinusOne, uPlusOne, cU, X, Y, Z, T field.Element
+	uMinusOne.Subtract(&p.u, imontgomery.ONE)
+	uPlusOne.Add(&p.u, imontgomery.ONE)
+	cU.Multiply(&p.u, imontgomery.SQRT_NEG_A_PLUS_TWO)
+	X.Multiply(&cU, &uPlusOne)
+	Y.Multiply(&uMinusOne, &p.v)
+	Z.Multiply(&p.v, &uPlusOne)
+	T.Multiply(&cU, &uMinusOne)
+
+	// The map is undefined when v = 0 or u = -1.
+	//
+	// On curve25519, v = 0 only for the point of order 2 (0, 0), which
+	// corresponds to (0, -1), and u = -1 is on the twist, so can not be
+	// the u-coordinate of a valid Point.  The identity element is also
+	// represented as (0, 0), and corresponds to (0, 1).
+	isOrderTwo := p.v.Equal(imontgomery.ZERO) & (1 ^ p.isIdentity)
+	isIdentity := p.isIdentity | uPlusOne.Equal(imontgomery.ZERO)
+
+	var negOne field.Element
+	negOne.Negate(imontgomery.ONE)
+	X.Select(imontgomery.ZERO, &X, isOrderTwo|isIdentity)
+	Y.Select(&negOne, &Y, isOrderTwo)
+	Y.Select(imontgomery.ONE, &Y, isIdentity)
+	Z.Select(imontgomery.ONE, &Z, isOrderTwo|isIdentity)
+	T.Select(imontgomery.ZERO, &T, isOrderTwo|isIdentity)
+
+	if _, err := q.SetExtendedCoordinates(&X, &Y, &Z, &T); err != nil {
+		panic("montgomery: failed to create edwards point from u, v: " + err.Error())
+	}
+
+	for _, fe := range []*field.Element{&uMinusOne, &uPlusOne, &cU, &X, &Y, &Z, &T} {
+		fe.Zero()
+	}
+
+	return q
+}
+
+// U returns a copy of the u-coordinate of the point.  The identity
+// element has no affine coordinates, and is returned as u = 0.
+func (p *Point) U() *field.Element {
+	return new(field.Element).Set(&p.u)
+}
+
+// V returns a copy of the v-coordinate of the point.  The identity
+// element has no affine coordinates, and is returned as v = 0.
+func (p *Point) V() *field.Element {
+	return new(field.Element).Set(&p.v)
+}
+
+// Bytes returns the canonical 32-byte little-endian encoding of the
+// u-coordinate of the point, as used by X25519.  The identity element
+// is encoded as u = 0, like with X25519.
+func (p *Point) Bytes() []byte {
+	return p.u.Bytes()
+}
+
+// SetBytes sets p to the point on curve25519 with the u-coordinate
+// encoded in b, with the non-negative v-coordinate, and returns p.  b
+// MUST be a canonical encoding of a u-coordinate on the curve (not the
+// twist), otherwise SetBytes returns nil and ErrInvalidEncoding, and
+// the receiver is unchanged.
+//
+// Note: As the sign of v is not encoded, SetBytes(p.Bytes()) is only
+// equal to p up to sign (and u = 0 is decoded as the point of order 2).
+// Use MarshalCompressed and UnmarshalCompressed to serialize entire
+// points.  This does not check that the point is in the prime order
+// subgroup.
+func (p *Point) SetBytes(b []byte) (*Point, error) {
+	return p.SetBytesWithFlags(b, 0)
+}
+
+// SetBytesWithFlags sets p like SetBytes, with the decoding behavior
+// adjusted by flags.
+func (p *Point) SetBytesWithFlags(b []byte, flags DecodeFlags) (*Point, error) {
+	if flags&^(DecodeAllowNonCanonical|DecodeRejectSmallOrder) != 0 {
+		panic("montgomery: invalid DecodeFlags")
+	}
+	if len(b) != CompressedSize {
+		return nil, fmt.Errorf("%w: invalid length: %d", ErrInvalidEncoding, len(b))
+	}
+
+	var u, v field.Element
+	if flags&DecodeAllowNonCanonical != 0 {
+		// field.Element.SetBytes ignores the most significant bit and
+		// reduces values >= p, exactly like X25519.
+		if _, err := u.SetBytes(b); err != nil {
+			return nil, fmt.Errorf("%w: u-coordinate: %v", ErrInvalidEncoding, err)
+		}
+	} else if err := setCanonicalBytes(&u, b); err != nil {
+		return nil, fmt.Errorf("%w: u-coordinate: %v", ErrInvalidEncoding, err)
+	}
+	if flags&DecodeRejectSmallOrder != 0 && IsSmallOrder(&u) {
+		return nil, fmt.Errorf("%w: small order point", ErrInvalidEncoding)
+	}
+	if _, err := recoverV(&v, &u, 0); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+
+	p.u.Set(&u)
+	p.v.Set(&v)
+	p.isIdentity = 0
+
+	return p, nil
+}
+
+// Equal returns 1 if p is equivalent to q, and 0 otherwise.
+func (p *Point) Equal(q *Point) int {
+	return p.u.Equal(&q.u) & p.v.Equal(&q.v) & subtle.ConstantTimeEq(int32(p.isIdentity), int32(q.isIdentity))
+}
+
+// MarshalCompressed returns the CompressedSize-byte compressed encoding
+// of the point, consisting of the canonical little-endian encoding of
+// the u-coordinate, with the sign of the v-coordinate (as in
+// field.Element.IsNegative) packed into the most significant bit.
+//
+// The identity element is encoded as u = 0 with the sign bit set, which
+// is not the encoding of any affine point.
+func (p *Point) MarshalCompressed() []byte {
+	var b [CompressedSize]byte
+	copy(b[:], p.u.Bytes())
+	b[31] |= byte(p.v.IsNegative() << 7)
+
+	subtle.ConstantTimeCopy(p.isIdentity, b[:], identityCompressed[:])
+
+	return b[:]
+}
+
+// UnmarshalCompressed sets p to the point represented by the compressed
+// encoding produced by MarshalCompressed.  The u-coordinate must be
+// canonically encoded and on the curve, and the sign bit must be 0 if
+// the v-coordinate is 0, otherwise ErrInvalidEncoding is returned and
+// p is left unchanged.
+//
+// Note: This does not check that the point is in the prime order
+// subgroup.
+func (p *Point) UnmarshalCompressed(b []byte) error {
+	if len(b) != CompressedSize {
+		return fmt.Errorf("%w: invalid length: %d", ErrInvalidEncoding, len(b))
+	}
+
+	if subtle.ConstantTimeCompare(b, identityCompressed[:]) == 1 {
+		p.setIdentity()
+		return nil
+	}
+
+	var uBytes [CompressedSize]byte
+	copy(uBytes[:], b)
+	sign := int(uBytes[31] >> 7)
+	uBytes[31] &= 0x7f
+
+	var u, v field.Element
+	if err := setCanonicalBytes(&u, uBytes[:]); err != nil {
+		return fmt.Errorf("%w: u-coordinate: %v", ErrInvalidEncoding, err)
+	}
+	if _, err := recoverV(&v, &u, sign); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+
+	p.u.Set(&u)
+	p.v.Set(&v)
+	p.isIdentity = 0
+
+	return nil
+}
+
+func (p *Point) setIdentity() *Point {
+	p.u.Zero()
+	p.v.Zero()
+	p.isIdentity = 1
+	return p
+}
+
+func setCanonicalBytes(fe *field.Element, b []byte) error {
+	if _, err := fe.SetBytes(b); err != nil {
+		return err
+	}
+	// field.Element.SetBytes ignores the most significant bit, and
+	// accepts values >= p, so round-trip to reject both.
+	if subtle.ConstantTimeCompare(fe.Bytes(), b) != 1 {
+		return errors.New("non-canonical encoding")
+	}
+	return nil
+}
+
+// Select sets p to a if cond == 1, and to b if cond == 0, in constant
+// time, and returns p.
+func (p *Point) Select(a, b *Point, cond int) *Point {
+	p.u.Select(&a.u, &b.u, cond)
+	p.v.Select(&a.v, &b.v, cond)
+	p.isIdentity = subtle.ConstantTimeSelect(cond, a.isIdentity, b.isIdentity)
+	return p
+}
+
+// CondAssign sets p to q if cond == 1, and leaves p unchanged if
+// cond == 0, in constant time, and returns p.
+func (p *Point) CondAssign(q *Point, cond int) *Point {
+	return p.Select(q, p, cond)
+}
+
+// Negate sets p = -q, and returns p.
+func (p *Point) Negate(q *Point) *Point {
+	p.u.Set(&q.u)
+	p.v.Negate(&q.v)
+	p.isIdentity = q.isIdentity
+	return p
+}
+
+// Add sets p = q + r, and returns p.  All of the exceptional cases
+// (either input being the identity element, q = r, and q = -r) are
+// handled in constant time.
+func (p *Point) Add(q, r *Point) *Point {
+	// Compute the slope of the line through q and r, or of the tangent
+	// line at q, if q = r.
+	//
+	//   lambda = (v2 - v1) / (u2 - u1)           (q != r)
+	//   lambda = (3*u1^2 + 2*A*u1 + 1) / (2*v1)  (q = r)
+	sameU := q.u.Equal(&r.u)
+	isDouble := sameU & q.v.Equal(&r.v) & (1 ^ q.v.Equal(imontgomery.ZERO))
+
+	var num, den, tmp, lambda field.Element
+	num.Subtract(&r.v, &q.v)
+	den.Subtract(&r.u, &q.u)
+
+	tmp.Add(&q.u, &q.u)
+	tmp.Add(&tmp, &q.u)            // 3*u1
+	tmp.Add(&tmp, imontgomery.A)   // 3*u1 + A
+	tmp.Add(&tmp, imontgomery.A)   // 3*u1 + 2*A
+	tmp.Multiply(&tmp, &q.u)       // 3*u1^2 + 2*A*u1
+	tmp.Add(&tmp, imontgomery.ONE) // 3*u1^2 + 2*A*u1 + 1
+	num.Select(&tmp, &num, isDouble)
+	tmp.Add(&q.v, &q.v)
+	den.Select(&tmp, &den, isDouble)
+
+	lambda.Invert(&den)
+	lambda.Multiply(&lambda, &num)
+
+	//   u3 = lambda^2 - A - u1 - u2
+	//   v3 = lambda * (u1 - u3) - v1
+	var u3, v3 field.Element
+	u3.Square(&lambda)
+	u3.Subtract(&u3, imontgomery.A)
+	u3.Subtract(&u3, &q.u)
+	u3.Subtract(&u3, &r.u)
+	v3.Subtract(&q.u, &u3)
+	v3.Multiply(&v3, &lambda)
+	v3.Subtract(&v3, &q.v)
+
+	// If u1 = u2, and this is not a doubling, then q = -r (including
+	// doubling the point of order 2), and the sum is the identity.
+	isIdentity := sameU & (1 ^ isDouble)
+	u3.Select(imontgomery.ZERO, &u3, isIdentity)
+	v3.Select(imontgomery.ZERO, &v3, isIdentity)
+
+	// Handle either of the inputs being the identity.
+	u3.Select(&q.u, &u3, r.isIdentity)
+	v3.Select(&q.v, &v3, r.isIdentity)
+	isIdentity = subtle.ConstantTimeSelect(r.isIdentity, q.isIdentity, isIdentity)
+	u3.Select(&r.u, &u3, q.isIdentity)
+	v3.Select(&r.v, &v3, q.isIdentity)
+	isIdentity = subtle.ConstantTimeSelect(q.isIdentity, r.isIdentity, isIdentity)
+
+	p.u.Set(&u3)
+	p.v.Set(&v3)
+	p.isIdentity = isIdentity
+
+	return p
+}
+
+// Double sets p = 2 * q, and returns p.
+func (p *Point) Double(q *Point) *Point {
+	return p.Add(q, q)
+}
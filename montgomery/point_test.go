@@ -0,0 +1,388 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+func newRandomPoint(t *testing.T) (*Point, *edwards25519.Point) {
+	var b [64]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		t.Fatalf("rand.Read: %v", err)
+	}
+	s, _ := new(edwards25519.Scalar).SetUniformBytes(b[:])
+	q := new(edwards25519.Point).ScalarBaseMult(s)
+
+	return pointFromEdwards(q), q
+}
+
+// testLowOrderPoint is a point of order 8 on edwards25519.
+var testLowOrderPoint = func() *edwards25519.Point {
+	b, err := hex.DecodeString("26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05")
+	if err != nil {
+		panic(err)
+	}
+	p, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}()
+
+func pointFromEdwards(q *edwards25519.Point) *Point {
+	return new(Point).SetEdwardsPoint(q)
+}
+
+func requirePointsEqual(t *testing.T, what string, p, q *Point) {
+	if p.isIdentity != q.isIdentity || p.u.Equal(&q.u) != 1 || p.v.Equal(&q.v) != 1 {
+		t.Fatalf("%s: points not equal", what)
+	}
+}
+
+func TestPointCompressed(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		for i := 0; i < 64; i++ {
+			p, _ := newRandomPoint(t)
+
+			b := p.MarshalCompressed()
+			if len(b) != CompressedSize {
+				t.Fatalf("MarshalCompressed: unexpected length: %d", len(b))
+			}
+			if !bytes.Equal(b[:31], p.u.Bytes()[:31]) || int(b[31]>>7) != p.v.IsNegative() {
+				t.Fatalf("MarshalCompressed: unexpected encoding: %x", b)
+			}
+
+			var p2 Point
+			if err := p2.UnmarshalCompressed(b); err != nil {
+				t.Fatalf("UnmarshalCompressed: %v", err)
+			}
+			if p2.u.Equal(&p.u) != 1 || p2.v.Equal(&p.v) != 1 || p2.isIdentity != 0 {
+				t.Fatalf("UnmarshalCompressed(MarshalCompressed(p)) != p")
+			}
+		}
+	})
+	t.Run("Identity", func(t *testing.T) {
+		b := NewIdentityPoint().MarshalCompressed()
+		if !bytes.Equal(b, identityCompressed[:]) {
+			t.Fatalf("MarshalCompressed(identity): unexpected encoding: %x", b)
+		}
+
+		p, _ := newRandomPoint(t)
+		if err := p.UnmarshalCompressed(b); err != nil {
+			t.Fatalf("UnmarshalCompressed(identity): %v", err)
+		}
+		if p.isIdentity != 1 || p.u.Equal(new(field.Element)) != 1 || p.v.Equal(new(field.Element)) != 1 {
+			t.Fatalf("UnmarshalCompressed(identity) != identity")
+		}
+	})
+	t.Run("OrderTwo", func(t *testing.T) {
+		var p Point
+		if err := p.UnmarshalCompressed(make([]byte, CompressedSize)); err != nil {
+			t.Fatalf("UnmarshalCompressed(order two): %v", err)
+		}
+		if p.isIdentity != 0 {
+			t.Fatalf("UnmarshalCompressed(order two) == identity")
+		}
+		if b := p.MarshalCompressed(); !bytes.Equal(b, make([]byte, CompressedSize)) {
+			t.Fatalf("MarshalCompressed(order two): unexpected encoding: %x", b)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		p, _ := newRandomPoint(t)
+		orig := *p
+
+		var twist [CompressedSize]byte
+		twist[0] = 2 // u = 2 is on the twist.
+
+		nonCanonical := bytes.Repeat([]byte{0xff}, CompressedSize)
+		nonCanonical[0] = 0xee // p + 1
+		nonCanonical[31] = 0x7f
+
+		for _, v := range []struct {
+			name string
+			b    []byte
+		}{
+			{"Short", make([]byte, CompressedSize-1)},
+			{"Long", make([]byte, CompressedSize+1)},
+			{"Twist", twist[:]},
+			{"NonCanonical", nonCanonical},
+		} {
+			err := p.UnmarshalCompressed(v.b)
+			if !errors.Is(err, ErrInvalidEncoding) {
+				t.Fatalf("UnmarshalCompressed(%s): unexpected error: %v", v.name, err)
+			}
+			if *p != orig {
+				t.Fatalf("UnmarshalCompressed(%s): modified point on failure", v.name)
+			}
+		}
+	})
+}
+
+func TestPointArithmetic(t *testing.T) {
+	// Build up a set of test points that covers the exceptional cases,
+	// with the corresponding edwards25519 points as a reference.
+	var edPoints []*edwards25519.Point
+	torsion := edwards25519.NewIdentityPoint()
+	for i := 0; i < 8; i++ {
+		edPoints = append(edPoints, new(edwards25519.Point).Set(torsion))
+		torsion.Add(torsion, testLowOrderPoint)
+	}
+	for i := 0; i < 8; i++ {
+		_, q := newRandomPoint(t)
+		edPoints = append(edPoints, q)
+		edPoints = append(edPoints, new(edwards25519.Point).Negate(q))
+		edPoints = append(edPoints, new(edwards25519.Point).Add(q, edPoints[i]))
+	}
+
+	for i, q := range edPoints {
+		p := pointFromEdwards(q)
+
+		var negP Point
+		requirePointsEqual(t, "Negate", negP.Negate(p), pointFromEdwards(new(edwards25519.Point).Negate(q)))
+
+		var dblP Point
+		requirePointsEqual(t, "Double", dblP.Double(p), pointFromEdwards(new(edwards25519.Point).Add(q, q)))
+
+		for j, r := range edPoints {
+			expected := pointFromEdwards(new(edwards25519.Point).Add(q, r))
+
+			var sum Point
+			sum.Add(p, pointFromEdwards(r))
+			requirePointsEqual(t, "Add", &sum, expected)
+			if i == j {
+				requirePointsEqual(t, "Add(p, p)", &sum, &dblP)
+			}
+		}
+
+		// Aliasing.
+		sum := new(Point).Set(p)
+		sum.Add(sum, sum)
+		requirePointsEqual(t, "Add(aliased)", sum, &dblP)
+	}
+}
+
+func TestLadderStep(t *testing.T) {
+	for i := 0; i < 32; i++ {
+		_, q := newRandomPoint(t)
+		_, r := newRandomPoint(t)
+
+		uP, _ := FromEdwardsPoint(q)
+		uQ, _ := FromEdwardsPoint(r)
+		uDiff, _ := FromEdwardsPoint(new(edwards25519.Point).Subtract(r, q))
+		expectedDbl, _ := FromEdwardsPoint(new(edwards25519.Point).Add(q, q))
+		expectedSum, _ := FromEdwardsPoint(new(edwards25519.Point).Add(q, r))
+
+		uDbl, uSum := LadderStep(uP, uQ, uDiff)
+		if uDbl.Equal(expectedDbl) != 1 {
+			t.Fatalf("LadderStep: u(2P) mismatch")
+		}
+		if uSum.Equal(expectedSum) != 1 {
+			t.Fatalf("LadderStep: u(P+Q) mismatch")
+		}
+	}
+}
+
+func TestPointBytes(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		for i := 0; i < 32; i++ {
+			p, _ := newRandomPoint(t)
+
+			b := p.Bytes()
+			if !bytes.Equal(b, p.u.Bytes()) {
+				t.Fatalf("Bytes: unexpected encoding: %x", b)
+			}
+
+			var q Point
+			if _, err := q.SetBytes(b); err != nil {
+				t.Fatalf("SetBytes: %v", err)
+			}
+			if q.v.IsNegative() != 0 {
+				t.Fatalf("SetBytes: v is negative")
+			}
+
+			var negP Point
+			negP.Negate(p)
+			if q.Equal(p)|q.Equal(&negP) != 1 {
+				t.Fatalf("SetBytes(p.Bytes()) != +-p")
+			}
+			if p.Equal(&negP) == 1 {
+				t.Fatalf("p == -p")
+			}
+		}
+	})
+	t.Run("Equal", func(t *testing.T) {
+		var orderTwo Point
+		if _, err := orderTwo.SetBytes(make([]byte, CompressedSize)); err != nil {
+			t.Fatalf("SetBytes(0): %v", err)
+		}
+		identity := NewIdentityPoint()
+		if orderTwo.Equal(identity) != 0 {
+			t.Fatalf("order two point == identity")
+		}
+		if identity.Equal(NewIdentityPoint()) != 1 {
+			t.Fatalf("identity != identity")
+		}
+		if !bytes.Equal(identity.Bytes(), orderTwo.Bytes()) {
+			t.Fatalf("Bytes(identity) != Bytes(order two point)")
+		}
+	})
+	t.Run("Flags", func(t *testing.T) {
+		p, _ := newRandomPoint(t)
+		orig := *p
+
+		// Set the most significant bit, which X25519 ignores.
+		nonCanonical := p.u.Bytes()
+		nonCanonical[31] |= 0x80
+		if _, err := p.SetBytes(nonCanonical); !errors.Is(err, ErrInvalidEncoding) {
+			t.Fatalf("SetBytes(nonCanonical): unexpected error: %v", err)
+		}
+		if *p != orig {
+			t.Fatalf("SetBytes(nonCanonical): modified point on failure")
+		}
+		var q Point
+		if _, err := q.SetBytesWithFlags(nonCanonical, DecodeAllowNonCanonical); err != nil {
+			t.Fatalf("SetBytesWithFlags(nonCanonical, DecodeAllowNonCanonical): %v", err)
+		}
+		if q.u.Equal(&p.u) != 1 {
+			t.Fatalf("SetBytesWithFlags(nonCanonical, DecodeAllowNonCanonical): unexpected u")
+		}
+
+		for _, u := range smallOrderUs {
+			b := u.Bytes()
+			_, err := q.SetBytesWithFlags(b, DecodeRejectSmallOrder)
+			if !errors.Is(err, ErrInvalidEncoding) {
+				t.Fatalf("SetBytesWithFlags(%x, DecodeRejectSmallOrder): unexpected error: %v", b, err)
+			}
+		}
+		if _, err := q.SetBytesWithFlags(p.Bytes(), DecodeRejectSmallOrder|DecodeAllowNonCanonical); err != nil {
+			t.Fatalf("SetBytesWithFlags(random, all): %v", err)
+		}
+
+		var twist [CompressedSize]byte
+		twist[0] = 2
+		if _, err := q.SetBytesWithFlags(twist[:], DecodeAllowNonCanonical); !errors.Is(err, ErrInvalidEncoding) {
+			t.Fatalf("SetBytesWithFlags(twist): unexpected error: %v", err)
+		}
+	})
+}
+
+func TestPointEdwards(t *testing.T) {
+	var edPoints []*edwards25519.Point
+	torsion := edwards25519.NewIdentityPoint()
+	for i := 0; i < 8; i++ {
+		edPoints = append(edPoints, new(edwards25519.Point).Set(torsion))
+		torsion.Add(torsion, testLowOrderPoint)
+	}
+	for i := 0; i < 16; i++ {
+		_, q := newRandomPoint(t)
+		edPoints = append(edPoints, q, new(edwards25519.Point).Add(q, testLowOrderPoint))
+	}
+
+	for i, q := range edPoints {
+		p := new(Point).SetEdwardsPoint(q)
+
+		u, v := FromEdwardsPoint(q)
+		if p.u.Equal(u) != 1 || p.v.Equal(v) != 1 {
+			t.Fatalf("SetEdwardsPoint(q) != FromEdwardsPoint(q)")
+		}
+		if !IsOnCurve(&p.u, &p.v) {
+			t.Fatalf("SetEdwardsPoint(q) not on curve")
+		}
+
+		if p.EdwardsPoint().Equal(q) != 1 {
+			t.Fatalf("EdwardsPoint(SetEdwardsPoint(q)) != q (%d)", i)
+		}
+	}
+
+	// The exceptional cases.
+	if NewIdentityPoint().EdwardsPoint().Equal(edwards25519.NewIdentityPoint()) != 1 {
+		t.Fatalf("EdwardsPoint(identity) != identity")
+	}
+	var orderTwo Point
+	if _, err := orderTwo.SetUV(new(field.Element), new(field.Element)); err != nil {
+		t.Fatalf("SetUV(0, 0): %v", err)
+	}
+	edOrderTwo := orderTwo.EdwardsPoint()
+	if edOrderTwo.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		t.Fatalf("EdwardsPoint(order two) == identity")
+	}
+	if new(edwards25519.Point).Add(edOrderTwo, edOrderTwo).Equal(edwards25519.NewIdentityPoint()) != 1 {
+		t.Fatalf("2 * EdwardsPoint(order two) != identity")
+	}
+
+	// u = -1 (on the twist) can not be a valid Point, but is mapped to
+	// the identity element.
+	var bogus Point
+	bogus.u.Negate(new(field.Element).One())
+	if bogus.EdwardsPoint().Equal(edwards25519.NewIdentityPoint()) != 1 {
+		t.Fatalf("EdwardsPoint(u = -1) != identity")
+	}
+	if _, err := ToEdwardsPoint(&bogus.u, &bogus.v); !errors.Is(err, ErrNotOnCurve) {
+		t.Fatalf("ToEdwardsPoint(u = -1): unexpected error: %v", err)
+	}
+}
+
+func TestPointSelect(t *testing.T) {
+	a, _ := newRandomPoint(t)
+	b := NewIdentityPoint()
+
+	var p Point
+	if p.Select(a, b, 1).Equal(a) != 1 {
+		t.Fatalf("Select(a, b, 1) != a")
+	}
+	if p.Select(a, b, 0).Equal(b) != 1 {
+		t.Fatalf("Select(a, b, 0) != b")
+	}
+
+	p.Set(a)
+	if p.CondAssign(b, 0).Equal(a) != 1 {
+		t.Fatalf("CondAssign(b, 0) modified p")
+	}
+	if p.CondAssign(b, 1).Equal(b) != 1 {
+		t.Fatalf("CondAssign(b, 1) != b")
+	}
+
+	// Aliasing.
+	p.Set(a)
+	if p.Select(&p, b, 1).Equal(a) != 1 {
+		t.Fatalf("Select(p, b, 1) != p")
+	}
+	if p.Select(b, &p, 0).Equal(a) != 1 {
+		t.Fatalf("Select(b, p, 0) != p")
+	}
+}
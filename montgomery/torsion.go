@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"crypto/subtle"
+
+	"filippo.io/edwards25519"
+)
+
+// TorsionOrder is the order of the torsion subgroup of edwards25519
+// (the cofactor).
+const TorsionOrder = 8
+
+var (
+	// torsionPoints are the points of the torsion subgroup, where
+	// torsionPoints[i] = i * torsionPoints[1].
+	torsionPoints = func() [TorsionOrder]*edwards25519.Point {
+		generator, err := new(edwards25519.Point).SetBytes([]byte{
+			0x26, 0xe8, 0x95, 0x8f, 0xc2, 0xb2, 0x27, 0xb0,
+			0x45, 0xc3, 0xf4, 0x89, 0xf2, 0xef, 0x98, 0xf0,
+			0xd5, 0xdf, 0xac, 0x05, 0xd3, 0xc6, 0x33, 0x39,
+			0xb1, 0x38, 0x02, 0x88, 0x6d, 0x53, 0xfc, 0x05,
+		})
+		if err != nil {
+			panic("montgomery: failed to deserialize torsion generator: " + err.Error())
+		}
+
+		var points [TorsionOrder]*edwards25519.Point
+		points[0] = edwards25519.NewIdentityPoint()
+		for i := 1; i < TorsionOrder; i++ {
+			points[i] = new(edwards25519.Point).Add(points[i-1], generator)
+		}
+		return points
+	}()
+
+	// invEight is 8^-1 mod l.
+	invEight = func() *edwards25519.Scalar {
+		s, err := edwards25519.NewScalar().SetCanonicalBytes([]byte{
+			0x79, 0x2f, 0xdc, 0xe2, 0x29, 0xe5, 0x06, 0x61,
+			0xd0, 0xda, 0x1c, 0x7d, 0xb3, 0x9d, 0xd3, 0x07,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x06,
+		})
+		if err != nil {
+			panic("montgomery: failed to deserialize 1/8: " + err.Error())
+		}
+		return s
+	}()
+)
+
+// TorsionPoint returns the point `index * T`, where T is the
+// edwards25519 point of order 8 with the canonical encoding
+// `26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05`,
+// which generates the torsion subgroup.  index MUST be in the range
+// [0, TorsionOrder).
+func TorsionPoint(index int) *edwards25519.Point {
+	if index < 0 || index >= TorsionOrder {
+		panic("montgomery: invalid torsion index")
+	}
+	return new(edwards25519.Point).Set(torsionPoints[index])
+}
+
+// DecomposeTorsion splits the edwards25519 point p into the component in
+// the prime order subgroup, and the component in the torsion subgroup,
+// such that `p = primeOrder + torsion`, and `torsion = TorsionPoint(index)`,
+// in constant time.
+//
+// A point is in the prime order subgroup iff index is 0.
+func DecomposeTorsion(p *edwards25519.Point) (primeOrder, torsion *edwards25519.Point, index int) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var points [2]edwards25519.Point
+	index = decomposeTorsion(&points, p)
+	return &points[0], &points[1], index
+}
+
+func decomposeTorsion(out *[2]edwards25519.Point, p *edwards25519.Point) int {
+	primeOrder, torsion := &out[0], &out[1]
+
+	// The prime order component is `(1/8 mod l) * 8 * p`, as
+	// multiplying by the cofactor clears the torsion component, and
+	// 1/8 mod l acts as the inverse of 8 on the prime order subgroup.
+	primeOrder.MultByCofactor(p)
+	primeOrder.ScalarMult(invEight, primeOrder)
+	torsion.Subtract(p, primeOrder)
+
+	index := 0
+	for i, t := range torsionPoints {
+		index = subtle.ConstantTimeSelect(torsion.Equal(t), i, index)
+	}
+
+	return index
+}
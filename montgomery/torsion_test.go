@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+func TestTorsion(t *testing.T) {
+	identity := edwards25519.NewIdentityPoint()
+
+	t.Run("TorsionPoint", func(t *testing.T) {
+		if TorsionPoint(1).Equal(testLowOrderPoint) != 1 {
+			t.Fatalf("TorsionPoint(1) != T8")
+		}
+		for i := 0; i < TorsionOrder; i++ {
+			p := TorsionPoint(i)
+			if new(edwards25519.Point).MultByCofactor(p).Equal(identity) != 1 {
+				t.Fatalf("8 * TorsionPoint(%d) != identity", i)
+			}
+			for j := 0; j < i; j++ {
+				if p.Equal(TorsionPoint(j)) == 1 {
+					t.Fatalf("TorsionPoint(%d) == TorsionPoint(%d)", i, j)
+				}
+			}
+		}
+
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("TorsionPoint(TorsionOrder) did not panic")
+			}
+		}()
+		_ = TorsionPoint(TorsionOrder)
+	})
+	t.Run("DecomposeTorsion", func(t *testing.T) {
+		for i := 0; i < 32; i++ {
+			_, q := newRandomPoint(t)
+			k := i % TorsionOrder
+
+			p := new(edwards25519.Point).Add(q, TorsionPoint(k))
+			primeOrder, torsion, index := DecomposeTorsion(p)
+			if index != k {
+				t.Fatalf("DecomposeTorsion: index %d, expected %d", index, k)
+			}
+			if primeOrder.Equal(q) != 1 {
+				t.Fatalf("DecomposeTorsion: prime order component mismatch")
+			}
+			if torsion.Equal(TorsionPoint(k)) != 1 {
+				t.Fatalf("DecomposeTorsion: torsion component mismatch")
+			}
+		}
+
+		for i := 0; i < TorsionOrder; i++ {
+			primeOrder, _, index := DecomposeTorsion(TorsionPoint(i))
+			if index != i || primeOrder.Equal(identity) != 1 {
+				t.Fatalf("DecomposeTorsion(TorsionPoint(%d)): index %d", i, index)
+			}
+		}
+	})
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"filippo.io/edwards25519/field"
+)
+
+// ScalarSize is the size of a X25519 scalar in bytes.
+const ScalarSize = 32
+
+// ClampScalar returns the clamped X25519 scalar corresponding to the
+// scalar s, per RFC 7748.  The three least significant bits and the
+// most significant bit are cleared, and the second most significant
+// bit is set.
+func ClampScalar(s [ScalarSize]byte) [ScalarSize]byte {
+	s[0] &= 248
+	s[31] &= 127
+	s[31] |= 64
+	return s
+}
+
+// ScalarMult returns the u-coordinate of `scalar * P`, where P is the
+// point with the u-coordinate u, computed with a constant-time
+// Montgomery ladder.  The scalar is clamped and interpreted per
+// RFC 7748, so this is equivalent to `X25519(scalar, u.Bytes())`
+// without the round trip through the byte encoding.
+//
+// Note that, like X25519, this does not reject low order points, and
+// the output for such points is 0.
+func ScalarMult(scalar []byte, u *field.Element) *field.Element {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var out field.Element
+	scalarMult(&out, scalar, u)
+	return &out
+}
+
+func scalarMult(out *field.Element, scalar []byte, u *field.Element) {
+	if len(scalar) != ScalarSize {
+		panic("montgomery: invalid scalar length")
+	}
+
+	var e [ScalarSize]byte
+	copy(e[:], scalar)
+	e = ClampScalar(e)
+
+	var x1, x2, z2, x3, z3, tmp0, tmp1 field.Element
+	x1.Set(u)
+	x2.One()
+	z2.Zero()
+	x3.Set(u)
+	z3.One()
+
+	swap := 0
+	for pos := 254; pos >= 0; pos-- {
+		b := int(e[pos/8]>>uint(pos&7)) & 1
+		swap ^= b
+		x2.Swap(&x3, swap)
+		z2.Swap(&z3, swap)
+		swap = b
+
+		ladderStep(&x2, &z2, &x3, &z3, &x1, &tmp0, &tmp1)
+	}
+	x2.Swap(&x3, swap)
+	z2.Swap(&z3, swap)
+
+	z2.Invert(&z2)
+	out.Multiply(&x2, &z2)
+
+	for i := range e {
+		e[i] = 0
+	}
+	for _, fe := range []*field.Element{&x1, &x2, &z2, &x3, &z3, &tmp0, &tmp1} {
+		fe.Zero()
+	}
+}
+
+// LadderStep performs a differential addition and doubling step of the
+// Montgomery ladder on u-coordinates, returning the u-coordinates of
+// 2*P and P+Q, given the u-coordinates of P, Q, and Q-P.  The point at
+// infinity is returned as u = 0, like with X25519.
+//
+// Note: The differential addition is undefined when Q-P is the point
+// of order 2 or the identity element (uDiff = 0), and the u-coordinate
+// of P+Q returned in that case is 0.
+func LadderStep(uP, uQ, uDiff *field.Element) (*field.Element, *field.Element) {
+	// This function is outlined to make the allocations inline in the
+	// caller rather than happen on the heap.
+	var out [2]field.Element
+	affineLadderStep(&out, uP, uQ, uDiff)
+	return &out[0], &out[1]
+}
+
+func affineLadderStep(out *[2]field.Element, uP, uQ, uDiff *field.Element) {
+	var x2, z2, x3, z3, x1, tmp0, tmp1 field.Element
+	x2.Set(uP)
+	z2.One()
+	x3.Set(uQ)
+	z3.One()
+	x1.Set(uDiff)
+
+	ladderStep(&x2, &z2, &x3, &z3, &x1, &tmp0, &tmp1)
+
+	out[0].Invert(&z2)
+	out[0].Multiply(&out[0], &x2)
+	out[1].Invert(&z3)
+	out[1].Multiply(&out[1], &x3)
+}
+
+// ladderStep performs a combined differential addition and doubling,
+// setting (x2:z2) to 2*(x2:z2), and (x3:z3) to (x2:z2) + (x3:z3), given
+// the difference x1 = (x3:z3) - (x2:z2) in affine form.
+func ladderStep(x2, z2, x3, z3, x1, tmp0, tmp1 *field.Element) {
+	// This is the ladder step used by ref10 (and the generic
+	// golang.org/x/crypto/curve25519 implementation), with
+	// a24 = (A + 2) / 4 = 121666.
+	tmp0.Subtract(x3, z3)
+	tmp1.Subtract(x2, z2)
+	x2.Add(x2, z2)
+	z2.Add(x3, z3)
+	z3.Multiply(tmp0, x2)
+	z2.Multiply(z2, tmp1)
+	tmp0.Square(tmp1)
+	tmp1.Square(x2)
+	x3.Add(z3, z2)
+	z2.Subtract(z3, z2)
+	x2.Multiply(tmp1, tmp0)
+	tmp1.Subtract(tmp1, tmp0)
+	z2.Square(z2)
+	z3.Mult32(tmp1, 121666)
+	x3.Square(x3)
+	tmp0.Add(tmp0, z3)
+	z3.Multiply(x1, z2)
+	z2.Multiply(tmp1, tmp0)
+}
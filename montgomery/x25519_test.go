@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package montgomery
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"filippo.io/edwards25519/field"
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestScalarMult(t *testing.T) {
+	var scalar, uBytes [32]byte
+	for i := 0; i < 64; i++ {
+		if _, err := rand.Read(scalar[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		if _, err := rand.Read(uBytes[:]); err != nil {
+			t.Fatalf("rand.Read: %v", err)
+		}
+		uBytes[31] &= 0x7f
+
+		u, err := new(field.Element).SetBytes(uBytes[:])
+		if err != nil {
+			t.Fatalf("SetBytes: %v", err)
+		}
+
+		expected, err := curve25519.X25519(scalar[:], u.Bytes())
+		if err != nil {
+			// Low order input, x/crypto rejects the all-zero output.
+			expected = make([]byte, 32)
+		}
+		if got := ScalarMult(scalar[:], u).Bytes(); !bytes.Equal(got, expected) {
+			t.Fatalf("ScalarMult(%x, %x): got %x, expected %x", scalar, uBytes, got, expected)
+		}
+	}
+
+	// RFC 7748 5.2 test vector.
+	vecScalar, _ := hex.DecodeString("a546e36bf0527c9d3b16154b82465edd62144c0ac1fc5a18506a2244ba449ac4")
+	vecU, _ := hex.DecodeString("e6db6867583030db3594c1a424b15f7c726624ec26b3353b10a903a6d0ab1c4c")
+	vecExpected, _ := hex.DecodeString("c3da55379de9c6908e94ea4df28d084f32eccf03491c71f754b4075577a28552")
+	u, err := new(field.Element).SetBytes(vecU)
+	if err != nil {
+		t.Fatalf("SetBytes: %v", err)
+	}
+	if got := ScalarMult(vecScalar, u).Bytes(); !bytes.Equal(got, vecExpected) {
+		t.Fatalf("ScalarMult(RFC 7748): got %x, expected %x", got, vecExpected)
+	}
+
+	// Basepoint.
+	nine := mustSetUint64(new(field.Element), 9)
+	if _, err := rand.Read(scalar[:]); err != nil {
+		t.Fatalf("rand.Read: %v", err)
+	}
+	expected, _ := curve25519.X25519(scalar[:], curve25519.Basepoint)
+	if got := ScalarMult(scalar[:], nine).Bytes(); !bytes.Equal(got, expected) {
+		t.Fatalf("ScalarMult(%x, 9): got %x, expected %x", scalar, got, expected)
+	}
+}
+
+func TestClampScalar(t *testing.T) {
+	var s [ScalarSize]byte
+	for i := range s {
+		s[i] = 0xff
+	}
+	clamped := ClampScalar(s)
+	if clamped[0] != 0xf8 || clamped[31] != 0x7f {
+		t.Fatalf("ClampScalar(0xff...): %x", clamped)
+	}
+	if !bytes.Equal(clamped[1:31], s[1:31]) {
+		t.Fatalf("ClampScalar: modified the middle bytes")
+	}
+	if s[0] != 0xff {
+		t.Fatalf("ClampScalar: modified the input")
+	}
+
+	clamped = ClampScalar([ScalarSize]byte{})
+	if clamped[0] != 0 || clamped[31] != 0x40 {
+		t.Fatalf("ClampScalar(0): %x", clamped)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package onion
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"golang.org/x/crypto/sha3"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/signing"
+)
+
+// ExpandedPrivateKeySize is the size of an expanded private key in bytes.
+const ExpandedPrivateKeySize = 64
+
+var (
+	// ErrInvalidPublicKey is the error returned when a public key is
+	// malformed, or not in the prime order subgroup.
+	ErrInvalidPublicKey = errors.New("onion: invalid public key")
+
+	// ErrInvalidPrivateKey is the error returned when an expanded private
+	// key is malformed.
+	ErrInvalidPrivateKey = errors.New("onion: invalid private key")
+
+	// BLIND_STRING = "Derive temporary signing key" | INT_1(0)
+	blindString = []byte("Derive temporary signing key\x00")
+
+	// RH_BLIND_STRING = "Derive temporary signing key hash input"
+	rhBlindString = []byte("Derive temporary signing key hash input")
+
+	keyBlindLabel = []byte("key-blind")
+
+	// B = "(1511[...]2202, 4631[...]5960)", the edwards25519 base point.
+	basePointString = []byte("(15112221349535400772501151409588531511454012693041857206046113283949847762202, 46316835694926478169428394003475163141307993866256225615783033603165251855960)")
+)
+
+// ExpandPrivateKey returns the ExpandedPrivateKeySize-byte expanded form
+// of the private key (`a || RH`), as used by Tor to store onion service
+// identity keys.
+func ExpandPrivateKey(sk ed25519.PrivateKey) []byte {
+	if len(sk) != ed25519.PrivateKeySize {
+		panic("onion: bad private key length")
+	}
+
+	extsk := sha512.Sum512(sk.Seed())
+	extsk[0] &= 248
+	extsk[31] &= 63
+	extsk[31] |= 64
+
+	return extsk[:]
+}
+
+// BlindedPrivateKey is an onion service identity private key blinded
+// for a time period.
+type BlindedPrivateKey struct {
+	s         edwards25519.Scalar
+	prefix    [signing.PrefixSize]byte
+	publicKey [ed25519.PublicKeySize]byte
+}
+
+// BlindPublicKey returns the identity public key blinded for the time
+// period, with the time period length periodLength minutes (eg:
+// DefaultPeriodLength), and the optional secret.  As with Tor, the
+// identity element, and public keys that are not in the prime order
+// subgroup are rejected.
+func BlindPublicKey(pk ed25519.PublicKey, timePeriod, periodLength uint64, secret []byte) (ed25519.PublicKey, error) {
+	A, err := decodePublicKey(pk)
+	if err != nil {
+		return nil, err
+	}
+
+	hBytes := blindingFactor(pk, timePeriod, periodLength, secret)
+	h, err := edwards25519.NewScalar().SetBytesWithClamping(hBytes[:])
+	if err != nil {
+		panic("onion: failed to deserialize blinding factor: " + err.Error())
+	}
+
+	// A' = h A
+	return ed25519.PublicKey(A.ScalarMult(h, A).Bytes()), nil
+}
+
+// BlindPrivateKey returns the identity private key blinded for the time
+// period, with the time period length periodLength minutes (eg:
+// DefaultPeriodLength), and the optional secret.
+func BlindPrivateKey(sk ed25519.PrivateKey, timePeriod, periodLength uint64, secret []byte) *BlindedPrivateKey {
+	extsk := ExpandPrivateKey(sk)
+	defer func() {
+		for i := range extsk {
+			extsk[i] = 0
+		}
+	}()
+
+	bsk, err := BlindExpandedPrivateKey(extsk, timePeriod, periodLength, secret)
+	if err != nil {
+		panic("onion: failed to blind expanded private key: " + err.Error())
+	}
+	return bsk
+}
+
+// BlindExpandedPrivateKey returns the expanded identity private key
+// blinded for the time period, with the time period length periodLength
+// minutes (eg: DefaultPeriodLength), and the optional secret.
+func BlindExpandedPrivateKey(extsk []byte, timePeriod, periodLength uint64, secret []byte) (*BlindedPrivateKey, error) {
+	if len(extsk) != ExpandedPrivateKeySize {
+		return nil, fmt.Errorf("%w: invalid length: %d", ErrInvalidPrivateKey, len(extsk))
+	}
+	a, err := edwards25519.NewScalar().SetBytesWithClamping(extsk[:32])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+	}
+	pk := edwards25519.NewIdentityPoint().ScalarBaseMult(a).Bytes()
+
+	hBytes := blindingFactor(pk, timePeriod, periodLength, secret)
+	h, err := edwards25519.NewScalar().SetBytesWithClamping(hBytes[:])
+	if err != nil {
+		panic("onion: failed to deserialize blinding factor: " + err.Error())
+	}
+
+	// a' = h a mod l
+	var bsk BlindedPrivateKey
+	bsk.s.Multiply(h, a)
+	copy(bsk.publicKey[:], edwards25519.NewIdentityPoint().ScalarBaseMult(&bsk.s).Bytes())
+
+	// RH' = SHA-512(RH_BLIND_STRING | RH)[:32]
+	hash := sha512.New()
+	_, _ = hash.Write(rhBlindString)
+	_, _ = hash.Write(extsk[32:])
+	copy(bsk.prefix[:], hash.Sum(nil))
+
+	a.Set(edwards25519.NewScalar())
+
+	return &bsk, nil
+}
+
+// Public returns the blinded public key.
+func (bsk *BlindedPrivateKey) Public() ed25519.PublicKey {
+	return append(ed25519.PublicKey{}, bsk.publicKey[:]...)
+}
+
+// Bytes returns the ExpandedPrivateKeySize-byte expanded form of the
+// blinded private key (`a' || RH'`).
+func (bsk *BlindedPrivateKey) Bytes() []byte {
+	b := make([]byte, 0, ExpandedPrivateKeySize)
+	b = append(b, bsk.s.Bytes()...)
+	b = append(b, bsk.prefix[:]...)
+	return b
+}
+
+// Sign signs the message with the blinded private key.  The signature
+// verifies under the blinded public key with ed25519.Verify.
+func (bsk *BlindedPrivateKey) Sign(message []byte) []byte {
+	return signing.Sign(&bsk.s, bsk.prefix[:], bsk.publicKey[:], message)
+}
+
+// blindingFactor returns the clamped blinding factor h.
+func blindingFactor(pk []byte, timePeriod, periodLength uint64, secret []byte) [32]byte {
+	// N = "key-blind" | INT_8(period-number) | INT_8(period_length)
+	var n [8 + 8]byte
+	binary.BigEndian.PutUint64(n[:8], timePeriod)
+	binary.BigEndian.PutUint64(n[8:], periodLength)
+
+	// h = H(BLIND_STRING | A | s | B | N)
+	var hBytes [32]byte
+	h := sha3.New256()
+	_, _ = h.Write(blindString)
+	_, _ = h.Write(pk)
+	_, _ = h.Write(secret)
+	_, _ = h.Write(basePointString)
+	_, _ = h.Write(keyBlindLabel)
+	_, _ = h.Write(n[:])
+	h.Sum(hBytes[:0])
+
+	hBytes[0] &= 248
+	hBytes[31] &= 63
+	hBytes[31] |= 64
+
+	return hBytes
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package onion
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	certVersion              = 0x01
+	certTypeDescSigningKey   = 0x08
+	certKeyTypeEd25519       = 0x01
+	certExtSignedWithEd25519 = 0x04
+
+	certHeaderSize = 1 + 1 + 4 + 1 + ed25519.PublicKeySize + 1
+	certExtSize    = 2 + 1 + 1 + ed25519.PublicKeySize
+
+	// DescriptorSigningKeyCertificateSize is the size of a descriptor
+	// signing key certificate in bytes.
+	DescriptorSigningKeyCertificateSize = certHeaderSize + certExtSize + ed25519.SignatureSize
+)
+
+// ErrInvalidCertificate is the error returned when a descriptor signing
+// key certificate is malformed, or fails to verify.
+var ErrInvalidCertificate = errors.New("onion: invalid certificate")
+
+// DescriptorSigningKeyCertificate returns a certificate (cert-spec type
+// 0x08) for the descriptor signing public key, signed by the blinded
+// private key, that expires at expiration, rounded up to the hour.
+func (bsk *BlindedPrivateKey) DescriptorSigningKeyCertificate(descSigningKey ed25519.PublicKey, expiration time.Time) []byte {
+	if len(descSigningKey) != ed25519.PublicKeySize {
+		panic("onion: bad descriptor signing key length")
+	}
+
+	// EXPIRATION_DATE is in hours since the epoch, rounded up.
+	expirationHours := (expiration.Unix() + 3599) / 3600
+
+	cert := make([]byte, 0, DescriptorSigningKeyCertificateSize)
+	cert = append(cert, certVersion, certTypeDescSigningKey)
+	cert = append(cert, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(cert[2:], uint32(expirationHours))
+	cert = append(cert, certKeyTypeEd25519)
+	cert = append(cert, descSigningKey...)
+
+	// N_EXTENSIONS = 1, signed-with-ed25519-key
+	cert = append(cert, 1)
+	cert = append(cert, 0, ed25519.PublicKeySize, certExtSignedWithEd25519, 0)
+	cert = append(cert, bsk.publicKey[:]...)
+
+	// SIGNATURE covers all of the preceding fields.
+	return append(cert, bsk.Sign(cert)...)
+}
+
+// VerifyDescriptorSigningKeyCertificate verifies a descriptor signing
+// key certificate produced by DescriptorSigningKeyCertificate against
+// the blinded public key, and returns the descriptor signing public key,
+// and the expiration time.
+//
+// Note: It is the caller's responsibility to check the expiration time.
+func VerifyDescriptorSigningKeyCertificate(blindedPk ed25519.PublicKey, cert []byte) (ed25519.PublicKey, time.Time, error) {
+	if len(cert) != DescriptorSigningKeyCertificateSize {
+		return nil, time.Time{}, fmt.Errorf("%w: invalid length: %d", ErrInvalidCertificate, len(cert))
+	}
+	if len(blindedPk) != ed25519.PublicKeySize {
+		return nil, time.Time{}, fmt.Errorf("%w: invalid length: %d", ErrInvalidPublicKey, len(blindedPk))
+	}
+
+	ext := cert[certHeaderSize : certHeaderSize+certExtSize]
+	switch {
+	case cert[0] != certVersion:
+		return nil, time.Time{}, fmt.Errorf("%w: unsupported version: %d", ErrInvalidCertificate, cert[0])
+	case cert[1] != certTypeDescSigningKey:
+		return nil, time.Time{}, fmt.Errorf("%w: unsupported type: %d", ErrInvalidCertificate, cert[1])
+	case cert[6] != certKeyTypeEd25519:
+		return nil, time.Time{}, fmt.Errorf("%w: unsupported key type: %d", ErrInvalidCertificate, cert[6])
+	case cert[certHeaderSize-1] != 1:
+		return nil, time.Time{}, fmt.Errorf("%w: unsupported extensions", ErrInvalidCertificate)
+	case binary.BigEndian.Uint16(ext[0:2]) != ed25519.PublicKeySize || ext[2] != certExtSignedWithEd25519:
+		return nil, time.Time{}, fmt.Errorf("%w: unsupported extensions", ErrInvalidCertificate)
+	case string(ext[4:]) != string(blindedPk):
+		return nil, time.Time{}, fmt.Errorf("%w: signing key mismatch", ErrInvalidCertificate)
+	}
+
+	body := cert[:len(cert)-ed25519.SignatureSize]
+	if !ed25519.Verify(blindedPk, body, cert[len(body):]) {
+		return nil, time.Time{}, fmt.Errorf("%w: invalid signature", ErrInvalidCertificate)
+	}
+
+	descSigningKey := append(ed25519.PublicKey{}, cert[7:7+ed25519.PublicKeySize]...)
+	expiration := time.Unix(int64(binary.BigEndian.Uint32(cert[2:6]))*3600, 0)
+
+	return descSigningKey, expiration, nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package onion implements the edwards25519 related cryptography used by
+// Tor v3 onion services, as specified in rend-spec-v3 (now the "Tor
+// Rendezvous Specification - Version 3"): time periods, onion addresses,
+// identity key blinding, subcredentials, and descriptor signing key
+// certificates.
+package onion
+
+import (
+	"crypto/ed25519"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"filippo.io/edwards25519"
+	"golang.org/x/crypto/sha3"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/scalar"
+)
+
+const (
+	// DefaultPeriodLength is the default time period length, in minutes.
+	DefaultPeriodLength = 1440
+
+	// AddressVersion is the onion address version.
+	AddressVersion = 0x03
+
+	// AddressSuffix is the onion address suffix.
+	AddressSuffix = ".onion"
+
+	// rotationOffset is the offset of the start of time periods from
+	// the epoch, in minutes.
+	rotationOffset = 12 * 60
+
+	addressChecksumSize = 2
+	addressLength       = 56
+)
+
+var (
+	// ErrInvalidAddress is the error returned when an onion address is
+	// malformed.
+	ErrInvalidAddress = errors.New("onion: invalid address")
+
+	addressChecksumLabel = []byte(".onion checksum")
+	credentialLabel      = []byte("credential")
+	subcredentialLabel   = []byte("subcredential")
+
+	addressEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	scalarMinusOne = edwards25519.NewScalar().Negate(scalar.One())
+)
+
+// TimePeriod returns the number of the time period of length
+// periodLength minutes (eg: DefaultPeriodLength) that t falls in.  Time
+// periods start 12 hours after the epoch, so t MUST be later than that.
+func TimePeriod(t time.Time, periodLength uint64) uint64 {
+	if periodLength == 0 {
+		panic("onion: invalid period length")
+	}
+
+	minutes := uint64(t.Unix()) / 60
+	return (minutes - rotationOffset) / periodLength
+}
+
+// Address returns the onion address (including AddressSuffix) of the
+// identity public key.
+func Address(pk ed25519.PublicKey) string {
+	if len(pk) != ed25519.PublicKeySize {
+		panic("onion: bad public key length")
+	}
+
+	// onion_address = base32(PUBKEY | CHECKSUM | VERSION) + ".onion"
+	checksum := addressChecksum(pk)
+	b := make([]byte, 0, ed25519.PublicKeySize+addressChecksumSize+1)
+	b = append(b, pk...)
+	b = append(b, checksum[:]...)
+	b = append(b, AddressVersion)
+
+	return strings.ToLower(addressEncoding.EncodeToString(b)) + AddressSuffix
+}
+
+// ParseAddress returns the identity public key of the onion address.
+// The AddressSuffix is optional.  As with Tor, addresses with an identity
+// public key that is the identity element, or that is not in the prime
+// order subgroup are rejected.
+func ParseAddress(address string) (ed25519.PublicKey, error) {
+	address = strings.TrimSuffix(address, AddressSuffix)
+	if len(address) != addressLength {
+		return nil, fmt.Errorf("%w: invalid length: %d", ErrInvalidAddress, len(address))
+	}
+	b, err := addressEncoding.DecodeString(strings.ToUpper(address))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+
+	pk := ed25519.PublicKey(b[:ed25519.PublicKeySize])
+	if b[len(b)-1] != AddressVersion {
+		return nil, fmt.Errorf("%w: unsupported version: %d", ErrInvalidAddress, b[len(b)-1])
+	}
+	if checksum := addressChecksum(pk); string(checksum[:]) != string(b[ed25519.PublicKeySize:ed25519.PublicKeySize+addressChecksumSize]) {
+		return nil, fmt.Errorf("%w: invalid checksum", ErrInvalidAddress)
+	}
+	if _, err = decodePublicKey(pk); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+
+	return pk, nil
+}
+
+// Subcredential returns the subcredential for the identity public key,
+// and the blinded public key for a time period.
+func Subcredential(pk, blindedPk ed25519.PublicKey) [32]byte {
+	// credential = H("credential" | public-identity-key)
+	h := sha3.New256()
+	_, _ = h.Write(credentialLabel)
+	_, _ = h.Write(pk)
+	credential := h.Sum(nil)
+
+	// subcredential = H("subcredential" | credential | blinded-public-key)
+	var subcredential [32]byte
+	h.Reset()
+	_, _ = h.Write(subcredentialLabel)
+	_, _ = h.Write(credential)
+	_, _ = h.Write(blindedPk)
+	h.Sum(subcredential[:0])
+
+	return subcredential
+}
+
+func addressChecksum(pk ed25519.PublicKey) [addressChecksumSize]byte {
+	// CHECKSUM = H(".onion checksum" | PUBKEY | VERSION)[:2]
+	h := sha3.New256()
+	_, _ = h.Write(addressChecksumLabel)
+	_, _ = h.Write(pk)
+	_, _ = h.Write([]byte{AddressVersion})
+
+	var checksum [addressChecksumSize]byte
+	copy(checksum[:], h.Sum(nil))
+	return checksum
+}
+
+func decodePublicKey(pk ed25519.PublicKey) (*edwards25519.Point, error) {
+	if len(pk) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: invalid length: %d", ErrInvalidPublicKey, len(pk))
+	}
+	A, err := edwards25519.NewIdentityPoint().SetBytes(pk)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPublicKey, err)
+	}
+
+	if A.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return nil, fmt.Errorf("%w: identity element", ErrInvalidPublicKey)
+	}
+
+	// A is in the prime order subgroup iff (l - 1) * A + A is the
+	// identity element.
+	q := edwards25519.NewIdentityPoint().ScalarMult(scalarMinusOne, A)
+	if q.Add(q, A).Equal(edwards25519.NewIdentityPoint()) != 1 {
+		return nil, fmt.Errorf("%w: not in prime order subgroup", ErrInvalidPublicKey)
+	}
+
+	return A, nil
+}
@@ -0,0 +1,307 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package onion
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"filippo.io/edwards25519"
+)
+
+func TestTimePeriod(t *testing.T) {
+	// From rend-spec-v3, "At 2016-04-13 11:00 UTC ... the current time
+	// period is 16903", and the next time period starts at 12:00 UTC.
+	for _, v := range []struct {
+		t      time.Time
+		period uint64
+	}{
+		{time.Date(2016, 4, 13, 11, 0, 0, 0, time.UTC), 16903},
+		{time.Date(2016, 4, 13, 11, 59, 59, 0, time.UTC), 16903},
+		{time.Date(2016, 4, 13, 12, 0, 0, 0, time.UTC), 16904},
+	} {
+		if got := TimePeriod(v.t, DefaultPeriodLength); got != v.period {
+			t.Fatalf("TimePeriod(%v): got %d, expected %d", v.t, got, v.period)
+		}
+	}
+}
+
+func TestAddress(t *testing.T) {
+	for _, address := range []string{
+		// rend-spec-v3 example address.
+		"pg6mmjiyjmcrsslvykfwnntlaru7p5svn6y2ymmju6nubxndf4pscryd.onion",
+		// DuckDuckGo.
+		"duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion",
+		// C-tor test_build_address (RFC 8032 TEST 1 public key).
+		"25njqamcweflpvkl73j4szahhihoc4xt3ktcgjnpaingr5yhkenl5sid.onion",
+	} {
+		pk, err := ParseAddress(address)
+		if err != nil {
+			t.Fatalf("ParseAddress(%s): %v", address, err)
+		}
+		if got := Address(pk); got != address {
+			t.Fatalf("Address(ParseAddress(%s)): got %s", address, got)
+		}
+		if _, err = ParseAddress(address[:len(address)-len(AddressSuffix)]); err != nil {
+			t.Fatalf("ParseAddress(%s, no suffix): %v", address, err)
+		}
+
+		// Corrupt the checksum.
+		bad := []byte(address)
+		bad[52] ^= 0x01
+		if _, err = ParseAddress(string(bad)); !errors.Is(err, ErrInvalidAddress) {
+			t.Fatalf("ParseAddress(bad checksum): unexpected error: %v", err)
+		}
+	}
+
+	if _, err := ParseAddress("duckduckgo.onion"); !errors.Is(err, ErrInvalidAddress) {
+		t.Fatalf("ParseAddress(v2 length): unexpected error: %v", err)
+	}
+}
+
+func TestBlinding(t *testing.T) {
+	pk, sk, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey: %v", err)
+	}
+	period := TimePeriod(time.Now(), DefaultPeriodLength)
+
+	blindedPk, err := BlindPublicKey(pk, period, DefaultPeriodLength, nil)
+	if err != nil {
+		t.Fatalf("BlindPublicKey: %v", err)
+	}
+	bsk := BlindPrivateKey(sk, period, DefaultPeriodLength, nil)
+
+	t.Run("Consistency", func(t *testing.T) {
+		if !bytes.Equal(bsk.Public(), blindedPk) {
+			t.Fatalf("BlindPrivateKey().Public() != BlindPublicKey()")
+		}
+
+		bsk2, err := BlindExpandedPrivateKey(ExpandPrivateKey(sk), period, DefaultPeriodLength, nil)
+		if err != nil {
+			t.Fatalf("BlindExpandedPrivateKey: %v", err)
+		}
+		if !bytes.Equal(bsk2.Bytes(), bsk.Bytes()) {
+			t.Fatalf("BlindExpandedPrivateKey(ExpandPrivateKey(sk)) != BlindPrivateKey(sk)")
+		}
+
+		// The blinded key depends on the time period, period length,
+		// and secret.
+		for _, v := range []struct {
+			n            string
+			period       uint64
+			periodLength uint64
+			secret       []byte
+		}{
+			{"Period", period + 1, DefaultPeriodLength, nil},
+			{"PeriodLength", period, DefaultPeriodLength / 2, nil},
+			{"Secret", period, DefaultPeriodLength, []byte("secret")},
+		} {
+			otherPk, err := BlindPublicKey(pk, v.period, v.periodLength, v.secret)
+			if err != nil {
+				t.Fatalf("BlindPublicKey(%s): %v", v.n, err)
+			}
+			if bytes.Equal(otherPk, blindedPk) {
+				t.Fatalf("BlindPublicKey(%s): blinded key unchanged", v.n)
+			}
+		}
+	})
+	t.Run("Sign", func(t *testing.T) {
+		msg := []byte("test message")
+		sig := bsk.Sign(msg)
+		if !ed25519.Verify(blindedPk, msg, sig) {
+			t.Fatalf("Sign: signature does not verify under the blinded key")
+		}
+		if ed25519.Verify(pk, msg, sig) {
+			t.Fatalf("Sign: signature verifies under the identity key")
+		}
+	})
+	t.Run("Torsion", func(t *testing.T) {
+		// Tor rejects identity keys that are the identity element, or
+		// that have a torsion component.
+		lowOrder, _ := hex.DecodeString("26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05")
+		A, err := edwards25519.NewIdentityPoint().SetBytes(pk)
+		if err != nil {
+			t.Fatalf("SetBytes(pk): %v", err)
+		}
+		T, err := edwards25519.NewIdentityPoint().SetBytes(lowOrder)
+		if err != nil {
+			t.Fatalf("SetBytes(lowOrder): %v", err)
+		}
+		mixedPk := A.Add(A, T).Bytes()
+		identityPk := edwards25519.NewIdentityPoint().Bytes()
+
+		for _, badPk := range [][]byte{mixedPk, lowOrder, identityPk} {
+			if _, err = BlindPublicKey(badPk, period, DefaultPeriodLength, nil); !errors.Is(err, ErrInvalidPublicKey) {
+				t.Fatalf("BlindPublicKey(%x): unexpected error: %v", badPk, err)
+			}
+			if _, err = ParseAddress(Address(badPk)); !errors.Is(err, ErrInvalidAddress) {
+				t.Fatalf("ParseAddress(%x): unexpected error: %v", badPk, err)
+			}
+		}
+	})
+	t.Run("Subcredential", func(t *testing.T) {
+		subcredential := Subcredential(pk, blindedPk)
+		otherPk, err := BlindPublicKey(pk, period+1, DefaultPeriodLength, nil)
+		if err != nil {
+			t.Fatalf("BlindPublicKey: %v", err)
+		}
+		if Subcredential(pk, otherPk) == subcredential {
+			t.Fatalf("Subcredential: does not depend on the blinded key")
+		}
+	})
+	t.Run("DescriptorSigningKeyCertificate", func(t *testing.T) {
+		descPk, _, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("ed25519.GenerateKey: %v", err)
+		}
+		expiration := time.Date(2016, 4, 13, 11, 30, 0, 0, time.UTC)
+
+		cert := bsk.DescriptorSigningKeyCertificate(descPk, expiration)
+		if len(cert) != DescriptorSigningKeyCertificateSize {
+			t.Fatalf("DescriptorSigningKeyCertificate: unexpected length: %d", len(cert))
+		}
+
+		certPk, certExpiration, err := VerifyDescriptorSigningKeyCertificate(blindedPk, cert)
+		if err != nil {
+			t.Fatalf("VerifyDescriptorSigningKeyCertificate: %v", err)
+		}
+		if !bytes.Equal(certPk, descPk) {
+			t.Fatalf("VerifyDescriptorSigningKeyCertificate: unexpected signing key")
+		}
+		if !certExpiration.Equal(time.Date(2016, 4, 13, 12, 0, 0, 0, time.UTC)) {
+			t.Fatalf("VerifyDescriptorSigningKeyCertificate: unexpected expiration: %v", certExpiration)
+		}
+
+		if _, _, err = VerifyDescriptorSigningKeyCertificate(pk, cert); !errors.Is(err, ErrInvalidCertificate) {
+			t.Fatalf("VerifyDescriptorSigningKeyCertificate(identity key): unexpected error: %v", err)
+		}
+		cert[10] ^= 0x01
+		if _, _, err = VerifyDescriptorSigningKeyCertificate(blindedPk, cert); !errors.Is(err, ErrInvalidCertificate) {
+			t.Fatalf("VerifyDescriptorSigningKeyCertificate(corrupted): unexpected error: %v", err)
+		}
+	})
+}
+
+func TestTorVectors(t *testing.T) {
+	// From C-tor's test_hs_common.c (test_build_address,
+	// test_blinding_basics), and arti's tor-hscrypto key blinding
+	// test vectors.
+	mustUnhex := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatalf("hex.DecodeString: %v", err)
+		}
+		return b
+	}
+
+	t.Run("Address", func(t *testing.T) {
+		pk := mustUnhex("d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a")
+		if got := Address(pk); got != "25njqamcweflpvkl73j4szahhihoc4xt3ktcgjnpaingr5yhkenl5sid.onion" {
+			t.Fatalf("Address: got %s", got)
+		}
+	})
+
+	var (
+		pk         = mustUnhex("833990b085c1a688c1d4c8b1f6b56afaf5a2eca674449e1d704f83765ccb7bc6")
+		identityA  = mustUnhex("d8c7ff0e31295b66540d789af3e3df992038a9592eea01d8b7cba06d6e66d159")
+		param      = mustUnhex("379e50db31fee6775abd0af6fb7c371e060308f4f847db09fe4cfe13af602287")
+		blindedPk  = mustUnhex("3a50bf210e8f9ee955ae0014f7a6917fb65ebf098a86305abb508d1a7291b6d5")
+		subcred    = mustUnhex("635d55907816e8d76398a675a50b1c2f3e36b42a5ca77ba3a0441285161ae07d")
+		periodTime = time.Date(1973, 5, 20, 1, 50, 33, 0, time.UTC)
+	)
+
+	period := TimePeriod(periodTime, DefaultPeriodLength)
+	if period != 1234 {
+		t.Fatalf("TimePeriod(%v): got %d, expected 1234", periodTime, period)
+	}
+
+	t.Run("BlindingFactor", func(t *testing.T) {
+		// The vector is the unclamped blinding parameter.
+		expected := append([]byte{}, param...)
+		expected[0] &= 248
+		expected[31] &= 63
+		expected[31] |= 64
+
+		h := blindingFactor(pk, period, DefaultPeriodLength, nil)
+		if !bytes.Equal(h[:], expected) {
+			t.Fatalf("blindingFactor: got %x", h[:])
+		}
+	})
+	t.Run("BlindPublicKey", func(t *testing.T) {
+		got, err := BlindPublicKey(pk, period, DefaultPeriodLength, nil)
+		if err != nil {
+			t.Fatalf("BlindPublicKey: %v", err)
+		}
+		if !bytes.Equal(got, blindedPk) {
+			t.Fatalf("BlindPublicKey: got %x", got)
+		}
+	})
+	t.Run("BlindExpandedPrivateKey", func(t *testing.T) {
+		// The vectors only fix the secret scalar half of the
+		// expanded identity key, so the RH half is arbitrary.
+		extsk := make([]byte, ExpandedPrivateKeySize)
+		copy(extsk, identityA)
+
+		bsk, err := BlindExpandedPrivateKey(extsk, period, DefaultPeriodLength, nil)
+		if err != nil {
+			t.Fatalf("BlindExpandedPrivateKey: %v", err)
+		}
+		if !bytes.Equal(bsk.Public(), blindedPk) {
+			t.Fatalf("BlindExpandedPrivateKey: got public key %x", bsk.Public())
+		}
+
+		msg := []byte("test message")
+		if !ed25519.Verify(blindedPk, msg, bsk.Sign(msg)) {
+			t.Fatalf("Sign: signature does not verify under the blinded key")
+		}
+
+		descPk := mustUnhex("d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a")
+		cert := bsk.DescriptorSigningKeyCertificate(descPk, periodTime)
+		certPk, _, err := VerifyDescriptorSigningKeyCertificate(blindedPk, cert)
+		if err != nil {
+			t.Fatalf("VerifyDescriptorSigningKeyCertificate: %v", err)
+		}
+		if !bytes.Equal(certPk, descPk) {
+			t.Fatalf("VerifyDescriptorSigningKeyCertificate: unexpected signing key")
+		}
+	})
+	t.Run("Subcredential", func(t *testing.T) {
+		got := Subcredential(pk, blindedPk)
+		if !bytes.Equal(got[:], subcred) {
+			t.Fatalf("Subcredential: got %x", got[:])
+		}
+	})
+}
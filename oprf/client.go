@@ -0,0 +1,214 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package oprf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/scalar"
+)
+
+// Client is the client side of the protocol.
+type Client struct {
+	suite *suite
+	pk    *PublicKey
+}
+
+// NewClient creates a new base mode (ModeOPRF) client.
+func NewClient() *Client {
+	return &Client{
+		suite: newSuite(ModeOPRF),
+	}
+}
+
+// NewVerifiableClient creates a new verifiable mode (ModeVOPRF) client,
+// that verifies evaluations against the server public key, which MUST
+// NOT be nil.
+func NewVerifiableClient(pk *PublicKey) *Client {
+	if pk == nil {
+		panic("oprf: nil public key")
+	}
+	return &Client{
+		suite: newSuite(ModeVOPRF),
+		pk:    pk,
+	}
+}
+
+// NewPartiallyObliviousClient creates a new partially-oblivious mode
+// (ModePOPRF) client, that verifies evaluations against the server
+// public key, tweaked by the public info.  The public key MUST NOT be
+// nil.
+func NewPartiallyObliviousClient(pk *PublicKey) *Client {
+	if pk == nil {
+		panic("oprf: nil public key")
+	}
+	return &Client{
+		suite: newSuite(ModePOPRF),
+		pk:    pk,
+	}
+}
+
+// Blind is the client state retained between Blind and Finalize.
+type Blind struct {
+	input          []byte
+	info           []byte
+	blind          edwards25519.Scalar
+	blindedElement edwards25519.Point
+	tweakedKey     edwards25519.Point
+}
+
+// Blind blinds the input, using entropy from rand, and returns the
+// state required to finalize the evaluation, and the serialized blinded
+// element to be sent to the server.  For partially-oblivious mode
+// (ModePOPRF) clients, this is equivalent to BlindWithInfo with empty
+// info.
+func (c *Client) Blind(rand io.Reader, input []byte) (*Blind, []byte, error) {
+	return c.BlindWithInfo(rand, input, nil)
+}
+
+// BlindWithInfo blinds the input for evaluation with the public info,
+// using entropy from rand, and returns the state required to finalize
+// the evaluation, and the serialized blinded element to be sent to the
+// server.  Non-empty info is only supported by partially-oblivious mode
+// (ModePOPRF) clients.
+func (c *Client) BlindWithInfo(rand io.Reader, input, info []byte) (*Blind, []byte, error) {
+	blind, err := scalar.Random(rand)
+	if err != nil {
+		return nil, nil, fmt.Errorf("oprf: failed to generate blind: %w", err)
+	}
+	return c.blindWithScalar(input, info, blind)
+}
+
+func (c *Client) blindWithScalar(input, info []byte, blind *edwards25519.Scalar) (*Blind, []byte, error) {
+	if err := c.suite.checkInfo(info); err != nil {
+		return nil, nil, err
+	}
+
+	state := &Blind{
+		input: append([]byte{}, input...),
+		info:  append([]byte{}, info...),
+	}
+	if c.suite.mode == ModePOPRF {
+		tweakedKey, err := c.suite.tweakPublicKey(c.pk, info)
+		if err != nil {
+			return nil, nil, err
+		}
+		state.tweakedKey.Set(tweakedKey)
+	}
+
+	inputElement, err := c.suite.hashToGroup(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	state.blind.Set(blind)
+	state.blindedElement.ScalarMult(blind, inputElement)
+
+	return state, state.blindedElement.Bytes(), nil
+}
+
+// Finalize unblinds the serialized evaluated element returned by the
+// server, and returns the PRF output.  Finalize is only supported by
+// base mode (ModeOPRF) clients, as the other modes require a proof, see
+// FinalizeBatch.
+func (c *Client) Finalize(state *Blind, evaluatedElement []byte) ([]byte, error) {
+	if c.suite.isVerifiable() {
+		return nil, fmt.Errorf("%w: proof required, use FinalizeBatch", errInvalidMode)
+	}
+
+	Z, err := deserializeElement(evaluatedElement)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.finalize(state, Z), nil
+}
+
+// FinalizeBatch verifies the serialized proof (if any) for, and
+// unblinds the serialized evaluated elements returned by the server in
+// response to the blinded elements corresponding to the states, and
+// returns the PRF outputs in the same order.
+//
+// The proof is ignored (and may be nil) for base mode (ModeOPRF)
+// clients.  For partially-oblivious mode (ModePOPRF) clients, all of
+// the states MUST have been created with the same info.
+func (c *Client) FinalizeBatch(states []*Blind, evaluatedElements [][]byte, proof []byte) ([][]byte, error) {
+	if len(states) != len(evaluatedElements) || len(states) == 0 {
+		return nil, fmt.Errorf("%w: invalid batch size", ErrInvalidInput)
+	}
+
+	blindedElements := make([]*edwards25519.Point, 0, len(states))
+	Zs := make([]*edwards25519.Point, 0, len(evaluatedElements))
+	for i, b := range evaluatedElements {
+		if !bytes.Equal(states[i].info, states[0].info) {
+			return nil, fmt.Errorf("%w: mismatched info: element %d", ErrInvalidInput, i)
+		}
+
+		Z, err := deserializeElement(b)
+		if err != nil {
+			return nil, fmt.Errorf("%w: element %d", err, i)
+		}
+		blindedElements = append(blindedElements, &states[i].blindedElement)
+		Zs = append(Zs, Z)
+	}
+
+	switch c.suite.mode {
+	case ModeVOPRF:
+		if err := c.suite.verifyProof(&c.pk.p, blindedElements, Zs, proof); err != nil {
+			return nil, err
+		}
+	case ModePOPRF:
+		// The server proves that `blindedElement = t * evaluatedElement`
+		// for the tweaked private key t.
+		if err := c.suite.verifyProof(&states[0].tweakedKey, Zs, blindedElements, proof); err != nil {
+			return nil, err
+		}
+	}
+
+	outputs := make([][]byte, 0, len(states))
+	for i, state := range states {
+		outputs = append(outputs, c.finalize(state, Zs[i]))
+	}
+
+	return outputs, nil
+}
+
+func (c *Client) finalize(state *Blind, evaluatedElement *edwards25519.Point) []byte {
+	// N = G.ScalarInverse(blind) * evaluatedElement
+	var inv edwards25519.Scalar
+	inv.Invert(&state.blind)
+	N := edwards25519.NewIdentityPoint().ScalarMult(&inv, evaluatedElement)
+
+	return c.suite.finalizeHash(state.input, state.info, N)
+}
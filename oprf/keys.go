@@ -0,0 +1,198 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package oprf
+
+import (
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+
+	"gitlab.com/yawning/edwards25519-extra/internal/scalar"
+)
+
+// PrivateKey is a server private key.
+type PrivateKey struct {
+	s         edwards25519.Scalar
+	publicKey PublicKey
+}
+
+// PublicKey is a server public key.
+type PublicKey struct {
+	p edwards25519.Point
+	b [ElementSize]byte
+}
+
+// GenerateKey generates a new private key, using entropy from rand.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	s, err := scalar.Random(rand)
+	if err != nil {
+		return nil, fmt.Errorf("oprf: failed to generate private key: %w", err)
+	}
+	return newPrivateKey(s), nil
+}
+
+// DeriveKey deterministically derives a private key for the mode from
+// the SeedSize-byte seed, and the public info, per RFC 9497 Section 3.2.1.
+func DeriveKey(mode Mode, seed, info []byte) (*PrivateKey, error) {
+	if err := mode.validate(); err != nil {
+		return nil, err
+	}
+	return newSuite(mode).deriveKey(seed, info)
+}
+
+func (s *suite) deriveKey(seed, info []byte) (*PrivateKey, error) {
+	if len(seed) != SeedSize {
+		return nil, fmt.Errorf("%w: invalid seed length: %d", ErrDeriveKeyPair, len(seed))
+	}
+	if len(info) > maxInputSize {
+		return nil, fmt.Errorf("%w: info too long", ErrDeriveKeyPair)
+	}
+
+	// deriveInput = seed || I2OSP(len(info), 2) || info
+	deriveInput := make([]byte, 0, len(seed)+2+len(info)+1)
+	deriveInput = append(deriveInput, seed...)
+	deriveInput = append(deriveInput, i2osp2(len(info))...)
+	deriveInput = append(deriveInput, info...)
+	deriveInput = append(deriveInput, 0x00) // counter
+
+	for counter := 0; counter <= 255; counter++ {
+		deriveInput[len(deriveInput)-1] = byte(counter)
+		sk, err := s.hashToScalarWithDST(deriveInput, s.deriveKeyPairDST)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrDeriveKeyPair, err)
+		}
+		if scalar.IsZero(sk) == 0 {
+			return newPrivateKey(sk), nil
+		}
+	}
+
+	return nil, ErrDeriveKeyPair
+}
+
+// NewPrivateKey deserializes a private key from the ScalarSize-byte
+// canonical encoding of a non-zero scalar.
+func NewPrivateKey(b []byte) (*PrivateKey, error) {
+	s, err := deserializeScalar(b)
+	if err != nil {
+		return nil, err
+	}
+	if scalar.IsZero(s) == 1 {
+		return nil, fmt.Errorf("%w: zero private key", ErrDeserialize)
+	}
+	return newPrivateKey(s), nil
+}
+
+func newPrivateKey(s *edwards25519.Scalar) *PrivateKey {
+	var sk PrivateKey
+	sk.s.Set(s)
+	sk.publicKey.p.ScalarBaseMult(s)
+	copy(sk.publicKey.b[:], sk.publicKey.p.Bytes())
+	return &sk
+}
+
+// Bytes returns the ScalarSize-byte encoding of the private key.
+func (sk *PrivateKey) Bytes() []byte {
+	return sk.s.Bytes()
+}
+
+// Public returns the public key corresponding to the private key.
+func (sk *PrivateKey) Public() *PublicKey {
+	return &sk.publicKey
+}
+
+// tweak returns the scalar used to tweak the key pair with the public
+// info (ModePOPRF), per RFC 9497 Section 3.3.3.
+func (s *suite) tweak(info []byte) (*edwards25519.Scalar, error) {
+	if err := s.checkInfo(info); err != nil {
+		return nil, err
+	}
+
+	// framedInfo = "Info" || I2OSP(len(info), 2) || info
+	framedInfo := make([]byte, 0, len(infoLabel)+2+len(info))
+	framedInfo = append(framedInfo, infoLabel...)
+	framedInfo = append(framedInfo, i2osp2(len(info))...)
+	framedInfo = append(framedInfo, info...)
+
+	return s.hashToScalar(framedInfo)
+}
+
+// tweakPrivateKey returns the private key tweaked by the public info
+// (`t = skS + m`).
+func (s *suite) tweakPrivateKey(sk *PrivateKey, info []byte) (*edwards25519.Scalar, error) {
+	m, err := s.tweak(info)
+	if err != nil {
+		return nil, err
+	}
+
+	t := m.Add(&sk.s, m)
+	if scalar.IsZero(t) == 1 {
+		return nil, fmt.Errorf("%w: info produces an invalid tweaked key", ErrInvalidInput)
+	}
+
+	return t, nil
+}
+
+// tweakPublicKey returns the public key tweaked by the public info
+// (`tweakedKey = G * m + pkS`).
+func (s *suite) tweakPublicKey(pk *PublicKey, info []byte) (*edwards25519.Point, error) {
+	m, err := s.tweak(info)
+	if err != nil {
+		return nil, err
+	}
+
+	tweakedKey := edwards25519.NewIdentityPoint().ScalarBaseMult(m)
+	tweakedKey.Add(tweakedKey, &pk.p)
+	if tweakedKey.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return nil, fmt.Errorf("%w: info produces an invalid tweaked key", ErrInvalidInput)
+	}
+
+	return tweakedKey, nil
+}
+
+// NewPublicKey deserializes a public key from the ElementSize-byte
+// encoding of a group element.
+func NewPublicKey(b []byte) (*PublicKey, error) {
+	p, err := deserializeElement(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var pk PublicKey
+	pk.p.Set(p)
+	copy(pk.b[:], b)
+
+	return &pk, nil
+}
+
+// Bytes returns the ElementSize-byte encoding of the public key.
+func (pk *PublicKey) Bytes() []byte {
+	return append([]byte{}, pk.b[:]...)
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package oprf implements the "Oblivious Pseudorandom Functions (OPRFs)
+// Using Prime-Order Groups" protocol as specified in RFC 9497, over the
+// prime order subgroup of edwards25519.
+//
+// RFC 9497 does not define an edwards25519 ciphersuite, so this package
+// defines "edwards25519-SHA512", following the ristretto255-SHA512
+// ciphersuite as closely as possible:
+//
+//   - Group: The prime order subgroup of edwards25519, with elements
+//     serialized per RFC 8032, and deserialization rejecting
+//     non-canonical encodings, the identity element, and elements that
+//     are not in the prime order subgroup.
+//   - HashToGroup: edwards25519_XMD:SHA-512_ELL2_RO_ (RFC 9380), with
+//     DST = "HashToGroup-" || contextString.
+//   - HashToScalar: expand_message_xmd with SHA-512 to 64 bytes, reduced
+//     modulo the group order, with DST = "HashToScalar-" || contextString.
+//   - Hash: SHA-512.
+//
+// The base mode (ModeOPRF), the verifiable mode (ModeVOPRF), and the
+// partially-oblivious mode (ModePOPRF) are supported.  In the latter two
+// modes, the server's evaluations of a batch of blinded elements are
+// accompanied by a single DLEQ proof, that the client verifies against
+// the server's public key (tweaked by the public info for ModePOPRF).
+package oprf
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+
+	"gitlab.com/yawning/edwards25519-extra/dleq"
+	"gitlab.com/yawning/edwards25519-extra/h2c"
+	"gitlab.com/yawning/edwards25519-extra/internal/scalar"
+)
+
+const (
+	// Identifier is the ciphersuite identifier.
+	Identifier = "edwards25519-SHA512"
+
+	// ElementSize is the size of a serialized group element in bytes.
+	ElementSize = 32
+
+	// ScalarSize is the size of a serialized scalar in bytes.
+	ScalarSize = 32
+
+	// OutputSize is the size of the PRF output in bytes.
+	OutputSize = sha512.Size
+
+	// SeedSize is the size of the seed used by DeriveKey in bytes.
+	SeedSize = 32
+
+	maxInputSize = 1<<16 - 1
+)
+
+// Mode is a protocol variant.
+type Mode byte
+
+const (
+	// ModeOPRF is the base OPRF mode.
+	ModeOPRF Mode = 0x00
+
+	// ModeVOPRF is the verifiable OPRF mode, where the server proves
+	// that evaluations were done with the private key corresponding to
+	// its public key.
+	ModeVOPRF Mode = 0x01
+
+	// ModePOPRF is the partially-oblivious OPRF mode, where the
+	// evaluation is additionally bound to public info shared by the
+	// client and the server, via a tweaked key.  Evaluations are
+	// verifiable, as with ModeVOPRF.
+	ModePOPRF Mode = 0x02
+)
+
+var (
+	// ErrInvalidInput is the error returned when the input maps to the
+	// identity element, or is too long.
+	ErrInvalidInput = errors.New("oprf: invalid input")
+
+	// ErrDeserialize is the error returned when a group element, scalar,
+	// or key fails to deserialize.
+	ErrDeserialize = errors.New("oprf: failed to deserialize")
+
+	// ErrDeriveKeyPair is the error returned when DeriveKey fails to
+	// derive a key.
+	ErrDeriveKeyPair = errors.New("oprf: failed to derive key pair")
+
+	// ErrVerify is the error returned when a proof fails to verify.
+	ErrVerify = errors.New("oprf: failed to verify proof")
+
+	errInvalidMode = errors.New("oprf: invalid mode")
+
+	xmdSHA512 = h2c.NewExpanderXMD(crypto.SHA512)
+
+	scalarMinusOne = edwards25519.NewScalar().Negate(scalar.One())
+
+	finalizeLabel = []byte("Finalize")
+	infoLabel     = []byte("Info")
+)
+
+func (mode Mode) validate() error {
+	switch mode {
+	case ModeOPRF, ModeVOPRF, ModePOPRF:
+		return nil
+	default:
+		return fmt.Errorf("%w: %d", errInvalidMode, mode)
+	}
+}
+
+// suite is a ciphersuite instantiated for a specific mode.
+type suite struct {
+	mode Mode
+
+	hashToGroupDST   []byte
+	hashToScalarDST  []byte
+	deriveKeyPairDST []byte
+	seedDST          []byte
+
+	proofParams *dleq.Params
+}
+
+func newSuite(mode Mode) *suite {
+	return newSuiteWithIdentifier(mode, Identifier)
+}
+
+func newSuiteWithIdentifier(mode Mode, identifier string) *suite {
+	if err := mode.validate(); err != nil {
+		panic(err)
+	}
+
+	// contextString = "OPRFV1-" || I2OSP(mode, 1) || "-" || identifier
+	contextString := append([]byte("OPRFV1-"), byte(mode))
+	contextString = append(contextString, '-')
+	contextString = append(contextString, identifier...)
+
+	withPrefix := func(prefix string) []byte {
+		return append([]byte(prefix), contextString...)
+	}
+
+	s := &suite{
+		mode:             mode,
+		hashToGroupDST:   withPrefix("HashToGroup-"),
+		hashToScalarDST:  withPrefix("HashToScalar-"),
+		deriveKeyPairDST: withPrefix("DeriveKeyPair"),
+		seedDST:          withPrefix("Seed-"),
+	}
+
+	// Proof challenges use the suite's HashToScalar.
+	proofParams, err := dleq.NewParams(xmdSHA512, s.hashToScalarDST)
+	if err != nil {
+		panic("oprf: failed to create proof parameters: " + err.Error())
+	}
+	s.proofParams = proofParams
+
+	return s
+}
+
+func (s *suite) isVerifiable() bool {
+	return s.mode != ModeOPRF
+}
+
+func (s *suite) hashToGroup(input []byte) (*edwards25519.Point, error) {
+	if len(input) > maxInputSize {
+		return nil, ErrInvalidInput
+	}
+	p, err := h2c.Edwards25519_XMD_SHA512_ELL2_RO(s.hashToGroupDST, input)
+	if err != nil {
+		return nil, fmt.Errorf("oprf: failed to hash to group: %w", err)
+	}
+	if p.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return nil, ErrInvalidInput
+	}
+	return p, nil
+}
+
+func (s *suite) hashToScalar(input []byte) (*edwards25519.Scalar, error) {
+	return s.hashToScalarWithDST(input, s.hashToScalarDST)
+}
+
+func (s *suite) hashToScalarWithDST(input, domainSeparator []byte) (*edwards25519.Scalar, error) {
+	sc, err := h2c.HashToScalar(xmdSHA512, domainSeparator, input)
+	if err != nil {
+		return nil, fmt.Errorf("oprf: failed to hash to scalar: %w", err)
+	}
+	return sc, nil
+}
+
+// checkInfo validates the public info.
+func (s *suite) checkInfo(info []byte) error {
+	if len(info) != 0 && s.mode != ModePOPRF {
+		return fmt.Errorf("%w: info requires ModePOPRF", errInvalidMode)
+	}
+	if len(info) > maxInputSize {
+		return fmt.Errorf("%w: info too long", ErrInvalidInput)
+	}
+	return nil
+}
+
+// finalizeHash returns the PRF output for the input, the public info
+// (ModePOPRF only), and the unblinded element.
+func (s *suite) finalizeHash(input, info []byte, unblindedElement *edwards25519.Point) []byte {
+	// hashInput = I2OSP(len(input), 2) || input ||
+	//             I2OSP(len(info), 2) || info ||     (ModePOPRF only)
+	//             I2OSP(len(unblindedElement), 2) || unblindedElement ||
+	//             "Finalize"
+	h := sha512.New()
+	_, _ = h.Write(i2osp2(len(input)))
+	_, _ = h.Write(input)
+	if s.mode == ModePOPRF {
+		_, _ = h.Write(i2osp2(len(info)))
+		_, _ = h.Write(info)
+	}
+	_, _ = h.Write(i2osp2(ElementSize))
+	_, _ = h.Write(unblindedElement.Bytes())
+	_, _ = h.Write(finalizeLabel)
+	return h.Sum(nil)
+}
+
+// deserializeElement deserializes a group element, rejecting
+// non-canonical encodings, the identity element, and points that are
+// not in the prime order subgroup.
+func deserializeElement(b []byte) (*edwards25519.Point, error) {
+	if len(b) != ElementSize {
+		return nil, fmt.Errorf("%w: invalid element length: %d", ErrDeserialize, len(b))
+	}
+	p, err := h2c.DecodeCanonicalPoint(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDeserialize, err)
+	}
+	if p.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return nil, fmt.Errorf("%w: identity element", ErrDeserialize)
+	}
+
+	// p is in the prime order subgroup iff l * p = (l - 1) * p + p is
+	// the identity element.  Note that ScalarMult does not reduce the
+	// point, so this works for points with a torsion component.
+	q := edwards25519.NewIdentityPoint().ScalarMult(scalarMinusOne, p)
+	if q.Add(q, p).Equal(edwards25519.NewIdentityPoint()) != 1 {
+		return nil, fmt.Errorf("%w: element not in prime order subgroup", ErrDeserialize)
+	}
+
+	return p, nil
+}
+
+func deserializeScalar(b []byte) (*edwards25519.Scalar, error) {
+	if len(b) != ScalarSize {
+		return nil, fmt.Errorf("%w: invalid scalar length: %d", ErrDeserialize, len(b))
+	}
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDeserialize, err)
+	}
+	return s, nil
+}
+
+func i2osp2(l int) []byte {
+	return []byte{byte(l >> 8), byte(l)}
+}
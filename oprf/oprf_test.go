@@ -0,0 +1,603 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package oprf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+// testSeed, testInfo and testBlind are the values used by the RFC 9497
+// ristretto255-SHA512 test vectors.
+var (
+	testSeed  = bytes.Repeat([]byte{0xa3}, SeedSize)
+	testInfo  = []byte("test key")
+	testBlind = mustUnhex("64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706")
+
+	testInputs = [][]byte{
+		{0x00},
+		bytes.Repeat([]byte{0x5a}, 17),
+	}
+)
+
+func mustUnhex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func mustDeserializeScalar(b []byte) *edwards25519.Scalar {
+	s, err := deserializeScalar(b)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func mustPanic(t *testing.T, name string, fn func()) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("%s: did not panic", name)
+		}
+	}()
+	fn()
+}
+
+func TestOPRF(t *testing.T) {
+	// There are no published test vectors for the edwards25519-SHA512
+	// ciphersuite, so these were generated by this implementation, and
+	// exist to catch regressions.  The inputs match the RFC 9497
+	// ristretto255-SHA512 OPRF mode test vectors.
+	t.Run("Vectors", func(t *testing.T) {
+		sk, err := DeriveKey(ModeOPRF, testSeed, testInfo)
+		if err != nil {
+			t.Fatalf("DeriveKey: %v", err)
+		}
+		if got := hex.EncodeToString(sk.Bytes()); got != "517f63f327f08b84119d97738af109ac63bb89b984e4bf8c2f9d9cfab129b00f" {
+			t.Fatalf("DeriveKey: unexpected private key: %s", got)
+		}
+
+		client, server := NewClient(), NewServer(sk)
+		blind := mustDeserializeScalar(testBlind)
+		for i, v := range []struct {
+			blindedElement   string
+			evaluatedElement string
+			output           string
+		}{
+			{
+				"cf77e970a5c4f9dd4f576222978eaf50b29bdfd448721ee8fab8b78d663708de",
+				"82e2cdcb46b8883768e4d2338d9d5dba53ea6887c0c51e7a3f3fcac1491dfa66",
+				"f083ed00600a85b717c3a64b6504429bf2c73e1c7ebec931c8f970b94fa6268b19837a8e788c13a1a44d2be5f9538ecc0b089549683da524667751e01a35e598",
+			},
+			{
+				"50a6399b6fe99f8f36c3f21c0582155355ce6d781bbdef6db6499adbddf6ebf6",
+				"c821347a1b79701b3a4e40442232cbc74239efb6423357a347204db94870d2bf",
+				"1c332a3fe1ac6bcf0eecf2698fda250e132b040dbdc552b0d6a46b3de2b9970030408ec885a8a37a7090468c2f03223d5c0086a245c60de5f850d2f93489f548",
+			},
+		} {
+			state, blindedElement, err := client.blindWithScalar(testInputs[i], nil, blind)
+			if err != nil {
+				t.Fatalf("[%d]: Blind: %v", i, err)
+			}
+			if got := hex.EncodeToString(blindedElement); got != v.blindedElement {
+				t.Fatalf("[%d]: Blind: unexpected blinded element: %s", i, got)
+			}
+
+			evaluatedElement, err := server.BlindEvaluate(blindedElement)
+			if err != nil {
+				t.Fatalf("[%d]: BlindEvaluate: %v", i, err)
+			}
+			if got := hex.EncodeToString(evaluatedElement); got != v.evaluatedElement {
+				t.Fatalf("[%d]: BlindEvaluate: unexpected evaluated element: %s", i, got)
+			}
+
+			output, err := client.Finalize(state, evaluatedElement)
+			if err != nil {
+				t.Fatalf("[%d]: Finalize: %v", i, err)
+			}
+			if got := hex.EncodeToString(output); got != v.output {
+				t.Fatalf("[%d]: Finalize: unexpected output: %s", i, got)
+			}
+		}
+	})
+	t.Run("RistrettoDeriveKey", func(t *testing.T) {
+		// The scalar field, and HashToScalar are shared with the
+		// ristretto255-SHA512 ciphersuite, so deriving a key with its
+		// contextString must reproduce the RFC 9497 skSm values.
+		for _, v := range []struct {
+			mode Mode
+			skSm string
+		}{
+			{ModeOPRF, "5ebcea5ee37023ccb9fc2d2019f9d7737be85591ae8652ffa9ef0f4d37063b0e"},
+			{ModeVOPRF, "e6f73f344b79b379f1a0dd37e07ff62e38d9f71345ce62ae3a9bc60b04ccd909"},
+			{ModePOPRF, "145c79c108538421ac164ecbe131942136d5570b16d8bf41a24d4337da981e07"},
+		} {
+			sk, err := newSuiteWithIdentifier(v.mode, "ristretto255-SHA512").deriveKey(testSeed, testInfo)
+			if err != nil {
+				t.Fatalf("deriveKey(%v): %v", v.mode, err)
+			}
+			if got := hex.EncodeToString(sk.Bytes()); got != v.skSm {
+				t.Fatalf("deriveKey(%v): got %s, expected %s", v.mode, got, v.skSm)
+			}
+		}
+	})
+	t.Run("RoundTrip", func(t *testing.T) {
+		sk, err := GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+		client, server := NewClient(), NewServer(sk)
+
+		for _, input := range append(testInputs, []byte{}) {
+			state, blindedElement, err := client.Blind(rand.Reader, input)
+			if err != nil {
+				t.Fatalf("Blind: %v", err)
+			}
+			_, blindedElement2, err := client.Blind(rand.Reader, input)
+			if err != nil {
+				t.Fatalf("Blind: %v", err)
+			}
+			if bytes.Equal(blindedElement, blindedElement2) {
+				t.Fatalf("Blind: blinded elements are linkable")
+			}
+
+			evaluatedElement, err := server.BlindEvaluate(blindedElement)
+			if err != nil {
+				t.Fatalf("BlindEvaluate: %v", err)
+			}
+			output, err := client.Finalize(state, evaluatedElement)
+			if err != nil {
+				t.Fatalf("Finalize: %v", err)
+			}
+			if len(output) != OutputSize {
+				t.Fatalf("Finalize: unexpected output length: %d", len(output))
+			}
+
+			expected, err := server.Evaluate(input)
+			if err != nil {
+				t.Fatalf("Evaluate: %v", err)
+			}
+			if !bytes.Equal(output, expected) {
+				t.Fatalf("Finalize(BlindEvaluate(Blind(input))) != Evaluate(input)")
+			}
+		}
+	})
+	t.Run("Keys", func(t *testing.T) {
+		sk, err := GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+		sk2, err := NewPrivateKey(sk.Bytes())
+		if err != nil {
+			t.Fatalf("NewPrivateKey: %v", err)
+		}
+		if !bytes.Equal(sk2.Public().Bytes(), sk.Public().Bytes()) {
+			t.Fatalf("NewPrivateKey(sk.Bytes()).Public() != sk.Public()")
+		}
+		pk, err := NewPublicKey(sk.Public().Bytes())
+		if err != nil {
+			t.Fatalf("NewPublicKey: %v", err)
+		}
+		if !bytes.Equal(pk.Bytes(), sk.Public().Bytes()) {
+			t.Fatalf("NewPublicKey(pk.Bytes()) != pk")
+		}
+
+		if _, err = NewPrivateKey(make([]byte, ScalarSize)); !errors.Is(err, ErrDeserialize) {
+			t.Fatalf("NewPrivateKey(0): unexpected error: %v", err)
+		}
+		if _, err = DeriveKey(ModeOPRF, testSeed[1:], testInfo); !errors.Is(err, ErrDeriveKeyPair) {
+			t.Fatalf("DeriveKey(short seed): unexpected error: %v", err)
+		}
+		if _, err = DeriveKey(Mode(0xff), testSeed, testInfo); err == nil {
+			t.Fatalf("DeriveKey(invalid mode): succeeded")
+		}
+	})
+	t.Run("DeserializeElement", func(t *testing.T) {
+		lowOrder := mustUnhex("26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05")
+		lowOrderPoint, err := edwards25519.NewIdentityPoint().SetBytes(lowOrder)
+		if err != nil {
+			t.Fatalf("SetBytes(lowOrder): %v", err)
+		}
+		mixed := edwards25519.NewGeneratorPoint().Add(edwards25519.NewGeneratorPoint(), lowOrderPoint)
+
+		nonCanonical := bytes.Repeat([]byte{0xff}, ElementSize)
+		nonCanonical[0], nonCanonical[31] = 0xee, 0x7f // y = p + 1 = 1
+
+		for _, v := range []struct {
+			name string
+			b    []byte
+		}{
+			{"Identity", edwards25519.NewIdentityPoint().Bytes()},
+			{"LowOrder", lowOrder},
+			{"Mixed", mixed.Bytes()},
+			{"NonCanonical", nonCanonical},
+			{"Short", make([]byte, ElementSize-1)},
+		} {
+			if _, err := deserializeElement(v.b); !errors.Is(err, ErrDeserialize) {
+				t.Fatalf("deserializeElement(%s): unexpected error: %v", v.name, err)
+			}
+		}
+		if _, err := deserializeElement(edwards25519.NewGeneratorPoint().Bytes()); err != nil {
+			t.Fatalf("deserializeElement(B): %v", err)
+		}
+	})
+}
+
+func TestVOPRF(t *testing.T) {
+	sk, err := DeriveKey(ModeVOPRF, testSeed, testInfo)
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+	client, server := NewVerifiableClient(sk.Public()), NewVerifiableServer(sk)
+
+	// The proof nonce is derived from a fixed entropy source so that
+	// the proofs are deterministic.
+	testProofRand := func() io.Reader {
+		return bytes.NewReader(bytes.Repeat([]byte{0x42}, 64))
+	}
+
+	blind := mustDeserializeScalar(testBlind)
+	var (
+		states          []*Blind
+		blindedElements [][]byte
+	)
+	for _, input := range testInputs {
+		state, blindedElement, err := client.blindWithScalar(input, nil, blind)
+		if err != nil {
+			t.Fatalf("Blind: %v", err)
+		}
+		states = append(states, state)
+		blindedElements = append(blindedElements, blindedElement)
+	}
+
+	// As with the base mode, these were generated by this
+	// implementation, and exist to catch regressions.
+	t.Run("Vectors", func(t *testing.T) {
+		if got := hex.EncodeToString(sk.Bytes()); got != "8f4d37c660aec7ae3ee3bb0efade0b6d0bf7eba5255c3198126dc51ad7e5920b" {
+			t.Fatalf("DeriveKey: unexpected private key: %s", got)
+		}
+
+		for i, v := range []struct {
+			blindedElement   string
+			evaluatedElement string
+			proof            string
+			output           string
+		}{
+			{
+				"c212abfbe6b853976e77c2a95e23ba69045903761fd2f4acc939c5c5fa586d52",
+				"dd617c04b9254b5c41dbc532db4d7cba251efcf713513d71c3ed4bd266c625a5",
+				"1c17722f9e574639d7649ef25c6bb1f82e4a0daf6b669e4396379a009faee1093c3ede9ff08274234fa77e85a3591b3e9e534f10562b7092b1099fdc09dd7509",
+				"b98f8c1cb2b0ae2c4fe6b79d4a6ba14047449a8edda8c39da7face3ad6f64e0e53c7401b3fe17e7bf3a1167b979342d852ad482b06374c85e6c1f4a3633872ef",
+			},
+			{
+				"16afee75bc9b32f4720d435f23f690bef22745f74709b405b23f62475afed6cf",
+				"fdfe051045c88ecd3cfbd4f4563c0468c291afef31b274b2f1be3e7a7d274196",
+				"bca3779f8eefeffc4f8f39ac8b0e2e0783c7710524be02a419a787c4a362aa08a7d3d98071eb7e52ce33c2a835b372fff3f1951112e25642c10b08ee8702bf00",
+				"5bff65507e4e42e2e8e2593fa3bd0f3196dc302de6b7ad430b3281028c9fb9ddb1f2cd0cb2161fcd1b5abf81ae5e57ce7b4319358bf4d042971c7676bb94b76f",
+			},
+		} {
+			if got := hex.EncodeToString(blindedElements[i]); got != v.blindedElement {
+				t.Fatalf("[%d]: Blind: unexpected blinded element: %s", i, got)
+			}
+
+			evaluatedElements, proof, err := server.BlindEvaluateBatch(testProofRand(), blindedElements[i:i+1])
+			if err != nil {
+				t.Fatalf("[%d]: BlindEvaluateBatch: %v", i, err)
+			}
+			if got := hex.EncodeToString(evaluatedElements[0]); got != v.evaluatedElement {
+				t.Fatalf("[%d]: BlindEvaluateBatch: unexpected evaluated element: %s", i, got)
+			}
+			if got := hex.EncodeToString(proof); got != v.proof {
+				t.Fatalf("[%d]: BlindEvaluateBatch: unexpected proof: %s", i, got)
+			}
+
+			outputs, err := client.FinalizeBatch(states[i:i+1], evaluatedElements, proof)
+			if err != nil {
+				t.Fatalf("[%d]: FinalizeBatch: %v", i, err)
+			}
+			if got := hex.EncodeToString(outputs[0]); got != v.output {
+				t.Fatalf("[%d]: FinalizeBatch: unexpected output: %s", i, got)
+			}
+		}
+
+		// Batched.
+		evaluatedElements, proof, err := server.BlindEvaluateBatch(testProofRand(), blindedElements)
+		if err != nil {
+			t.Fatalf("BlindEvaluateBatch: %v", err)
+		}
+		if got := hex.EncodeToString(proof); got != "a8d0a9a84a52df8141a3a6f8d868eef3ec9278eb9d506e1e50e14aaa16b52000af9fa782fa09c3d564450c3971dac1a8815dd7a28656c2b71905aa158b452209" {
+			t.Fatalf("BlindEvaluateBatch: unexpected proof: %s", got)
+		}
+		outputs, err := client.FinalizeBatch(states, evaluatedElements, proof)
+		if err != nil {
+			t.Fatalf("FinalizeBatch: %v", err)
+		}
+		for i, input := range testInputs {
+			expected, err := server.Evaluate(input)
+			if err != nil {
+				t.Fatalf("[%d]: Evaluate: %v", i, err)
+			}
+			if !bytes.Equal(outputs[i], expected) {
+				t.Fatalf("[%d]: FinalizeBatch output != Evaluate(input)", i)
+			}
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		evaluatedElements, proof, err := server.BlindEvaluateBatch(rand.Reader, blindedElements)
+		if err != nil {
+			t.Fatalf("BlindEvaluateBatch: %v", err)
+		}
+		if _, err = client.FinalizeBatch(states, evaluatedElements, proof); err != nil {
+			t.Fatalf("FinalizeBatch: %v", err)
+		}
+
+		badProof := append([]byte{}, proof...)
+		badProof[0] ^= 0x01
+		if _, err = client.FinalizeBatch(states, evaluatedElements, badProof); !errors.Is(err, ErrVerify) {
+			t.Fatalf("FinalizeBatch(bad proof): unexpected error: %v", err)
+		}
+
+		swapped := [][]byte{evaluatedElements[1], evaluatedElements[0]}
+		if _, err = client.FinalizeBatch(states, swapped, proof); !errors.Is(err, ErrVerify) {
+			t.Fatalf("FinalizeBatch(swapped): unexpected error: %v", err)
+		}
+
+		// A server using a different key than advertised.
+		otherSk, err := GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+		evaluatedElements, proof, err = NewVerifiableServer(otherSk).BlindEvaluateBatch(rand.Reader, blindedElements)
+		if err != nil {
+			t.Fatalf("BlindEvaluateBatch: %v", err)
+		}
+		if _, err = client.FinalizeBatch(states, evaluatedElements, proof); !errors.Is(err, ErrVerify) {
+			t.Fatalf("FinalizeBatch(wrong key): unexpected error: %v", err)
+		}
+
+		if _, err = client.FinalizeBatch(states, evaluatedElements[:1], proof); !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("FinalizeBatch(mismatched batch): unexpected error: %v", err)
+		}
+		if _, err = client.FinalizeBatch(states, evaluatedElements, proof[1:]); !errors.Is(err, ErrDeserialize) {
+			t.Fatalf("FinalizeBatch(short proof): unexpected error: %v", err)
+		}
+		if _, _, err = server.BlindEvaluateBatch(rand.Reader, nil); !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("BlindEvaluateBatch(empty): unexpected error: %v", err)
+		}
+
+		// The unbatched API is base mode only.
+		if _, err = server.BlindEvaluate(blindedElements[0]); err == nil {
+			t.Fatalf("BlindEvaluate: succeeded in verifiable mode")
+		}
+		if _, err = client.Finalize(states[0], evaluatedElements[0]); err == nil {
+			t.Fatalf("Finalize: succeeded in verifiable mode")
+		}
+	})
+	t.Run("NilPublicKey", func(t *testing.T) {
+		mustPanic(t, "NewVerifiableClient(nil)", func() {
+			NewVerifiableClient(nil)
+		})
+	})
+	t.Run("BaseModeBatch", func(t *testing.T) {
+		baseSk, err := GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+		baseClient, baseServer := NewClient(), NewServer(baseSk)
+
+		var baseStates []*Blind
+		var baseBlindedElements [][]byte
+		for _, input := range testInputs {
+			state, blindedElement, err := baseClient.Blind(rand.Reader, input)
+			if err != nil {
+				t.Fatalf("Blind: %v", err)
+			}
+			baseStates = append(baseStates, state)
+			baseBlindedElements = append(baseBlindedElements, blindedElement)
+		}
+
+		evaluatedElements, proof, err := baseServer.BlindEvaluateBatch(nil, baseBlindedElements)
+		if err != nil {
+			t.Fatalf("BlindEvaluateBatch: %v", err)
+		}
+		if proof != nil {
+			t.Fatalf("BlindEvaluateBatch: unexpected proof in base mode")
+		}
+		outputs, err := baseClient.FinalizeBatch(baseStates, evaluatedElements, nil)
+		if err != nil {
+			t.Fatalf("FinalizeBatch: %v", err)
+		}
+		for i, input := range testInputs {
+			expected, err := baseServer.Evaluate(input)
+			if err != nil {
+				t.Fatalf("[%d]: Evaluate: %v", i, err)
+			}
+			if !bytes.Equal(outputs[i], expected) {
+				t.Fatalf("[%d]: FinalizeBatch output != Evaluate(input)", i)
+			}
+		}
+	})
+}
+
+func TestPOPRF(t *testing.T) {
+	info := []byte("test info")
+
+	sk, err := DeriveKey(ModePOPRF, testSeed, testInfo)
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+	client, server := NewPartiallyObliviousClient(sk.Public()), NewPartiallyObliviousServer(sk)
+
+	testProofRand := func() io.Reader {
+		return bytes.NewReader(bytes.Repeat([]byte{0x42}, 64))
+	}
+
+	blind := mustDeserializeScalar(testBlind)
+	var (
+		states          []*Blind
+		blindedElements [][]byte
+	)
+	for _, input := range testInputs {
+		state, blindedElement, err := client.blindWithScalar(input, info, blind)
+		if err != nil {
+			t.Fatalf("Blind: %v", err)
+		}
+		states = append(states, state)
+		blindedElements = append(blindedElements, blindedElement)
+	}
+
+	// As with the base mode, these were generated by this
+	// implementation, and exist to catch regressions.
+	t.Run("Vectors", func(t *testing.T) {
+		if got := hex.EncodeToString(sk.Bytes()); got != "ffe364c773c5332d232fa74ea914054ab625abfb0a04ebe2b93b65e0cbc1a006" {
+			t.Fatalf("DeriveKey: unexpected private key: %s", got)
+		}
+
+		for i, v := range []struct {
+			blindedElement   string
+			evaluatedElement string
+			proof            string
+			output           string
+		}{
+			{
+				"ccd0f5a0f8c8379f921050445492d4773b994cc0db50f3b38b9a687f756f209c",
+				"f382a3cf5ba0b4b8214a3e975811747327ed5db429918b450a16e61350d064f4",
+				"0bb9d8a0d573d07bdf0b1e6fe438c1811f1b03f036f0e3c94e8e5bd246ebb80ba7086610d2482c3521412a1039810a0630e9ca9b1368d2e3cc6ca4a77feaac03",
+				"ffff80974b84dc81bc4b995cc116d13451792665f69d69ac0e08f0b53d4f8b766161d30c40580a2eaf4374781ab5a5316bf7548cfb6e1701d4d40cb30728b498",
+			},
+			{
+				"35ee55064fbcd5ec3cec5e0fc4b3b3576dda988c0fd5bd20497f0912c360b792",
+				"1d21289ef51403fdc7c34aceaf0aa1db698399ecbac1b9cafbb4d17e87ac01eb",
+				"dade7ee2ef4cea1d0290f288feae7423ee589e761050c1308b2ac704e724240388ea8b8a6e8cb665254fc7c9160d70990b2724c8de51abfa0e9c30e0fd603408",
+				"ae09094eef2237574da6da14881a39c756b57338b6591e0a8527b72c54c1b078d157d0a347097c66b96581d003c6ad7b90dbcf2c2a6957b25bb54516ea498eb7",
+			},
+		} {
+			if got := hex.EncodeToString(blindedElements[i]); got != v.blindedElement {
+				t.Fatalf("[%d]: Blind: unexpected blinded element: %s", i, got)
+			}
+
+			evaluatedElements, proof, err := server.BlindEvaluateBatchWithInfo(testProofRand(), blindedElements[i:i+1], info)
+			if err != nil {
+				t.Fatalf("[%d]: BlindEvaluateBatchWithInfo: %v", i, err)
+			}
+			if got := hex.EncodeToString(evaluatedElements[0]); got != v.evaluatedElement {
+				t.Fatalf("[%d]: BlindEvaluateBatchWithInfo: unexpected evaluated element: %s", i, got)
+			}
+			if got := hex.EncodeToString(proof); got != v.proof {
+				t.Fatalf("[%d]: BlindEvaluateBatchWithInfo: unexpected proof: %s", i, got)
+			}
+
+			outputs, err := client.FinalizeBatch(states[i:i+1], evaluatedElements, proof)
+			if err != nil {
+				t.Fatalf("[%d]: FinalizeBatch: %v", i, err)
+			}
+			if got := hex.EncodeToString(outputs[0]); got != v.output {
+				t.Fatalf("[%d]: FinalizeBatch: unexpected output: %s", i, got)
+			}
+
+			expected, err := server.EvaluateWithInfo(testInputs[i], info)
+			if err != nil {
+				t.Fatalf("[%d]: EvaluateWithInfo: %v", i, err)
+			}
+			if !bytes.Equal(outputs[0], expected) {
+				t.Fatalf("[%d]: FinalizeBatch output != EvaluateWithInfo(input, info)", i)
+			}
+		}
+
+		// Batched.
+		evaluatedElements, proof, err := server.BlindEvaluateBatchWithInfo(testProofRand(), blindedElements, info)
+		if err != nil {
+			t.Fatalf("BlindEvaluateBatchWithInfo: %v", err)
+		}
+		if got := hex.EncodeToString(proof); got != "efb7b5409afc1f9324b56fd3d2c8ab8b52cf58a6da71334fb2d92515eaf3b60204ca04e9627f9ac71211236c14c39129aae1dca1dcf171a9b243dcb5f6c1b904" {
+			t.Fatalf("BlindEvaluateBatchWithInfo: unexpected proof: %s", got)
+		}
+		if _, err = client.FinalizeBatch(states, evaluatedElements, proof); err != nil {
+			t.Fatalf("FinalizeBatch: %v", err)
+		}
+	})
+	t.Run("NilPublicKey", func(t *testing.T) {
+		mustPanic(t, "NewPartiallyObliviousClient(nil)", func() {
+			NewPartiallyObliviousClient(nil)
+		})
+	})
+	t.Run("Info", func(t *testing.T) {
+		// The output depends on the info.
+		output, err := server.EvaluateWithInfo(testInputs[0], info)
+		if err != nil {
+			t.Fatalf("EvaluateWithInfo: %v", err)
+		}
+		output2, err := server.Evaluate(testInputs[0])
+		if err != nil {
+			t.Fatalf("Evaluate: %v", err)
+		}
+		if bytes.Equal(output, output2) {
+			t.Fatalf("EvaluateWithInfo: output does not depend on info")
+		}
+
+		// A server evaluating with different info than the client.
+		evaluatedElements, proof, err := server.BlindEvaluateBatchWithInfo(rand.Reader, blindedElements, []byte("other info"))
+		if err != nil {
+			t.Fatalf("BlindEvaluateBatchWithInfo: %v", err)
+		}
+		if _, err = client.FinalizeBatch(states, evaluatedElements, proof); !errors.Is(err, ErrVerify) {
+			t.Fatalf("FinalizeBatch(mismatched info): unexpected error: %v", err)
+		}
+
+		// States with different info can not be finalized together.
+		state, blindedElement, err := client.Blind(rand.Reader, testInputs[0])
+		if err != nil {
+			t.Fatalf("Blind: %v", err)
+		}
+		evaluatedElements, proof, err = server.BlindEvaluateBatch(rand.Reader, [][]byte{blindedElements[0], blindedElement})
+		if err != nil {
+			t.Fatalf("BlindEvaluateBatch: %v", err)
+		}
+		if _, err = client.FinalizeBatch([]*Blind{states[0], state}, evaluatedElements, proof); !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("FinalizeBatch(mixed info): unexpected error: %v", err)
+		}
+
+		// Info is only supported by ModePOPRF.
+		if _, _, err = NewVerifiableClient(sk.Public()).BlindWithInfo(rand.Reader, testInputs[0], info); err == nil {
+			t.Fatalf("BlindWithInfo: succeeded in verifiable mode")
+		}
+		if _, err = NewServer(sk).EvaluateWithInfo(testInputs[0], info); err == nil {
+			t.Fatalf("EvaluateWithInfo: succeeded in base mode")
+		}
+	})
+}
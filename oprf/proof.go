@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package oprf
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+
+	"gitlab.com/yawning/edwards25519-extra/dleq"
+)
+
+var compositeLabel = []byte("Composite")
+
+// computeComposites returns the composite elements M and Z for the
+// proof that `D[i] = k * C[i]` for all i, per RFC 9497 Section 2.2.1.
+// If k is non-nil, Z is computed as `k * M` (ComputeCompositesFast),
+// otherwise it is computed from D (ComputeComposites).
+func (s *suite) computeComposites(k *edwards25519.Scalar, B *edwards25519.Point, C, D []*edwards25519.Point) (*edwards25519.Point, *edwards25519.Point, error) {
+	if len(C) != len(D) || len(C) == 0 || len(C) > maxInputSize {
+		return nil, nil, fmt.Errorf("%w: invalid batch size", ErrInvalidInput)
+	}
+
+	// seedTranscript = I2OSP(len(Bm), 2) || Bm ||
+	//                  I2OSP(len(seedDST), 2) || seedDST
+	h := sha512.New()
+	_, _ = h.Write(i2osp2(ElementSize))
+	_, _ = h.Write(B.Bytes())
+	_, _ = h.Write(i2osp2(len(s.seedDST)))
+	_, _ = h.Write(s.seedDST)
+	seed := h.Sum(nil)
+
+	// compositeTranscript = I2OSP(len(seed), 2) || seed || I2OSP(i, 2) ||
+	//                       I2OSP(len(Ci), 2) || Ci ||
+	//                       I2OSP(len(Di), 2) || Di ||
+	//                       "Composite"
+	transcript := make([]byte, 0, 2+len(seed)+2+2*(2+ElementSize)+len(compositeLabel))
+	ds := make([]*edwards25519.Scalar, 0, len(C))
+	for i := range C {
+		transcript = transcript[:0]
+		transcript = append(transcript, i2osp2(len(seed))...)
+		transcript = append(transcript, seed...)
+		transcript = append(transcript, i2osp2(i)...)
+		transcript = append(transcript, i2osp2(ElementSize)...)
+		transcript = append(transcript, C[i].Bytes()...)
+		transcript = append(transcript, i2osp2(ElementSize)...)
+		transcript = append(transcript, D[i].Bytes()...)
+		transcript = append(transcript, compositeLabel...)
+
+		di, err := s.hashToScalar(transcript)
+		if err != nil {
+			return nil, nil, err
+		}
+		ds = append(ds, di)
+	}
+
+	// The composites are derived from public values, so variable time
+	// multiscalar multiplication is fine.
+	M := edwards25519.NewIdentityPoint().VarTimeMultiScalarMult(ds, C)
+	var Z *edwards25519.Point
+	if k != nil {
+		Z = edwards25519.NewIdentityPoint().ScalarMult(k, M)
+	} else {
+		Z = edwards25519.NewIdentityPoint().VarTimeMultiScalarMult(ds, D)
+	}
+
+	return M, Z, nil
+}
+
+// generateProof returns a serialized proof that `B = k * G` and
+// `D[i] = k * C[i]` for all i, per RFC 9497 Section 2.2.1.
+func (s *suite) generateProof(rand io.Reader, k *edwards25519.Scalar, B *edwards25519.Point, C, D []*edwards25519.Point) ([]byte, error) {
+	M, Z, err := s.computeComposites(k, B, C, D)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := s.proofParams.Prove(rand, k, B, M, Z)
+	if err != nil {
+		return nil, fmt.Errorf("oprf: failed to generate proof: %w", err)
+	}
+
+	return proof.Bytes(), nil
+}
+
+// verifyProof verifies the serialized proof that `log_G(B) = log_C[i](D[i])`
+// for all i, per RFC 9497 Section 2.2.2.
+func (s *suite) verifyProof(B *edwards25519.Point, C, D []*edwards25519.Point, proofBytes []byte) error {
+	proof, err := new(dleq.Proof).SetBytes(proofBytes)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDeserialize, err)
+	}
+
+	M, Z, err := s.computeComposites(nil, B, C, D)
+	if err != nil {
+		return err
+	}
+
+	if !s.proofParams.Verify(B, M, Z, proof) {
+		return ErrVerify
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package oprf
+
+import (
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+)
+
+// Server is the server side of the protocol.
+type Server struct {
+	suite *suite
+	sk    *PrivateKey
+}
+
+// NewServer creates a new base mode (ModeOPRF) server with the private
+// key.
+func NewServer(sk *PrivateKey) *Server {
+	return &Server{
+		suite: newSuite(ModeOPRF),
+		sk:    sk,
+	}
+}
+
+// NewVerifiableServer creates a new verifiable mode (ModeVOPRF) server
+// with the private key.
+func NewVerifiableServer(sk *PrivateKey) *Server {
+	return &Server{
+		suite: newSuite(ModeVOPRF),
+		sk:    sk,
+	}
+}
+
+// NewPartiallyObliviousServer creates a new partially-oblivious mode
+// (ModePOPRF) server with the private key.
+func NewPartiallyObliviousServer(sk *PrivateKey) *Server {
+	return &Server{
+		suite: newSuite(ModePOPRF),
+		sk:    sk,
+	}
+}
+
+// BlindEvaluate evaluates the serialized blinded element received from
+// the client, and returns the serialized evaluated element.  BlindEvaluate
+// is only supported by base mode (ModeOPRF) servers, as the other modes
+// require a proof, see BlindEvaluateBatch.
+func (s *Server) BlindEvaluate(blindedElement []byte) ([]byte, error) {
+	if s.suite.isVerifiable() {
+		return nil, fmt.Errorf("%w: proof required, use BlindEvaluateBatch", errInvalidMode)
+	}
+
+	M, err := deserializeElement(blindedElement)
+	if err != nil {
+		return nil, err
+	}
+
+	// evaluatedElement = skS * blindedElement
+	Z := edwards25519.NewIdentityPoint().ScalarMult(&s.sk.s, M)
+
+	return Z.Bytes(), nil
+}
+
+// BlindEvaluateBatch evaluates the serialized blinded elements received
+// from the client, and returns the serialized evaluated elements in the
+// same order, along with a single serialized proof covering all of the
+// evaluations, generated using entropy from rand.  For
+// partially-oblivious mode (ModePOPRF) servers, this is equivalent to
+// BlindEvaluateBatchWithInfo with empty info.
+//
+// For base mode (ModeOPRF) servers, no proof is generated, the returned
+// proof is nil, and rand is unused.
+func (s *Server) BlindEvaluateBatch(rand io.Reader, blindedElements [][]byte) ([][]byte, []byte, error) {
+	return s.BlindEvaluateBatchWithInfo(rand, blindedElements, nil)
+}
+
+// BlindEvaluateBatchWithInfo is BlindEvaluateBatch, with the evaluations
+// bound to the public info.  Non-empty info is only supported by
+// partially-oblivious mode (ModePOPRF) servers.
+func (s *Server) BlindEvaluateBatchWithInfo(rand io.Reader, blindedElements [][]byte, info []byte) ([][]byte, []byte, error) {
+	if len(blindedElements) == 0 || len(blindedElements) > maxInputSize {
+		return nil, nil, fmt.Errorf("%w: invalid batch size", ErrInvalidInput)
+	}
+
+	k, t, err := s.evaluationKey(info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	Cs := make([]*edwards25519.Point, 0, len(blindedElements))
+	Ds := make([]*edwards25519.Point, 0, len(blindedElements))
+	evaluatedElements := make([][]byte, 0, len(blindedElements))
+	for i, b := range blindedElements {
+		C, err := deserializeElement(b)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: element %d", err, i)
+		}
+
+		// evaluatedElement = skS * blindedElement, or
+		// evaluatedElement = (1 / t) * blindedElement (ModePOPRF)
+		D := edwards25519.NewIdentityPoint().ScalarMult(k, C)
+
+		Cs = append(Cs, C)
+		Ds = append(Ds, D)
+		evaluatedElements = append(evaluatedElements, D.Bytes())
+	}
+
+	var proof []byte
+	switch s.suite.mode {
+	case ModeVOPRF:
+		proof, err = s.suite.generateProof(rand, &s.sk.s, &s.sk.publicKey.p, Cs, Ds)
+	case ModePOPRF:
+		// Prove that `blindedElement = t * evaluatedElement`.
+		tweakedKey := edwards25519.NewIdentityPoint().ScalarBaseMult(t)
+		proof, err = s.suite.generateProof(rand, t, tweakedKey, Ds, Cs)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return evaluatedElements, proof, nil
+}
+
+// Evaluate computes the PRF output for the input directly, without
+// interacting with a client.  For partially-oblivious mode (ModePOPRF)
+// servers, this is equivalent to EvaluateWithInfo with empty info.
+func (s *Server) Evaluate(input []byte) ([]byte, error) {
+	return s.EvaluateWithInfo(input, nil)
+}
+
+// EvaluateWithInfo computes the PRF output for the input and the public
+// info directly, without interacting with a client.  Non-empty info is
+// only supported by partially-oblivious mode (ModePOPRF) servers.
+func (s *Server) EvaluateWithInfo(input, info []byte) ([]byte, error) {
+	k, _, err := s.evaluationKey(info)
+	if err != nil {
+		return nil, err
+	}
+
+	inputElement, err := s.suite.hashToGroup(input)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluatedElement := edwards25519.NewIdentityPoint().ScalarMult(k, inputElement)
+
+	return s.suite.finalizeHash(input, info, evaluatedElement), nil
+}
+
+// evaluationKey returns the scalar that elements are multiplied by
+// during evaluation, `skS`, or `1 / t` and the tweaked private key t
+// (ModePOPRF).
+func (s *Server) evaluationKey(info []byte) (*edwards25519.Scalar, *edwards25519.Scalar, error) {
+	if s.suite.mode != ModePOPRF {
+		if err := s.suite.checkInfo(info); err != nil {
+			return nil, nil, err
+		}
+		return &s.sk.s, nil, nil
+	}
+
+	t, err := s.suite.tweakPrivateKey(s.sk, info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return edwards25519.NewScalar().Invert(t), t, nil
+}
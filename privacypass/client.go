@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package privacypass
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"gitlab.com/yawning/edwards25519-extra/oprf"
+)
+
+// Client is a Privacy Pass client, that requests and finalizes tokens
+// issued by a specific issuer.
+type Client struct {
+	oprfClient *oprf.Client
+	tokenKeyID [TokenKeyIDSize]byte
+}
+
+// NewClient creates a new client for the issuer public key.
+func NewClient(pk *oprf.PublicKey) *Client {
+	return &Client{
+		oprfClient: oprf.NewVerifiableClient(pk),
+		tokenKeyID: TokenKeyID(pk),
+	}
+}
+
+// TokenRequestState is the client state retained between creating a
+// token request and finalizing the tokens.
+type TokenRequestState struct {
+	challengeDigest [ChallengeDigestSize]byte
+	nonces          [][NonceSize]byte
+	blinds          []*oprf.Blind
+}
+
+// CreateTokenRequest creates a token request for a single token, for
+// the serialized token challenge, using entropy from rand.
+func (c *Client) CreateTokenRequest(rand io.Reader, challenge []byte) (*TokenRequest, *TokenRequestState, error) {
+	state, blindedElements, err := c.newState(rand, challenge, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := &TokenRequest{
+		TruncatedTokenKeyID: truncateTokenKeyID(&c.tokenKeyID),
+		BlindedElement:      blindedElements[0],
+	}
+
+	return req, state, nil
+}
+
+// CreateBatchTokenRequest creates a batched token request for n tokens,
+// for the serialized token challenge, using entropy from rand.
+func (c *Client) CreateBatchTokenRequest(rand io.Reader, challenge []byte, n int) (*BatchTokenRequest, *TokenRequestState, error) {
+	if n <= 0 || n > MaxBatchSize {
+		return nil, nil, fmt.Errorf("privacypass: invalid batch size: %d", n)
+	}
+
+	state, blindedElements, err := c.newState(rand, challenge, n)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := &BatchTokenRequest{
+		TruncatedTokenKeyID: truncateTokenKeyID(&c.tokenKeyID),
+		BlindedElements:     blindedElements,
+	}
+
+	return req, state, nil
+}
+
+// FinalizeToken verifies the token response, and returns the token.
+func (c *Client) FinalizeToken(state *TokenRequestState, resp *TokenResponse) (*Token, error) {
+	if len(state.blinds) != 1 {
+		return nil, errors.New("privacypass: invalid state for a single token")
+	}
+
+	tokens, err := c.finalize(state, [][]byte{resp.EvaluatedElement}, resp.Proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens[0], nil
+}
+
+// FinalizeBatchTokens verifies the batched token response, and returns
+// the tokens.
+func (c *Client) FinalizeBatchTokens(state *TokenRequestState, resp *BatchTokenResponse) ([]*Token, error) {
+	return c.finalize(state, resp.EvaluatedElements, resp.Proof)
+}
+
+func (c *Client) newState(rand io.Reader, challenge []byte, n int) (*TokenRequestState, [][]byte, error) {
+	var tokenChallenge TokenChallenge
+	if err := tokenChallenge.UnmarshalBinary(challenge); err != nil {
+		return nil, nil, fmt.Errorf("privacypass: invalid token challenge: %w", err)
+	}
+	if tokenChallenge.TokenType != TokenType {
+		return nil, nil, fmt.Errorf("%w: %#04x", ErrUnsupportedTokenType, tokenChallenge.TokenType)
+	}
+
+	state := &TokenRequestState{
+		challengeDigest: sha256.Sum256(challenge),
+		nonces:          make([][NonceSize]byte, n),
+		blinds:          make([]*oprf.Blind, 0, n),
+	}
+	blindedElements := make([][]byte, 0, n)
+	for i := range state.nonces {
+		if _, err := io.ReadFull(rand, state.nonces[i][:]); err != nil {
+			return nil, nil, fmt.Errorf("privacypass: failed to generate nonce: %w", err)
+		}
+
+		token := c.newToken(state, i)
+		blind, blindedElement, err := c.oprfClient.Blind(rand, token.tokenInput())
+		if err != nil {
+			return nil, nil, fmt.Errorf("privacypass: failed to blind token input: %w", err)
+		}
+		state.blinds = append(state.blinds, blind)
+		blindedElements = append(blindedElements, blindedElement)
+	}
+
+	return state, blindedElements, nil
+}
+
+func (c *Client) newToken(state *TokenRequestState, i int) *Token {
+	return &Token{
+		Nonce:           state.nonces[i],
+		ChallengeDigest: state.challengeDigest,
+		TokenKeyID:      c.tokenKeyID,
+	}
+}
+
+func (c *Client) finalize(state *TokenRequestState, evaluatedElements [][]byte, proof []byte) ([]*Token, error) {
+	if len(evaluatedElements) != len(state.blinds) {
+		return nil, fmt.Errorf("privacypass: invalid batch size: %d", len(evaluatedElements))
+	}
+
+	outputs, err := c.oprfClient.FinalizeBatch(state.blinds, evaluatedElements, proof)
+	if err != nil {
+		return nil, fmt.Errorf("privacypass: failed to finalize tokens: %w", err)
+	}
+
+	tokens := make([]*Token, 0, len(outputs))
+	for i, output := range outputs {
+		token := c.newToken(state, i)
+		copy(token.Authenticator[:], output)
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package privacypass
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"io"
+
+	"gitlab.com/yawning/edwards25519-extra/oprf"
+)
+
+// Issuer is a Privacy Pass issuer, that issues and verifies tokens with
+// a specific private key.
+type Issuer struct {
+	oprfServer *oprf.Server
+	publicKey  *oprf.PublicKey
+	tokenKeyID [TokenKeyIDSize]byte
+}
+
+// NewIssuer creates a new issuer with the private key.
+func NewIssuer(sk *oprf.PrivateKey) *Issuer {
+	return &Issuer{
+		oprfServer: oprf.NewVerifiableServer(sk),
+		publicKey:  sk.Public(),
+		tokenKeyID: TokenKeyID(sk.Public()),
+	}
+}
+
+// PublicKey returns the issuer public key.
+func (iss *Issuer) PublicKey() *oprf.PublicKey {
+	return iss.publicKey
+}
+
+// TokenKeyID returns the token key ID of the issuer public key.
+func (iss *Issuer) TokenKeyID() [TokenKeyIDSize]byte {
+	return iss.tokenKeyID
+}
+
+// Issue evaluates the token request, using entropy from rand for the
+// proof, and returns the token response.
+func (iss *Issuer) Issue(rand io.Reader, req *TokenRequest) (*TokenResponse, error) {
+	evaluatedElements, proof, err := iss.issue(rand, req.TruncatedTokenKeyID, [][]byte{req.BlindedElement})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenResponse{
+		EvaluatedElement: evaluatedElements[0],
+		Proof:            proof,
+	}, nil
+}
+
+// IssueBatch evaluates the batched token request, using entropy from
+// rand for the proof, and returns the batched token response.
+func (iss *Issuer) IssueBatch(rand io.Reader, req *BatchTokenRequest) (*BatchTokenResponse, error) {
+	if len(req.BlindedElements) == 0 || len(req.BlindedElements) > MaxBatchSize {
+		return nil, fmt.Errorf("privacypass: invalid batch size: %d", len(req.BlindedElements))
+	}
+
+	evaluatedElements, proof, err := iss.issue(rand, req.TruncatedTokenKeyID, req.BlindedElements)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BatchTokenResponse{
+		EvaluatedElements: evaluatedElements,
+		Proof:             proof,
+	}, nil
+}
+
+// VerifyToken verifies that the token was issued by the issuer, for the
+// serialized token challenge.
+//
+// Note: It is the caller's responsibility to reject tokens that have
+// already been redeemed.
+func (iss *Issuer) VerifyToken(token *Token, challenge []byte) error {
+	if subtle.ConstantTimeCompare(token.TokenKeyID[:], iss.tokenKeyID[:]) != 1 {
+		return ErrUnknownKeyID
+	}
+
+	challengeDigest := sha256.Sum256(challenge)
+	if subtle.ConstantTimeCompare(token.ChallengeDigest[:], challengeDigest[:]) != 1 {
+		return fmt.Errorf("%w: challenge mismatch", ErrInvalidToken)
+	}
+
+	authenticator, err := iss.oprfServer.Evaluate(token.tokenInput())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if subtle.ConstantTimeCompare(token.Authenticator[:], authenticator) != 1 {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+func (iss *Issuer) issue(rand io.Reader, truncatedTokenKeyID uint8, blindedElements [][]byte) ([][]byte, []byte, error) {
+	if truncatedTokenKeyID != truncateTokenKeyID(&iss.tokenKeyID) {
+		return nil, nil, ErrUnknownKeyID
+	}
+
+	evaluatedElements, proof, err := iss.oprfServer.BlindEvaluateBatch(rand, blindedElements)
+	if err != nil {
+		return nil, nil, fmt.Errorf("privacypass: failed to evaluate token request: %w", err)
+	}
+
+	return evaluatedElements, proof, nil
+}
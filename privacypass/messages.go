@@ -0,0 +1,283 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package privacypass
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/cryptobyte"
+
+	"gitlab.com/yawning/edwards25519-extra/dleq"
+	"gitlab.com/yawning/edwards25519-extra/oprf"
+)
+
+const (
+	// TokenRequestSize is the size of a serialized TokenRequest in bytes.
+	TokenRequestSize = 2 + 1 + oprf.ElementSize
+
+	// TokenResponseSize is the size of a serialized TokenResponse in
+	// bytes.
+	TokenResponseSize = oprf.ElementSize + dleq.ProofSize
+
+	// MaxBatchSize is the maximum number of tokens that can be issued
+	// in a single batch.
+	MaxBatchSize = maxVectorSize / oprf.ElementSize
+)
+
+// TokenRequest is a token request, as specified in RFC 9578 Section 5.1.
+type TokenRequest struct {
+	// TruncatedTokenKeyID is the least significant byte of the token
+	// key ID of the issuer key.
+	TruncatedTokenKeyID uint8
+
+	// BlindedElement is the serialized blinded element.
+	BlindedElement []byte
+}
+
+// MarshalBinary returns the TokenRequestSize-byte serialized token
+// request.
+func (req *TokenRequest) MarshalBinary() ([]byte, error) {
+	if len(req.BlindedElement) != oprf.ElementSize {
+		return nil, fmt.Errorf("privacypass: invalid blinded element length: %d", len(req.BlindedElement))
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint16(TokenType)
+	b.AddUint8(req.TruncatedTokenKeyID)
+	b.AddBytes(req.BlindedElement)
+	return b.Bytes()
+}
+
+// UnmarshalBinary deserializes a token request.
+func (req *TokenRequest) UnmarshalBinary(data []byte) error {
+	var (
+		s = cryptobyte.String(data)
+
+		tokenType           uint16
+		truncatedTokenKeyID uint8
+		blindedElement      []byte
+	)
+	if !s.ReadUint16(&tokenType) {
+		return ErrMalformed
+	}
+	if tokenType != TokenType {
+		return fmt.Errorf("%w: %#04x", ErrUnsupportedTokenType, tokenType)
+	}
+	if !s.ReadUint8(&truncatedTokenKeyID) ||
+		!s.ReadBytes(&blindedElement, oprf.ElementSize) ||
+		!s.Empty() {
+		return ErrMalformed
+	}
+
+	req.TruncatedTokenKeyID = truncatedTokenKeyID
+	req.BlindedElement = append([]byte{}, blindedElement...)
+
+	return nil
+}
+
+// TokenResponse is a token response, as specified in RFC 9578
+// Section 5.2.
+type TokenResponse struct {
+	// EvaluatedElement is the serialized evaluated element.
+	EvaluatedElement []byte
+
+	// Proof is the serialized proof of correct evaluation.
+	Proof []byte
+}
+
+// MarshalBinary returns the TokenResponseSize-byte serialized token
+// response.
+func (resp *TokenResponse) MarshalBinary() ([]byte, error) {
+	if len(resp.EvaluatedElement) != oprf.ElementSize {
+		return nil, fmt.Errorf("privacypass: invalid evaluated element length: %d", len(resp.EvaluatedElement))
+	}
+	if len(resp.Proof) != dleq.ProofSize {
+		return nil, fmt.Errorf("privacypass: invalid proof length: %d", len(resp.Proof))
+	}
+
+	b := make([]byte, 0, TokenResponseSize)
+	b = append(b, resp.EvaluatedElement...)
+	b = append(b, resp.Proof...)
+	return b, nil
+}
+
+// UnmarshalBinary deserializes a token response.
+func (resp *TokenResponse) UnmarshalBinary(data []byte) error {
+	if len(data) != TokenResponseSize {
+		return ErrMalformed
+	}
+
+	resp.EvaluatedElement = append([]byte{}, data[:oprf.ElementSize]...)
+	resp.Proof = append([]byte{}, data[oprf.ElementSize:]...)
+
+	return nil
+}
+
+// BatchTokenRequest is a batched token request, as specified in the
+// "Batched Token Issuance Protocol" draft.
+type BatchTokenRequest struct {
+	// TruncatedTokenKeyID is the least significant byte of the token
+	// key ID of the issuer key.
+	TruncatedTokenKeyID uint8
+
+	// BlindedElements are the serialized blinded elements.
+	BlindedElements [][]byte
+}
+
+// MarshalBinary returns the serialized batched token request.
+func (req *BatchTokenRequest) MarshalBinary() ([]byte, error) {
+	if err := checkElements(req.BlindedElements); err != nil {
+		return nil, err
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint16(TokenType)
+	b.AddUint8(req.TruncatedTokenKeyID)
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		for _, e := range req.BlindedElements {
+			b.AddBytes(e)
+		}
+	})
+	return b.Bytes()
+}
+
+// UnmarshalBinary deserializes a batched token request.
+func (req *BatchTokenRequest) UnmarshalBinary(data []byte) error {
+	var (
+		s = cryptobyte.String(data)
+
+		tokenType           uint16
+		truncatedTokenKeyID uint8
+		blindedElements     cryptobyte.String
+	)
+	if !s.ReadUint16(&tokenType) {
+		return ErrMalformed
+	}
+	if tokenType != TokenType {
+		return fmt.Errorf("%w: %#04x", ErrUnsupportedTokenType, tokenType)
+	}
+	if !s.ReadUint8(&truncatedTokenKeyID) ||
+		!s.ReadUint16LengthPrefixed(&blindedElements) ||
+		!s.Empty() {
+		return ErrMalformed
+	}
+
+	elements, err := splitElements(blindedElements)
+	if err != nil {
+		return err
+	}
+
+	req.TruncatedTokenKeyID = truncatedTokenKeyID
+	req.BlindedElements = elements
+
+	return nil
+}
+
+// BatchTokenResponse is a batched token response, as specified in the
+// "Batched Token Issuance Protocol" draft.
+type BatchTokenResponse struct {
+	// EvaluatedElements are the serialized evaluated elements, in the
+	// same order as the blinded elements in the request.
+	EvaluatedElements [][]byte
+
+	// Proof is the serialized proof of correct evaluation, covering all
+	// of the evaluated elements.
+	Proof []byte
+}
+
+// MarshalBinary returns the serialized batched token response.
+func (resp *BatchTokenResponse) MarshalBinary() ([]byte, error) {
+	if err := checkElements(resp.EvaluatedElements); err != nil {
+		return nil, err
+	}
+	if len(resp.Proof) != dleq.ProofSize {
+		return nil, fmt.Errorf("privacypass: invalid proof length: %d", len(resp.Proof))
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		for _, e := range resp.EvaluatedElements {
+			b.AddBytes(e)
+		}
+	})
+	b.AddBytes(resp.Proof)
+	return b.Bytes()
+}
+
+// UnmarshalBinary deserializes a batched token response.
+func (resp *BatchTokenResponse) UnmarshalBinary(data []byte) error {
+	var (
+		s = cryptobyte.String(data)
+
+		evaluatedElements cryptobyte.String
+		proof             []byte
+	)
+	if !s.ReadUint16LengthPrefixed(&evaluatedElements) ||
+		!s.ReadBytes(&proof, dleq.ProofSize) ||
+		!s.Empty() {
+		return ErrMalformed
+	}
+
+	elements, err := splitElements(evaluatedElements)
+	if err != nil {
+		return err
+	}
+
+	resp.EvaluatedElements = elements
+	resp.Proof = append([]byte{}, proof...)
+
+	return nil
+}
+
+func checkElements(elements [][]byte) error {
+	if len(elements) == 0 || len(elements) > MaxBatchSize {
+		return fmt.Errorf("privacypass: invalid batch size: %d", len(elements))
+	}
+	for i, e := range elements {
+		if len(e) != oprf.ElementSize {
+			return fmt.Errorf("privacypass: invalid element length: element %d: %d", i, len(e))
+		}
+	}
+	return nil
+}
+
+func splitElements(s cryptobyte.String) ([][]byte, error) {
+	if len(s) == 0 || len(s)%oprf.ElementSize != 0 {
+		return nil, fmt.Errorf("%w: invalid element vector length: %d", ErrMalformed, len(s))
+	}
+
+	elements := make([][]byte, 0, len(s)/oprf.ElementSize)
+	for !s.Empty() {
+		var e []byte
+		_ = s.ReadBytes(&e, oprf.ElementSize)
+		elements = append(elements, append([]byte{}, e...))
+	}
+	return elements, nil
+}
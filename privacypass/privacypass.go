@@ -0,0 +1,250 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package privacypass implements the Privacy Pass issuance and
+// redemption protocols for privately verifiable tokens, as specified in
+// RFC 9577 and RFC 9578, with batched issuance as specified in the
+// "Batched Token Issuance Protocol" draft, on top of the verifiable
+// mode of the edwards25519-SHA512 OPRF ciphersuite provided by the oprf
+// package.
+//
+// As there is no registered token type for edwards25519, this package
+// uses an unregistered token type (TokenType), and interoperability
+// with other implementations requires agreeing on it out of band.
+//
+// Preventing double spending (eg: by tracking the nonces of redeemed
+// tokens) is the caller's responsibility.
+package privacypass
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/cryptobyte"
+
+	"gitlab.com/yawning/edwards25519-extra/oprf"
+)
+
+const (
+	// TokenType is the token type used by this package.
+	TokenType uint16 = 0xed25
+
+	// NonceSize is the size of a token nonce in bytes.
+	NonceSize = 32
+
+	// ChallengeDigestSize is the size of a token challenge digest in
+	// bytes.
+	ChallengeDigestSize = sha256.Size
+
+	// TokenKeyIDSize is the size of a token key ID in bytes.
+	TokenKeyIDSize = sha256.Size
+
+	// AuthenticatorSize is the size of a token authenticator in bytes.
+	AuthenticatorSize = oprf.OutputSize
+
+	// TokenSize is the size of a serialized Token in bytes.
+	TokenSize = 2 + NonceSize + ChallengeDigestSize + TokenKeyIDSize + AuthenticatorSize
+
+	// RedemptionContextSize is the size of a non-empty token challenge
+	// redemption context in bytes.
+	RedemptionContextSize = 32
+
+	maxVectorSize = 1<<16 - 1
+)
+
+var (
+	// ErrMalformed is the error returned when a message fails to
+	// deserialize.
+	ErrMalformed = errors.New("privacypass: malformed message")
+
+	// ErrUnsupportedTokenType is the error returned when a message has
+	// a token type other than TokenType.
+	ErrUnsupportedTokenType = errors.New("privacypass: unsupported token type")
+
+	// ErrUnknownKeyID is the error returned when a message refers to a
+	// token key other than the issuer's.
+	ErrUnknownKeyID = errors.New("privacypass: unknown token key ID")
+
+	// ErrInvalidToken is the error returned when a token fails to
+	// verify.
+	ErrInvalidToken = errors.New("privacypass: invalid token")
+)
+
+// TokenKeyID returns the token key ID of the issuer public key, which
+// is the SHA-256 digest of the serialized public key.
+func TokenKeyID(pk *oprf.PublicKey) [TokenKeyIDSize]byte {
+	return sha256.Sum256(pk.Bytes())
+}
+
+// TokenChallenge is a token challenge, as specified in RFC 9577
+// Section 2.1.
+type TokenChallenge struct {
+	// TokenType is the token type, which MUST be TokenType to be
+	// usable with this package.
+	TokenType uint16
+
+	// IssuerName is the name of the issuer.
+	IssuerName string
+
+	// RedemptionContext is either empty, or a RedemptionContextSize-byte
+	// value binding the challenge to a context (eg: a session).
+	RedemptionContext []byte
+
+	// OriginInfo is the (optional) comma separated list of origin names
+	// that may redeem the token.
+	OriginInfo string
+}
+
+// MarshalBinary returns the serialized token challenge.
+func (challenge *TokenChallenge) MarshalBinary() ([]byte, error) {
+	switch {
+	case len(challenge.IssuerName) == 0 || len(challenge.IssuerName) > maxVectorSize:
+		return nil, fmt.Errorf("privacypass: invalid issuer name length: %d", len(challenge.IssuerName))
+	case len(challenge.RedemptionContext) != 0 && len(challenge.RedemptionContext) != RedemptionContextSize:
+		return nil, fmt.Errorf("privacypass: invalid redemption context length: %d", len(challenge.RedemptionContext))
+	case len(challenge.OriginInfo) > maxVectorSize:
+		return nil, fmt.Errorf("privacypass: invalid origin info length: %d", len(challenge.OriginInfo))
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint16(challenge.TokenType)
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes([]byte(challenge.IssuerName))
+	})
+	b.AddUint8LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(challenge.RedemptionContext)
+	})
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes([]byte(challenge.OriginInfo))
+	})
+	return b.Bytes()
+}
+
+// UnmarshalBinary deserializes a token challenge.
+func (challenge *TokenChallenge) UnmarshalBinary(data []byte) error {
+	var (
+		s = cryptobyte.String(data)
+
+		tokenType                                 uint16
+		issuerName, redemptionContext, originInfo cryptobyte.String
+	)
+	if !s.ReadUint16(&tokenType) ||
+		!s.ReadUint16LengthPrefixed(&issuerName) ||
+		!s.ReadUint8LengthPrefixed(&redemptionContext) ||
+		!s.ReadUint16LengthPrefixed(&originInfo) ||
+		!s.Empty() {
+		return ErrMalformed
+	}
+	if len(issuerName) == 0 {
+		return fmt.Errorf("%w: empty issuer name", ErrMalformed)
+	}
+	if len(redemptionContext) != 0 && len(redemptionContext) != RedemptionContextSize {
+		return fmt.Errorf("%w: invalid redemption context length: %d", ErrMalformed, len(redemptionContext))
+	}
+
+	challenge.TokenType = tokenType
+	challenge.IssuerName = string(issuerName)
+	challenge.RedemptionContext = nil
+	if len(redemptionContext) != 0 {
+		challenge.RedemptionContext = append([]byte{}, redemptionContext...)
+	}
+	challenge.OriginInfo = string(originInfo)
+
+	return nil
+}
+
+// Token is a token, as specified in RFC 9577 Section 2.2.
+type Token struct {
+	// Nonce is the client generated nonce.
+	Nonce [NonceSize]byte
+
+	// ChallengeDigest is the SHA-256 digest of the serialized token
+	// challenge the token was issued for.
+	ChallengeDigest [ChallengeDigestSize]byte
+
+	// TokenKeyID is the token key ID of the issuer key.
+	TokenKeyID [TokenKeyIDSize]byte
+
+	// Authenticator is the OPRF output over the token input.
+	Authenticator [AuthenticatorSize]byte
+}
+
+// MarshalBinary returns the TokenSize-byte serialized token.
+func (token *Token) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, TokenSize)
+	b = append(b, byte(TokenType>>8), byte(TokenType&0xff))
+	b = append(b, token.Nonce[:]...)
+	b = append(b, token.ChallengeDigest[:]...)
+	b = append(b, token.TokenKeyID[:]...)
+	b = append(b, token.Authenticator[:]...)
+	return b, nil
+}
+
+// UnmarshalBinary deserializes a token.
+func (token *Token) UnmarshalBinary(data []byte) error {
+	var (
+		s = cryptobyte.String(data)
+
+		tokenType uint16
+		tmp       Token
+	)
+	if !s.ReadUint16(&tokenType) {
+		return ErrMalformed
+	}
+	if tokenType != TokenType {
+		return fmt.Errorf("%w: %#04x", ErrUnsupportedTokenType, tokenType)
+	}
+	if !s.CopyBytes(tmp.Nonce[:]) ||
+		!s.CopyBytes(tmp.ChallengeDigest[:]) ||
+		!s.CopyBytes(tmp.TokenKeyID[:]) ||
+		!s.CopyBytes(tmp.Authenticator[:]) ||
+		!s.Empty() {
+		return ErrMalformed
+	}
+
+	*token = tmp
+
+	return nil
+}
+
+// tokenInput returns the OPRF input for the token.
+func (token *Token) tokenInput() []byte {
+	// token_input = concat(0xED25, nonce, challenge_digest, token_key_id)
+	b := make([]byte, 0, TokenSize-AuthenticatorSize)
+	b = append(b, byte(TokenType>>8), byte(TokenType&0xff))
+	b = append(b, token.Nonce[:]...)
+	b = append(b, token.ChallengeDigest[:]...)
+	b = append(b, token.TokenKeyID[:]...)
+	return b
+}
+
+func truncateTokenKeyID(tokenKeyID *[TokenKeyIDSize]byte) uint8 {
+	return tokenKeyID[TokenKeyIDSize-1]
+}
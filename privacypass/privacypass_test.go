@@ -0,0 +1,303 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package privacypass
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"gitlab.com/yawning/edwards25519-extra/oprf"
+)
+
+func newTestIssuer(t *testing.T) *Issuer {
+	sk, err := oprf.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("oprf.GenerateKey: %v", err)
+	}
+	return NewIssuer(sk)
+}
+
+func newTestChallenge(t *testing.T, originInfo string) []byte {
+	tokenChallenge := &TokenChallenge{
+		TokenType:         TokenType,
+		IssuerName:        "issuer.example",
+		RedemptionContext: bytes.Repeat([]byte{0x17}, RedemptionContextSize),
+		OriginInfo:        originInfo,
+	}
+	challenge, err := tokenChallenge.MarshalBinary()
+	if err != nil {
+		t.Fatalf("TokenChallenge.MarshalBinary: %v", err)
+	}
+	return challenge
+}
+
+// roundTrip serializes and deserializes a message, as if it were sent
+// over the wire.
+func roundTrip(t *testing.T, src interface{ MarshalBinary() ([]byte, error) }, dst interface{ UnmarshalBinary([]byte) error }) {
+	b, err := src.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	if err = dst.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+}
+
+func TestPrivacyPass(t *testing.T) {
+	issuer := newTestIssuer(t)
+	client := NewClient(issuer.PublicKey())
+	challenge := newTestChallenge(t, "origin.example")
+
+	t.Run("Issuance", func(t *testing.T) {
+		req, state, err := client.CreateTokenRequest(rand.Reader, challenge)
+		if err != nil {
+			t.Fatalf("CreateTokenRequest: %v", err)
+		}
+		var wireReq TokenRequest
+		roundTrip(t, req, &wireReq)
+
+		resp, err := issuer.Issue(rand.Reader, &wireReq)
+		if err != nil {
+			t.Fatalf("Issue: %v", err)
+		}
+		var wireResp TokenResponse
+		roundTrip(t, resp, &wireResp)
+
+		token, err := client.FinalizeToken(state, &wireResp)
+		if err != nil {
+			t.Fatalf("FinalizeToken: %v", err)
+		}
+		var wireToken Token
+		roundTrip(t, token, &wireToken)
+
+		if err = issuer.VerifyToken(&wireToken, challenge); err != nil {
+			t.Fatalf("VerifyToken: %v", err)
+		}
+	})
+	t.Run("BatchedIssuance", func(t *testing.T) {
+		const n = 5
+
+		req, state, err := client.CreateBatchTokenRequest(rand.Reader, challenge, n)
+		if err != nil {
+			t.Fatalf("CreateBatchTokenRequest: %v", err)
+		}
+		var wireReq BatchTokenRequest
+		roundTrip(t, req, &wireReq)
+
+		resp, err := issuer.IssueBatch(rand.Reader, &wireReq)
+		if err != nil {
+			t.Fatalf("IssueBatch: %v", err)
+		}
+		var wireResp BatchTokenResponse
+		roundTrip(t, resp, &wireResp)
+
+		tokens, err := client.FinalizeBatchTokens(state, &wireResp)
+		if err != nil {
+			t.Fatalf("FinalizeBatchTokens: %v", err)
+		}
+		if len(tokens) != n {
+			t.Fatalf("FinalizeBatchTokens: unexpected number of tokens: %d", len(tokens))
+		}
+		for i, token := range tokens {
+			if err = issuer.VerifyToken(token, challenge); err != nil {
+				t.Fatalf("[%d]: VerifyToken: %v", i, err)
+			}
+			if i > 0 && token.Nonce == tokens[0].Nonce {
+				t.Fatalf("[%d]: tokens share a nonce", i)
+			}
+		}
+
+		// Tampering with the order of the evaluated elements is
+		// detected by the proof.
+		wireResp.EvaluatedElements[0], wireResp.EvaluatedElements[1] = wireResp.EvaluatedElements[1], wireResp.EvaluatedElements[0]
+		if _, err = client.FinalizeBatchTokens(state, &wireResp); !errors.Is(err, oprf.ErrVerify) {
+			t.Fatalf("FinalizeBatchTokens(swapped): unexpected error: %v", err)
+		}
+	})
+	t.Run("Redemption", func(t *testing.T) {
+		req, state, err := client.CreateTokenRequest(rand.Reader, challenge)
+		if err != nil {
+			t.Fatalf("CreateTokenRequest: %v", err)
+		}
+		resp, err := issuer.Issue(rand.Reader, req)
+		if err != nil {
+			t.Fatalf("Issue: %v", err)
+		}
+		token, err := client.FinalizeToken(state, resp)
+		if err != nil {
+			t.Fatalf("FinalizeToken: %v", err)
+		}
+
+		// The token is bound to the challenge.
+		otherChallenge := newTestChallenge(t, "other.example")
+		if err = issuer.VerifyToken(token, otherChallenge); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("VerifyToken(other challenge): unexpected error: %v", err)
+		}
+
+		badToken := *token
+		badToken.Authenticator[0] ^= 0x01
+		if err = issuer.VerifyToken(&badToken, challenge); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("VerifyToken(bad authenticator): unexpected error: %v", err)
+		}
+
+		badToken = *token
+		badToken.Nonce[0] ^= 0x01
+		if err = issuer.VerifyToken(&badToken, challenge); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("VerifyToken(bad nonce): unexpected error: %v", err)
+		}
+
+		otherIssuer := newTestIssuer(t)
+		if err = otherIssuer.VerifyToken(token, challenge); !errors.Is(err, ErrUnknownKeyID) {
+			t.Fatalf("VerifyToken(other issuer): unexpected error: %v", err)
+		}
+	})
+	t.Run("WrongIssuer", func(t *testing.T) {
+		otherIssuer := newTestIssuer(t)
+
+		req, state, err := client.CreateTokenRequest(rand.Reader, challenge)
+		if err != nil {
+			t.Fatalf("CreateTokenRequest: %v", err)
+		}
+
+		// Force the truncated key ID to match, so that the issuer
+		// evaluates the request with the wrong key.
+		otherKeyID := otherIssuer.TokenKeyID()
+		req.TruncatedTokenKeyID = truncateTokenKeyID(&otherKeyID)
+		resp, err := otherIssuer.Issue(rand.Reader, req)
+		if err != nil {
+			t.Fatalf("Issue: %v", err)
+		}
+		if _, err = client.FinalizeToken(state, resp); !errors.Is(err, oprf.ErrVerify) {
+			t.Fatalf("FinalizeToken(wrong issuer): unexpected error: %v", err)
+		}
+
+		req.TruncatedTokenKeyID++
+		if _, err = otherIssuer.Issue(rand.Reader, req); !errors.Is(err, ErrUnknownKeyID) {
+			t.Fatalf("Issue(wrong key ID): unexpected error: %v", err)
+		}
+	})
+}
+
+func TestSerialization(t *testing.T) {
+	t.Run("TokenChallenge", func(t *testing.T) {
+		for _, v := range []TokenChallenge{
+			{TokenType, "issuer.example", nil, ""},
+			{TokenType, "issuer.example", bytes.Repeat([]byte{0xaa}, RedemptionContextSize), "a.example,b.example"},
+		} {
+			b, err := v.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary: %v", err)
+			}
+			var decoded TokenChallenge
+			if err = decoded.UnmarshalBinary(b); err != nil {
+				t.Fatalf("UnmarshalBinary: %v", err)
+			}
+			if decoded.TokenType != v.TokenType ||
+				decoded.IssuerName != v.IssuerName ||
+				!bytes.Equal(decoded.RedemptionContext, v.RedemptionContext) ||
+				decoded.OriginInfo != v.OriginInfo {
+				t.Fatalf("UnmarshalBinary(MarshalBinary(v)) != v")
+			}
+			if err = decoded.UnmarshalBinary(append(b, 0x00)); !errors.Is(err, ErrMalformed) {
+				t.Fatalf("UnmarshalBinary(trailing garbage): unexpected error: %v", err)
+			}
+		}
+
+		for _, v := range []TokenChallenge{
+			{TokenType, "", nil, ""},
+			{TokenType, "issuer.example", []byte{0x01}, ""},
+		} {
+			if _, err := v.MarshalBinary(); err == nil {
+				t.Fatalf("MarshalBinary(%+v): succeeded", v)
+			}
+		}
+
+		// The client rejects challenges for other token types.
+		challenge, err := (&TokenChallenge{0x0001, "issuer.example", nil, ""}).MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary: %v", err)
+		}
+		client := NewClient(newTestIssuer(t).PublicKey())
+		if _, _, err = client.CreateTokenRequest(rand.Reader, challenge); !errors.Is(err, ErrUnsupportedTokenType) {
+			t.Fatalf("CreateTokenRequest(token type 1): unexpected error: %v", err)
+		}
+	})
+	t.Run("Messages", func(t *testing.T) {
+		var (
+			req       TokenRequest
+			resp      TokenResponse
+			batchReq  BatchTokenRequest
+			batchResp BatchTokenResponse
+			token     Token
+		)
+
+		reqBytes := make([]byte, TokenRequestSize)
+		reqBytes[0], reqBytes[1] = 0xed, 0x25
+		if err := req.UnmarshalBinary(reqBytes); err != nil {
+			t.Fatalf("TokenRequest.UnmarshalBinary: %v", err)
+		}
+		if err := req.UnmarshalBinary(reqBytes[:TokenRequestSize-1]); !errors.Is(err, ErrMalformed) {
+			t.Fatalf("TokenRequest.UnmarshalBinary(truncated): unexpected error: %v", err)
+		}
+		reqBytes[1] = 0x01
+		if err := req.UnmarshalBinary(reqBytes); !errors.Is(err, ErrUnsupportedTokenType) {
+			t.Fatalf("TokenRequest.UnmarshalBinary(wrong type): unexpected error: %v", err)
+		}
+
+		if err := resp.UnmarshalBinary(make([]byte, TokenResponseSize+1)); !errors.Is(err, ErrMalformed) {
+			t.Fatalf("TokenResponse.UnmarshalBinary(oversized): unexpected error: %v", err)
+		}
+
+		// tokenType || truncatedTokenKeyID || len || partial element
+		if err := batchReq.UnmarshalBinary([]byte{0xed, 0x25, 0x00, 0x00, 0x01, 0x00}); !errors.Is(err, ErrMalformed) {
+			t.Fatalf("BatchTokenRequest.UnmarshalBinary(partial element): unexpected error: %v", err)
+		}
+		if err := batchReq.UnmarshalBinary([]byte{0xed, 0x25, 0x00, 0x00, 0x00}); !errors.Is(err, ErrMalformed) {
+			t.Fatalf("BatchTokenRequest.UnmarshalBinary(empty): unexpected error: %v", err)
+		}
+		if _, err := (&BatchTokenRequest{}).MarshalBinary(); err == nil {
+			t.Fatalf("BatchTokenRequest.MarshalBinary(empty): succeeded")
+		}
+		if err := batchResp.UnmarshalBinary([]byte{0x00, 0x00}); !errors.Is(err, ErrMalformed) {
+			t.Fatalf("BatchTokenResponse.UnmarshalBinary(truncated): unexpected error: %v", err)
+		}
+
+		tokenBytes := make([]byte, TokenSize)
+		tokenBytes[0], tokenBytes[1] = 0xed, 0x25
+		if err := token.UnmarshalBinary(tokenBytes); err != nil {
+			t.Fatalf("Token.UnmarshalBinary: %v", err)
+		}
+		if err := token.UnmarshalBinary(tokenBytes[:TokenSize-1]); !errors.Is(err, ErrMalformed) {
+			t.Fatalf("Token.UnmarshalBinary(truncated): unexpected error: %v", err)
+		}
+	})
+}
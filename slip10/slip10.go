@@ -0,0 +1,183 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package slip10 implements SLIP-0010 hierarchical deterministic key
+// derivation for Ed25519.  As SLIP-0010 does not support non-hardened
+// derivation for Ed25519, all derivation is hardened.
+//
+// Derived keys are returned as crypto/ed25519 keys, and are thus usable
+// directly with the vrf package, and anything else that takes standard
+// Ed25519 keys.
+package slip10
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// HardenedOffset is the offset added to an index to make it a
+	// hardened index.
+	HardenedOffset uint32 = 0x80000000
+
+	// MinSeedSize is the minimum seed size in bytes.
+	MinSeedSize = 16
+
+	// MaxSeedSize is the maximum seed size in bytes.
+	MaxSeedSize = 64
+
+	// ChainCodeSize is the size of a chain code in bytes.
+	ChainCodeSize = 32
+)
+
+var (
+	// ErrInvalidSeed is the error returned when a seed is malformed.
+	ErrInvalidSeed = errors.New("slip10: invalid seed")
+
+	// ErrNonHardenedIndex is the error returned when attempting to
+	// derive a child key with a non-hardened index.
+	ErrNonHardenedIndex = errors.New("slip10: non-hardened index")
+
+	// ErrInvalidPath is the error returned when a derivation path is
+	// malformed.
+	ErrInvalidPath = errors.New("slip10: invalid path")
+
+	curveKey = []byte("ed25519 seed")
+)
+
+// Key is an extended private key.
+type Key struct {
+	seed      [ed25519.SeedSize]byte
+	chainCode [ChainCodeSize]byte
+}
+
+// NewMasterKey derives the master extended private key from the seed.
+func NewMasterKey(seed []byte) (*Key, error) {
+	if l := len(seed); l < MinSeedSize || l > MaxSeedSize {
+		return nil, fmt.Errorf("%w: invalid length: %d", ErrInvalidSeed, l)
+	}
+
+	// I = HMAC-SHA512(Key = "ed25519 seed", Data = S)
+	mac := hmac.New(sha512.New, curveKey)
+	_, _ = mac.Write(seed)
+
+	return newKey(mac.Sum(nil)), nil
+}
+
+// Derive derives the child extended private key with the hardened index
+// (>= HardenedOffset).
+func (k *Key) Derive(index uint32) (*Key, error) {
+	if index < HardenedOffset {
+		return nil, fmt.Errorf("%w: %d", ErrNonHardenedIndex, index)
+	}
+
+	// I = HMAC-SHA512(Key = c_par, Data = 0x00 || ser256(k_par) || ser32(i))
+	var data [1 + ed25519.SeedSize + 4]byte
+	copy(data[1:], k.seed[:])
+	binary.BigEndian.PutUint32(data[1+ed25519.SeedSize:], index)
+
+	mac := hmac.New(sha512.New, k.chainCode[:])
+	_, _ = mac.Write(data[:])
+
+	for i := range data {
+		data[i] = 0
+	}
+
+	return newKey(mac.Sum(nil)), nil
+}
+
+// DerivePath derives the descendant extended private key along the path
+// of hardened indexes.
+func (k *Key) DerivePath(path []uint32) (*Key, error) {
+	var err error
+	for _, index := range path {
+		if k, err = k.Derive(index); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// PrivateKey returns the Ed25519 private key.
+func (k *Key) PrivateKey() ed25519.PrivateKey {
+	return ed25519.NewKeyFromSeed(k.seed[:])
+}
+
+// PublicKey returns the Ed25519 public key.
+func (k *Key) PublicKey() ed25519.PublicKey {
+	return k.PrivateKey().Public().(ed25519.PublicKey)
+}
+
+// ChainCode returns the chain code.
+func (k *Key) ChainCode() []byte {
+	return append([]byte{}, k.chainCode[:]...)
+}
+
+// ParsePath parses a derivation path of the form "m/44'/0'/0'", where
+// each index MUST be hardened (suffixed with "'", "H" or "h").
+func ParsePath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("%w: missing master key", ErrInvalidPath)
+	}
+
+	indexes := make([]uint32, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		trimmed := strings.TrimRight(segment, "'Hh")
+		if len(segment)-len(trimmed) != 1 {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidPath, segment, ErrNonHardenedIndex)
+		}
+		index, err := strconv.ParseUint(trimmed, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidPath, segment, err)
+		}
+		indexes = append(indexes, uint32(index)+HardenedOffset)
+	}
+
+	return indexes, nil
+}
+
+func newKey(i []byte) *Key {
+	// IL is the key, IR is the chain code.
+	var k Key
+	copy(k.seed[:], i[:ed25519.SeedSize])
+	copy(k.chainCode[:], i[ed25519.SeedSize:])
+
+	for j := range i {
+		i[j] = 0
+	}
+
+	return &k
+}
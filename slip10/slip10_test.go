@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Yawning Angel. All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+// 1. Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//
+// 2. Redistributions in binary form must reproduce the above copyright
+// notice, this list of conditions and the following disclaimer in the
+// documentation and/or other materials provided with the distribution.
+//
+// 3. Neither the name of the copyright holder nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS
+// IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED
+// TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A
+// PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+// TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+// PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+// LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+// NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package slip10
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"gitlab.com/yawning/edwards25519-extra/vrf"
+)
+
+func mustUnhex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Test vectors from SLIP-0010, "Test vector 1 for ed25519" and "Test
+// vector 2 for ed25519".  The public keys are prefixed with 0x00 as in
+// the specification.
+var testVectors = []struct {
+	seed  string
+	steps []struct {
+		path       string
+		chainCode  string
+		privateKey string
+		publicKey  string
+	}
+}{
+	{
+		seed: "000102030405060708090a0b0c0d0e0f",
+		steps: []struct {
+			path       string
+			chainCode  string
+			privateKey string
+			publicKey  string
+		}{
+			{
+				"m",
+				"90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb",
+				"2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+				"00a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed",
+			},
+			{
+				"m/0H",
+				"8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69",
+				"68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+				"008c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c",
+			},
+			{
+				"m/0H/1H",
+				"a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14",
+				"b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+				"001932a5270f335bed617d5b935c80aedb1a35bd9fc1e31acafd5372c30f5c1187",
+			},
+			{
+				"m/0H/1H/2H",
+				"2e69929e00b5ab250f49c3fb1c12f252de4fed2c1db88387094a0f8c4c9ccd6c",
+				"92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9",
+				"00ae98736566d30ed0e9d2f4486a64bc95740d89c7db33f52121f8ea8f76ff0fc1",
+			},
+			{
+				"m/0H/1H/2H/2H",
+				"8f6d87f93d750e0efccda017d662a1b31a266e4a6f5993b15f5c1f07f74dd5cc",
+				"30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662",
+				"008abae2d66361c879b900d204ad2cc4984fa2aa344dd7ddc46007329ac76c429c",
+			},
+			{
+				"m/0H/1H/2H/2H/1000000000H",
+				"68789923a0cac2cd5a29172a475fe9e0fb14cd6adb5ad98a3fa70333e7afa230",
+				"8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793",
+				"003c24da049451555d51a7014a37337aa4e12d41e485abccfa46b47dfb2af54b7a",
+			},
+		},
+	},
+	{
+		seed: "fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542",
+		steps: []struct {
+			path       string
+			chainCode  string
+			privateKey string
+			publicKey  string
+		}{
+			{
+				"m",
+				"ef70a74db9c3a5af931b5fe73ed8e1a53464133654fd55e7a66f8570b8e33c3b",
+				"171cb88b1b3c1db25add599712e36245d75bc65a1a5c9e18d76f9f2b1eab4012",
+				"008fe9693f8fa62a4305a140b9764c5ee01e455963744fe18204b4fb948249308a",
+			},
+			{
+				"m/0H",
+				"0b78a3226f915c082bf118f83618a618ab6dec793752624cbeb622acb562862d",
+				"1559eb2bbec5790b0c65d8693e4d0875b1747f4970ae8b650486ed7470845635",
+				"0086fab68dcb57aa196c77c5f264f215a112c22a912c10d123b0d03c3c28ef1037",
+			},
+		},
+	},
+}
+
+func TestSLIP10(t *testing.T) {
+	for i, vec := range testVectors {
+		master, err := NewMasterKey(mustUnhex(vec.seed))
+		if err != nil {
+			t.Fatalf("[%d]: NewMasterKey: %v", i, err)
+		}
+		for _, step := range vec.steps {
+			path, err := ParsePath(step.path)
+			if err != nil {
+				t.Fatalf("[%d]: ParsePath(%s): %v", i, step.path, err)
+			}
+			k, err := master.DerivePath(path)
+			if err != nil {
+				t.Fatalf("[%d]: DerivePath(%s): %v", i, step.path, err)
+			}
+
+			if got := hex.EncodeToString(k.ChainCode()); got != step.chainCode {
+				t.Errorf("[%d]: %s: unexpected chain code: %s", i, step.path, got)
+			}
+			if got := hex.EncodeToString(k.PrivateKey().Seed()); got != step.privateKey {
+				t.Errorf("[%d]: %s: unexpected private key: %s", i, step.path, got)
+			}
+			if got := "00" + hex.EncodeToString(k.PublicKey()); got != step.publicKey {
+				t.Errorf("[%d]: %s: unexpected public key: %s", i, step.path, got)
+			}
+		}
+	}
+}
+
+func TestDerivation(t *testing.T) {
+	master, err := NewMasterKey(mustUnhex(testVectors[0].seed))
+	if err != nil {
+		t.Fatalf("NewMasterKey: %v", err)
+	}
+
+	t.Run("VRF", func(t *testing.T) {
+		// Derived keys are usable directly with the vrf package.
+		k, err := master.Derive(HardenedOffset + 7)
+		if err != nil {
+			t.Fatalf("Derive: %v", err)
+		}
+		alpha := []byte("slip10 vrf test")
+		proof := vrf.Prove(k.PrivateKey(), alpha)
+		if ok, _ := vrf.Verify(k.PublicKey(), proof, alpha); !ok {
+			t.Fatalf("vrf.Verify: failed")
+		}
+		if !k.PublicKey().Equal(k.PrivateKey().Public()) {
+			t.Fatalf("PublicKey() != PrivateKey().Public()")
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := master.Derive(0); !errors.Is(err, ErrNonHardenedIndex) {
+			t.Fatalf("Derive(0): unexpected error: %v", err)
+		}
+		if _, err := master.DerivePath([]uint32{HardenedOffset, 1}); !errors.Is(err, ErrNonHardenedIndex) {
+			t.Fatalf("DerivePath(m/0H/1): unexpected error: %v", err)
+		}
+		for _, l := range []int{MinSeedSize - 1, MaxSeedSize + 1} {
+			if _, err := NewMasterKey(make([]byte, l)); !errors.Is(err, ErrInvalidSeed) {
+				t.Fatalf("NewMasterKey(%d bytes): unexpected error: %v", l, err)
+			}
+		}
+	})
+	t.Run("ParsePath", func(t *testing.T) {
+		path, err := ParsePath("m/44'/0h/2147483647H")
+		if err != nil {
+			t.Fatalf("ParsePath: %v", err)
+		}
+		if len(path) != 3 || path[0] != HardenedOffset+44 || path[1] != HardenedOffset || path[2] != ^uint32(0) {
+			t.Fatalf("ParsePath: unexpected path: %v", path)
+		}
+		if path, err = ParsePath("m"); err != nil || len(path) != 0 {
+			t.Fatalf("ParsePath(m): %v %v", path, err)
+		}
+
+		for _, s := range []string{
+			"",
+			"0'",
+			"m/",
+			"m/0",
+			"m/0''",
+			"m/'",
+			"m/-1'",
+			"m/2147483648'",
+		} {
+			if _, err := ParsePath(s); !errors.Is(err, ErrInvalidPath) {
+				t.Fatalf("ParsePath(%q): unexpected error: %v", s, err)
+			}
+		}
+	})
+}
@@ -33,7 +33,6 @@
 package vrf
 
 import (
-	"bytes"
 	"crypto/ed25519"
 	"crypto/sha512"
 	"crypto/subtle"
@@ -195,15 +194,12 @@ func doVerify(
 	draftPreV11 bool,
 ) (bool, []byte) {
 	// 1.   Y = string_to_point(PK_string)
+	// 2.   If Y is "INVALID", output "INVALID" and stop
 	yString := pk
-	Y, err := edwards25519.NewIdentityPoint().SetBytes(yString)
+	Y, err := h2c.DecodeCanonicalPoint(yString) // Required by RFC 8032 decode semantics.
 	if err != nil {
 		return false, nil
 	}
-	// 2.   If Y is "INVALID", output "INVALID" and stop
-	if !bytes.Equal(Y.Bytes(), yString) { // Required by RFC 8032 decode semantics.
-		return false, nil
-	}
 	// 3.   If validate_key, run ECVRF_validate_key(Y) (Section 5.4.5); if
 	//      it outputs "INVALID", output "INVALID" and stop
 	cY := edwards25519.NewIdentityPoint().MultByCofactor(Y)
@@ -342,12 +338,9 @@ func decodeProof(piString []byte) (*edwards25519.Point, *edwards25519.Scalar, *e
 	// 4.  Gamma = string_to_point(gamma_string)
 	// 5.  if Gamma = "INVALID" output "INVALID" and stop.
 	gammaString := piString[:32]
-	gamma, err := edwards25519.NewIdentityPoint().SetBytes(gammaString[:32])
+	gamma, err := h2c.DecodeCanonicalPoint(gammaString) // Required by RFC 8032 decode semantics.
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("ecvrf: failed to decompress gamma: %w", err)
-	}
-	if subtle.ConstantTimeCompare(gamma.Bytes(), gammaString) != 1 { // Required by RFC 8032 decode semantics.
-		return nil, nil, nil, fmt.Errorf("ecvrf: non-canonical gamma")
+		return nil, nil, nil, fmt.Errorf("ecvrf: invalid gamma: %w", err)
 	}
 
 	// 6.  c = string_to_int(c_string)